@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sjrhee/crdp-cli-go/internal/access"
+	"github.com/sjrhee/crdp-cli-go/internal/config"
+	"github.com/sjrhee/crdp-cli-go/internal/redact"
+	"github.com/sjrhee/crdp-cli-go/internal/runner"
+)
+
+// runVerifyAccess는 표본 데이터를 protect한 뒤 신원마다 reveal하여 접근 정책(평문/마스킹/거부)을 검증하고 종료 코드를 반환합니다
+// exitOK: 모든 신원이 기대대로 reveal, exitFailed: 기대와 다른 결과, exitConnectivity: 연결 실패, exitConfigError: 설정 오류
+func runVerifyAccess(args []string) int {
+	fs := flag.NewFlagSet("verify-access", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to config.yaml file (default: auto-search)")
+	host := fs.String("host", "", "API host")
+	port := fs.Int("port", 0, "API port")
+	policy := fs.String("policy", "", "protection_policy_name")
+	useTLS := fs.String("tls", "", "use HTTPS (true/false, default: config value)")
+	jwtToken := fs.String("jwt-token", "", "JWT token for protecting samples and identities without their own")
+	username := fs.String("username", "", "username sent when protecting samples")
+	timeout := fs.Int("timeout", 0, "per-request timeout seconds")
+	samples := fs.Int("samples", 0, "number of sample values generated from execution.start_data")
+	inputFile := fs.String("input-file", "", "read sample values from file (.csv, .jsonl or one value per line)")
+	var identities stringList
+	fs.Var(&identities, "identity", "identity and expected reveal, e.g. \"alice=plain\" (repeatable)")
+	var headers stringList
+	fs.Var(&headers, "header", "extra HTTP header, e.g. \"X-Api-Key: secret\" (repeatable)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s verify-access [flags]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  --config string      path to config.yaml file (default: auto-search)\n")
+		fmt.Fprintf(os.Stderr, "  --host string        API host (default: config value)\n")
+		fmt.Fprintf(os.Stderr, "  --port int           API port (default: config value)\n")
+		fmt.Fprintf(os.Stderr, "  --policy string      protection_policy_name (default: config value)\n")
+		fmt.Fprintf(os.Stderr, "  --tls string         use HTTPS (true/false, default: config value)\n")
+		fmt.Fprintf(os.Stderr, "  --jwt-token string   JWT token for protecting samples and identities without their own (enables JWT)\n")
+		fmt.Fprintf(os.Stderr, "  --username string    username sent when protecting samples (default: protection.username)\n")
+		fmt.Fprintf(os.Stderr, "  --timeout int        per-request timeout seconds (default: api.timeout)\n")
+		fmt.Fprintf(os.Stderr, "  --samples int        number of sample values (default: access.sample_size)\n")
+		fmt.Fprintf(os.Stderr, "  --input-file string  read sample values from file instead of generating them from execution.start_data\n")
+		fmt.Fprintf(os.Stderr, "  --identity string    username and expected reveal: plain, masked or denied, e.g. \"alice=plain\"\n")
+		fmt.Fprintf(os.Stderr, "                       (repeatable, replaces access.identities)\n")
+		fmt.Fprintf(os.Stderr, "  --header string      extra HTTP header, e.g. \"X-Api-Key: secret\" (repeatable, overrides api.headers)\n")
+		fmt.Fprintf(os.Stderr, "\nExit codes: 0 access policy as expected, 1 deviation found, 2 config error, 3 connectivity failure\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitConfigError
+	}
+
+	cfg := loadConfig(*configPath)
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "host":
+			cfg.API.Host = *host
+			cfg.API.Hosts = nil
+		case "port":
+			cfg.API.Port = *port
+		case "policy":
+			cfg.Protection.Policy = *policy
+		case "tls":
+			cfg.API.TLS = *useTLS == "true"
+		case "jwt-token":
+			cfg.Auth.JWT = true
+			cfg.Auth.JWTToken = *jwtToken
+		case "username":
+			cfg.Protection.Username = *username
+		case "timeout":
+			cfg.API.Timeout = *timeout
+		case "samples":
+			cfg.Access.SampleSize = *samples
+		case "input-file":
+			cfg.Execution.InputFile = *inputFile
+		}
+	})
+	if len(identities) > 0 {
+		cfg.Access.Identities = nil
+		for _, s := range identities {
+			id, err := parseIdentity(s)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --identity: %v\n", err)
+				return exitConfigError
+			}
+			cfg.Access.Identities = append(cfg.Access.Identities, id)
+		}
+	}
+	if err := applyHeaders(cfg, headers); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid configuration: %v\n", err)
+		return exitConfigError
+	}
+	if len(cfg.Access.Identities) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no identities to verify (set access.identities or pass --identity)\n")
+		return exitConfigError
+	}
+	if cfg.Access.SampleSize == 0 {
+		fmt.Fprintf(os.Stderr, "Error: sample size must be positive\n")
+		return exitConfigError
+	}
+
+	data, err := accessSamples(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+	ids, err := accessIdentities(cfg.Access.Identities)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+
+	t := access.Target{
+		Host:     cfg.API.Host,
+		Port:     cfg.API.Port,
+		TLS:      cfg.API.TLS,
+		Timeout:  cfg.API.Timeout,
+		Policy:   cfg.Protection.Policy,
+		JWT:      cfg.Auth.JWT,
+		JWTToken: cfg.Auth.JWTToken,
+		Username: cfg.Protection.Username,
+		Headers:  cfg.API.Headers,
+		Limiter:  newLimiter(cfg),
+	}
+	if len(cfg.API.Hosts) > 0 {
+		// 접근 정책은 정책 설정이므로 첫 번째 호스트에서만 검증합니다
+		first := checkTargets(cfg)[0]
+		t.Host, t.Port = first.Host, first.Port
+	}
+
+	report := access.Run(context.Background(), t, data, ids)
+	mode, _ := redact.ParseMode(cfg.Output.Redact) // Validate에서 검증됨
+	report.Print(os.Stdout, redact.New(mode))
+
+	switch {
+	case report.ConnectivityFailed():
+		fmt.Printf("\nResult: FAIL (server unreachable)\n")
+		return exitConnectivity
+	case report.ProtectErr != nil:
+		fmt.Printf("\nResult: FAIL (samples could not be protected)\n")
+		return exitFailed
+	case report.Deviations() > 0:
+		fmt.Printf("\nResult: FAIL (%d of %d reveals deviate from the expected access policy)\n", report.Deviations(), len(report.Samples)*len(report.Results))
+		return exitFailed
+	}
+	fmt.Printf("\nResult: PASS (%d identities, %d samples each)\n", len(report.Results), len(report.Samples))
+	return exitOK
+}
+
+// parseIdentity는 "username=expect" 형식의 --identity 값을 신원 설정으로 변환합니다
+func parseIdentity(s string) (config.AccessIdentity, error) {
+	name, expect, ok := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return config.AccessIdentity{}, fmt.Errorf("invalid identity %q (expected \"username=plain|masked|denied\")", s)
+	}
+	o, err := access.ParseOutcome(expect)
+	if err != nil {
+		return config.AccessIdentity{}, err
+	}
+	return config.AccessIdentity{Name: name, Username: name, Expect: string(o)}, nil
+}
+
+// accessSamples는 검증에 사용할 표본 값을 만듭니다 (입력 파일이 있으면 파일 앞부분, 없으면 start_data부터 생성)
+func accessSamples(cfg *config.Config) ([]string, error) {
+	n := cfg.Access.SampleSize
+	if cfg.Execution.InputFile == "" {
+		return runner.GenerateDataSequence(cfg.Execution.StartData, n), nil
+	}
+	items, err := runner.LoadItems(cfg.Execution.InputFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > n {
+		items = items[:n]
+	}
+	data := make([]string, len(items))
+	for i, item := range items {
+		data[i] = item.Data
+	}
+	return data, nil
+}
+
+// accessIdentities는 설정의 신원 목록을 검증용 신원으로 변환합니다
+func accessIdentities(list []config.AccessIdentity) ([]access.Identity, error) {
+	ids := make([]access.Identity, 0, len(list))
+	for _, c := range list {
+		id := access.Identity{Name: c.Name, Username: c.Username, JWTToken: c.JWTToken, Expect: access.Outcome(c.Expect)}
+		if id.Name == "" {
+			id.Name = c.Username
+		}
+		if c.MaskPattern != "" {
+			re, err := regexp.Compile(c.MaskPattern)
+			if err != nil {
+				return nil, fmt.Errorf("identity %s: mask_pattern: %w", id.Name, err)
+			}
+			id.MaskPattern = re
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
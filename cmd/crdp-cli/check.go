@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sjrhee/crdp-cli-go/internal/check"
+	"github.com/sjrhee/crdp-cli-go/internal/config"
+)
+
+// runCheck는 서버 연결, TLS, 인증, canary 값 protect/reveal 왕복을 점검하고 종료 코드를 반환합니다
+// exitOK: 모든 항목 통과, exitFailed: 인증/정책/왕복 실패, exitConnectivity: 연결 실패, exitConfigError: 설정 오류
+func runCheck(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to config.yaml file (default: auto-search)")
+	host := fs.String("host", "", "API host")
+	port := fs.Int("port", 0, "API port")
+	policy := fs.String("policy", "", "protection_policy_name")
+	useTLS := fs.String("tls", "", "use HTTPS (true/false, default: config value)")
+	jwtToken := fs.String("jwt-token", "", "JWT token for authentication")
+	timeout := fs.Int("timeout", 0, "per-step timeout seconds")
+	canary := fs.String("canary", "", "value to protect and reveal (default: execution.start_data)")
+	var headers stringList
+	fs.Var(&headers, "header", "extra HTTP header, e.g. \"X-Api-Key: secret\" (repeatable)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s check [flags]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  --config string      path to config.yaml file (default: auto-search)\n")
+		fmt.Fprintf(os.Stderr, "  --host string        API host (default: config value)\n")
+		fmt.Fprintf(os.Stderr, "  --port int           API port (default: config value)\n")
+		fmt.Fprintf(os.Stderr, "  --policy string      protection_policy_name (default: config value)\n")
+		fmt.Fprintf(os.Stderr, "  --tls string         use HTTPS (true/false, default: config value)\n")
+		fmt.Fprintf(os.Stderr, "  --jwt-token string   JWT token for authentication (enables JWT)\n")
+		fmt.Fprintf(os.Stderr, "  --timeout int        per-step timeout seconds (default: api.timeout)\n")
+		fmt.Fprintf(os.Stderr, "  --canary string      value to protect and reveal (default: execution.start_data)\n")
+		fmt.Fprintf(os.Stderr, "  --header string      extra HTTP header, e.g. \"X-Api-Key: secret\" (repeatable, overrides api.headers)\n")
+		fmt.Fprintf(os.Stderr, "\nExit codes: 0 all checks passed, 1 auth/policy/round-trip failed, 2 config error, 3 connectivity failure\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitConfigError
+	}
+
+	cfg := loadConfig(*configPath)
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "host":
+			cfg.API.Host = *host
+			cfg.API.Hosts = nil
+		case "port":
+			cfg.API.Port = *port
+		case "policy":
+			cfg.Protection.Policy = *policy
+		case "tls":
+			cfg.API.TLS = *useTLS == "true"
+		case "jwt-token":
+			cfg.Auth.JWT = true
+			cfg.Auth.JWTToken = *jwtToken
+		case "timeout":
+			cfg.API.Timeout = *timeout
+		case "canary":
+			cfg.Execution.StartData = *canary
+		}
+	})
+	if err := applyHeaders(cfg, headers); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid configuration: %v\n", err)
+		return exitConfigError
+	}
+
+	code := exitOK
+	failed, warnings, total := 0, 0, 0
+	for i, t := range checkTargets(cfg) {
+		if i > 0 {
+			fmt.Println()
+		}
+		report := check.Run(t)
+		report.Print(os.Stdout)
+
+		failed += report.Failed()
+		warnings += report.Warnings()
+		total += len(report.Results)
+		switch {
+		case report.ConnectivityFailed():
+			code = worseExitCode(code, exitConnectivity)
+		case report.Failed() > 0:
+			code = worseExitCode(code, exitFailed)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("\nResult: FAIL (%d of %d checks failed)\n", failed, total)
+	} else {
+		fmt.Printf("\nResult: PASS (%d checks, %d warnings)\n", total, warnings)
+	}
+	return code
+}
+
+// checkTargets는 점검할 대상 목록을 만듭니다 (api.hosts가 있으면 호스트마다 하나)
+func checkTargets(cfg *config.Config) []check.Target {
+	base := check.Target{
+		Host:     cfg.API.Host,
+		Port:     cfg.API.Port,
+		TLS:      cfg.API.TLS,
+		Timeout:  cfg.API.Timeout,
+		Policy:   cfg.Protection.Policy,
+		JWT:      cfg.Auth.JWT,
+		JWTToken: cfg.Auth.JWTToken,
+		Headers:  cfg.API.Headers,
+		Canary:   cfg.Execution.StartData,
+	}
+	if len(cfg.API.Hosts) == 0 {
+		return []check.Target{base}
+	}
+
+	targets := make([]check.Target, 0, len(cfg.API.Hosts))
+	for _, h := range cfg.API.Hosts {
+		t := base
+		t.Host = strings.TrimSpace(h)
+		if host, port, err := net.SplitHostPort(t.Host); err == nil {
+			t.Host = host
+			if p, err := strconv.Atoi(port); err == nil {
+				t.Port = p
+			}
+		}
+		targets = append(targets, t)
+	}
+	return targets
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sjrhee/crdp-cli-go/internal/compare"
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+)
+
+// runCompare는 두 결과 파일을 비교하고 종료 코드를 반환합니다
+// exitOK: 회귀 없음, exitFailed: 회귀 감지, exitConfigError: 사용법 또는 파일 오류, 비교할 행 없음
+func runCompare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path to config.yaml file (default: auto-search)")
+	threshold := fs.Float64("threshold", 0, "max allowed throughput/latency regression in percent")
+	maxErrorIncrease := fs.Float64("max-error-increase", 0, "max allowed error rate increase in percentage points")
+	alpha := fs.Float64("alpha", 0, "significance level for statistical tests")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s compare [flags] base.json new.json\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  --config string              path to config.yaml file (default: auto-search)\n")
+		fmt.Fprintf(os.Stderr, "  --threshold float            max allowed throughput/latency regression in percent (default 10)\n")
+		fmt.Fprintf(os.Stderr, "  --max-error-increase float   max allowed error rate increase in percentage points (default 1)\n")
+		fmt.Fprintf(os.Stderr, "  --alpha float                significance level for statistical tests (default 0.05)\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitConfigError
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return exitConfigError
+	}
+
+	cfg := loadConfig(*configPath)
+	th := compare.Thresholds{
+		MaxRegressionPct:     cfg.Compare.MaxRegressionPct,
+		MaxErrorRateIncrease: cfg.Compare.MaxErrorRateIncrease,
+		Alpha:                cfg.Compare.Alpha,
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "threshold":
+			th.MaxRegressionPct = *threshold
+		case "max-error-increase":
+			th.MaxErrorRateIncrease = *maxErrorIncrease
+		case "alpha":
+			th.Alpha = *alpha
+		}
+	})
+
+	base, err := output.ReadResults(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+	current, err := output.ReadResults(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+
+	report := compare.Compare(base, current, th)
+	report.Print(os.Stdout)
+
+	// 비교한 행이 없으면 회귀 여부를 판단할 수 없으므로 통과로 처리하지 않음
+	if len(report.Rows) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no matching row names between %s and %s, nothing was compared\n", fs.Arg(0), fs.Arg(1))
+		return exitConfigError
+	}
+	if len(report.OnlyBase) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d row(s) from %s are missing in %s and were not compared\n", len(report.OnlyBase), fs.Arg(0), fs.Arg(1))
+	}
+
+	if report.Regressed() {
+		fmt.Printf("\nResult: REGRESSION detected\n")
+		return exitFailed
+	}
+	fmt.Printf("\nResult: no regression\n")
+	return exitOK
+}
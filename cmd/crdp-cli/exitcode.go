@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+)
+
+// 종료 코드
+const (
+	exitOK           = 0 // 성공
+	exitFailed       = 1 // SLO 조건 실패, 성공한 반복 없음, 또는 compare 회귀 감지
+	exitConfigError  = 2 // 설정/사용법 오류
+	exitConnectivity = 3 // 서버에 연결할 수 없음 (모든 요청이 전송 오류)
+)
+
+// stringList는 여러 번 지정할 수 있는 문자열 플래그입니다
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// connectivityFailed는 모든 요청이 응답 없이 전송 오류로 끝났는지 확인합니다
+// 보내기 전에 실패한 요청(JWT 토큰 공급자 오류 등)만 있었다면 서버 연결 문제가 아니므로 제외합니다
+func connectivityFailed(row output.Row) bool {
+	setup := row.ErrorsByCategory[string(client.CategoryRequestSetup)]
+	return row.Errors > setup && row.Samples == 0
+}
+
+// worseExitCode는 두 종료 코드 중 우선순위가 높은 것을 반환합니다
+// 우선순위: 설정 오류 > 연결 실패 > 실패 > 성공
+func worseExitCode(a, b int) int {
+	rank := func(code int) int {
+		switch code {
+		case exitConfigError:
+			return 3
+		case exitConnectivity:
+			return 2
+		case exitFailed:
+			return 1
+		}
+		return 0
+	}
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
@@ -3,48 +3,148 @@ package main
 import (
 	"flag"
 	"fmt"
-	"log"
 	"os"
-	"strconv"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/sjrhee/crdp-cli-go/internal/assertion"
+	"github.com/sjrhee/crdp-cli-go/internal/cassette"
 	"github.com/sjrhee/crdp-cli-go/internal/client"
 	"github.com/sjrhee/crdp-cli-go/internal/config"
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+	"github.com/sjrhee/crdp-cli-go/internal/metrics"
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+	"github.com/sjrhee/crdp-cli-go/internal/redact"
 	"github.com/sjrhee/crdp-cli-go/internal/runner"
 )
 
-// generateDataSequence generates a sequence of data strings starting from startData
-func generateDataSequence(startData string, count int) []string {
-	inputs := make([]string, 0, count)
-	
-	// Parse initial value as int64 for efficient incrementing
-	currentNum, err := strconv.ParseInt(startData, 10, 64)
-	if err != nil {
-		// If parse fails, just return the startData repeated
-		for i := 0; i < count; i++ {
-			inputs = append(inputs, startData)
+// printSummary prints execution summary
+func printSummary(s *runner.Summary) {
+	fmt.Printf("\nSummary\n")
+	fmt.Printf("- Iterations attempted: %d\n", s.Attempted)
+	fmt.Printf("- Successful (both 2xx): %d\n", s.Successful)
+	fmt.Printf("- Revealed matched original data: %d\n", s.Matched)
+	fmt.Printf("- Total time: %.4fs\n", s.TotalTime.Seconds())
+	if s.Attempted > 0 {
+		avgTime := s.TotalTime.Seconds() / float64(s.Attempted)
+		fmt.Printf("- Average per-iteration time: %.4fs\n", avgTime)
+		fmt.Printf("- Throughput: %.1f items/s\n", s.Throughput())
+	}
+	if len(s.Latencies) > 0 {
+		stats := s.LatencyStats()
+		fmt.Printf("- Latency p50/p95/p99: %.4fs / %.4fs / %.4fs\n", stats.P50, stats.P95, stats.P99)
+	}
+	if len(s.Categories) > 0 {
+		printCategories(s.Categories)
+	}
+	if s.TokensChecked > 0 {
+		printViolations(s.TokensChecked, s.RepeatChecked, s.Violations)
+	}
+	if s.ThrottledRequests > 0 {
+		fmt.Printf("- Rate limit wait: %.4fs total (%d requests throttled)\n", s.Throttled.Seconds(), s.ThrottledRequests)
+	}
+	if s.Phases.Requests > 0 {
+		printPhases(&s.Phases)
+	}
+}
+
+// printCategories prints failed requests by error category, most frequent first
+func printCategories(categories map[string]int) {
+	names := make([]string, 0, len(categories))
+	total := 0
+	for name, n := range categories {
+		names = append(names, name)
+		total += n
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if categories[names[i]] != categories[names[j]] {
+			return categories[names[i]] > categories[names[j]]
 		}
-		return inputs
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%d", name, categories[name])
 	}
-	
-	// Generate sequence by incrementing numeric value
-	for i := 0; i < count; i++ {
-		inputs = append(inputs, strconv.FormatInt(currentNum+int64(i), 10))
+	fmt.Printf("- Failed requests: %d (%s)\n", total, strings.Join(parts, ", "))
+}
+
+// printViolations prints token validation results by check
+// repeated is the number of sample tokens re-protected after the run for the deterministic/randomized check
+func printViolations(checked, repeated int, violations map[string]int) {
+	counts := fmt.Sprintf("%d tokens checked", checked)
+	if repeated > 0 {
+		counts += fmt.Sprintf(" (%d re-protected after the run)", repeated)
+	}
+	if len(violations) == 0 {
+		fmt.Printf("- Token validation: %s, no violations\n", counts)
+		return
+	}
+	names := make([]string, 0, len(violations))
+	total := 0
+	for name, n := range violations {
+		names = append(names, name)
+		total += n
 	}
-	return inputs
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%d", name, violations[name])
+	}
+	fmt.Printf("- Token validation: %s, %d violations (%s)\n", counts, total, strings.Join(parts, ", "))
 }
 
-// printSummary prints execution summary
-// printSummary prints execution summary
-func printSummary(attempted, successful, matched int, totalTime time.Duration) {
-	fmt.Printf("\nSummary\n")
-	fmt.Printf("- Iterations attempted: %d\n", attempted)
-	fmt.Printf("- Successful (both 2xx): %d\n", successful)
-	fmt.Printf("- Revealed matched original data: %d\n", matched)
-	fmt.Printf("- Total time: %.4fs\n", totalTime.Seconds())
-	if attempted > 0 {
-		avgTime := totalTime.Seconds() / float64(attempted)
-		fmt.Printf("- Average per-iteration time: %.4fs\n", avgTime)
+// printPhases prints connection-phase timing breakdown
+func printPhases(p *runner.PhaseSummary) {
+	stats := p.Stats()
+	fmt.Printf("\nConnection phases (mean / p95 / max)\n")
+	printPhase := func(name string, st runner.LatencyStats) {
+		if st.Count == 0 {
+			fmt.Printf("- %-13s -\n", name+":")
+			return
+		}
+		fmt.Printf("- %-13s %.2fms / %.2fms / %.2fms (n=%d)\n", name+":", st.Mean*1000, st.P95*1000, st.Max*1000, st.Count)
+	}
+	printPhase("DNS", stats.DNS)
+	printPhase("TCP connect", stats.Connect)
+	printPhase("TLS", stats.TLS)
+	printPhase("TTFB", stats.TTFB)
+	printPhase("Body read", stats.BodyRead)
+	fmt.Printf("- Connections: %d new, %d reused (%.1f%% reuse)\n",
+		p.NewConns, p.ReusedConns, float64(p.ReusedConns)/float64(p.Requests)*100)
+}
+
+// printBreaker prints circuit breaker state transitions
+func printBreaker(b *client.Breaker) {
+	transitions := b.Transitions()
+	if len(transitions) == 0 {
+		return
+	}
+	fmt.Printf("\nCircuit breaker\n")
+	fmt.Printf("- State: %s\n", b.State())
+	fmt.Printf("- Rejected requests: %d\n", b.Rejected())
+	fmt.Printf("- Transitions: %d\n", len(transitions))
+	const maxShown = 20
+	if len(transitions) > maxShown {
+		fmt.Printf("  ... %d earlier transitions omitted\n", len(transitions)-maxShown)
+		transitions = transitions[len(transitions)-maxShown:]
+	}
+	for _, t := range transitions {
+		if t.To == client.BreakerOpen {
+			fmt.Printf("  %s %s -> %s (error ratio %.1f%%)\n", t.At.Format("15:04:05.000"), t.From, t.To, t.ErrorRatio*100)
+		} else {
+			fmt.Printf("  %s %s -> %s\n", t.At.Format("15:04:05.000"), t.From, t.To)
+		}
+	}
+}
+
+// printHosts prints per-host request statistics
+func printHosts(hosts []output.HostStats) {
+	fmt.Printf("\nHosts\n")
+	for _, h := range hosts {
+		fmt.Printf("- %s: requests=%d errors=%d ejections=%d mean=%.2fms p99=%.2fms\n",
+			h.Host, h.Requests, h.Errors, h.Ejections, h.LatencyMean, h.LatencyP99)
 	}
 }
 
@@ -65,63 +165,163 @@ func printIterationProgress(iterNum int, data string, timeS float64, protectStat
 	}
 }
 
+// loadConfig는 지정된 경로(비어 있으면 자동 검색)에서 설정을 로드합니다
+// 설정 파일을 읽거나 파싱할 수 없으면 설정 오류 코드로 종료합니다
+func loadConfig(configPath string) *config.Config {
+	var cfg *config.Config
+	var err error
+	if configPath != "" {
+		// 명시적으로 지정된 config 파일 사용
+		cfg, err = config.LoadConfig(configPath)
+	} else {
+		// 자동 검색
+		cfg, err = config.LoadConfig(config.GetConfigPath())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load config file: %v\n", err)
+		os.Exit(exitConfigError)
+	}
+	return cfg
+}
+
 func main() {
+	// 서브커맨드 처리
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "compare":
+			os.Exit(runCompare(os.Args[2:]))
+		case "check":
+			os.Exit(runCheck(os.Args[2:]))
+		case "mock-server":
+			os.Exit(runMockServer(os.Args[2:]))
+		case "proxy":
+			os.Exit(runProxy(os.Args[2:]))
+		case "replay":
+			os.Exit(runReplay(os.Args[2:]))
+		case "verify-access":
+			os.Exit(runVerifyAccess(os.Args[2:]))
+		}
+	}
+
 	// 모든 플래그 정의 (기본값은 공백/0/false로 설정)
 	configPath := flag.String("config", "", "path to config.yaml file (default: auto-search)")
-	
+
 	// CLI 플래그 정의 (기본값을 빈 값이나 0으로 설정하여 명시적 제공 여부 감지)
 	host := flag.String("host", "", "API host")
 	port := flag.Int("port", 0, "API port")
+	hosts := flag.String("hosts", "", "comma-separated CRDP hosts (host or host:port) to balance across")
+	balance := flag.String("balance", "", "host selection: round-robin, least-inflight, random")
 	policy := flag.String("policy", "", "protection_policy_name")
+	policies := flag.String("policies", "", "run several policies in one invocation, e.g. \"P03:3,P04:1\" (name:weight)")
+	policyMode := flag.String("policy-mode", "", "multi-policy mode: mix (weighted, default) or iterate (every item per policy)")
 	startData := flag.String("start-data", "", "numeric data to start from")
 	iterations := flag.Int("iterations", 0, "number of iterations")
+	inputFile := flag.String("input-file", "", "read input items from file (.csv, .jsonl or one value per line)")
+	username := flag.String("username", "", "username sent with protect/reveal requests")
+	externalVersion := flag.String("external-version", "", "external_version sent with reveal requests when protect returns none")
 	timeout := flag.Int("timeout", 0, "per-request timeout seconds")
-	verbose := flag.Bool("verbose", false, "enable debug logging")
+	verbose := flag.Bool("verbose", false, "enable debug logging (same as --log-level debug)")
+	logLevel := flag.String("log-level", "", "log level: debug, info, warn, error (default info)")
+	logFormat := flag.String("log-format", "", "log format: text, json (default text)")
+	logFile := flag.String("log-file", "", "append logs to file instead of stderr")
 	showProgress := flag.Bool("show-progress", false, "show per-iteration progress output")
 	showBody := flag.Bool("show-body", false, "show request/response URLs and JSON bodies")
+	redactMode := flag.String("redact", "", "redact data/tokens in debug output: none, mask, hash, length (default mask)")
 	useBulk := flag.Bool("bulk", false, "use bulk protect/reveal endpoints")
 	batchSize := flag.Int("batch-size", 0, "batch size for bulk operations")
 	useTLSFlag := flag.String("tls", "", "use HTTPS (true/false, default: config value)")
 	jwtFlag := flag.String("jwt", "", "enable JWT authentication (true/false)")
 	jwtTokenFlag := flag.String("jwt-token", "", "JWT token for authentication")
+	workers := flag.Int("workers", 0, "number of concurrent workers")
+	rateLimit := flag.Float64("rate-limit", 0, "max requests per second across all endpoints (0 = unlimited)")
+	itemRateLimit := flag.Float64("item-rate-limit", 0, "max data items per second, bulk requests count batch size (0 = unlimited)")
+	maxInFlight := flag.Int("max-in-flight", 0, "max concurrent requests (0 = unlimited)")
+	circuitBreaker := flag.Bool("circuit-breaker", false, "fail fast while the server keeps failing (see circuit_breaker in config)")
+	useMatrix := flag.Bool("matrix", false, "run every combination of the matrix section in config")
+	outputFile := flag.String("output", "", "write results to file (.json or .csv)")
+	reportFile := flag.String("report", "", "write self-contained HTML report to file")
+	junitFile := flag.String("junit", "", "write JUnit XML report to file")
+	tapFile := flag.String("tap", "", "write TAP report to file")
+	metricsAddr := flag.String("metrics-addr", "", "serve Prometheus metrics on this address (e.g. :9090)")
+	recordFile := flag.String("record", "", "record every request/response pair to a cassette file")
+	recordRedact := flag.String("record-redact", "", "redact data/tokens in the cassette: none, mask, hash, length (default mask)")
+	traceFile := flag.String("trace-file", "", "write OTLP/JSON trace spans to file")
+	traceEndpoint := flag.String("trace-endpoint", "", "send OTLP/HTTP trace spans to collector (e.g. http://localhost:4318/v1/traces)")
+	live := flag.Bool("live", false, "show live dashboard (interval reports when stdout is not a terminal)")
+	phaseTiming := flag.Bool("phase-timing", false, "measure DNS/connect/TLS/TTFB/body read time per request")
+	var asserts stringList
+	flag.Var(&asserts, "assert", "SLO assertion evaluated after the run, e.g. \"p99 < 50ms\" (repeatable)")
+	var tokenChecks stringList
+	flag.Var(&tokenChecks, "token-check", "validate protected tokens, e.g. \"same_length,prefix=6\" or \"deterministic\" (repeatable)")
+	var headers stringList
+	flag.Var(&headers, "header", "extra HTTP header sent with every request, e.g. \"X-Api-Key: secret\" (repeatable)")
 
 	// 커스텀 Usage 함수
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s [flags]                         run protect/reveal iterations\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s compare [flags] base.json new.json  compare two results files\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s check [flags]                   verify connectivity, TLS, auth and a canary round-trip\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s mock-server [flags]             serve an in-memory fake CRDP API for tests and demos\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s proxy --target URL [flags]      forward to CRDP while injecting latency, resets and errors\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s replay [flags] cassette.jsonl   re-send recorded requests or serve recorded responses\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s verify-access [flags]           reveal sample tokens as each identity and check plain/masked/denied\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  --config string          path to config.yaml file (default: auto-search)\n")
 		fmt.Fprintf(os.Stderr, "  --host string            API host (default \"192.168.0.231\")\n")
 		fmt.Fprintf(os.Stderr, "  --port int               API port (default 32082)\n")
+		fmt.Fprintf(os.Stderr, "  --hosts string           comma-separated CRDP hosts (host or host:port) to balance across\n")
+		fmt.Fprintf(os.Stderr, "  --balance string         host selection: round-robin, least-inflight, random (default \"round-robin\")\n")
 		fmt.Fprintf(os.Stderr, "  --policy string          protection_policy_name (default \"P03\")\n")
+		fmt.Fprintf(os.Stderr, "  --policies string        run several policies in one invocation, e.g. \"P03:3,P04:1\" (name:weight)\n")
+		fmt.Fprintf(os.Stderr, "  --policy-mode string     multi-policy mode: mix (weighted, default) or iterate (every item per policy)\n")
 		fmt.Fprintf(os.Stderr, "  --start-data string      numeric data to start from (default \"1234567890123\")\n")
 		fmt.Fprintf(os.Stderr, "  --iterations int         number of iterations (default 100)\n")
+		fmt.Fprintf(os.Stderr, "  --input-file string      read input items from file (.csv, .jsonl or one value per line), replaces start-data/iterations\n")
+		fmt.Fprintf(os.Stderr, "  --username string        username sent with protect/reveal requests\n")
+		fmt.Fprintf(os.Stderr, "  --external-version string external_version sent with reveal requests when protect returns none\n")
 		fmt.Fprintf(os.Stderr, "  --timeout int            per-request timeout seconds (default 10)\n")
-		fmt.Fprintf(os.Stderr, "  --verbose                enable debug logging\n")
+		fmt.Fprintf(os.Stderr, "  --verbose                enable debug logging (same as --log-level debug)\n")
+		fmt.Fprintf(os.Stderr, "  --log-level string       log level: debug, info, warn, error (default \"info\")\n")
+		fmt.Fprintf(os.Stderr, "  --log-format string      log format: text, json (default \"text\")\n")
+		fmt.Fprintf(os.Stderr, "  --log-file string        append logs to file instead of stderr\n")
 		fmt.Fprintf(os.Stderr, "  --show-progress          show per-iteration progress output\n")
 		fmt.Fprintf(os.Stderr, "  --show-body              show request/response URLs and JSON bodies\n")
+		fmt.Fprintf(os.Stderr, "  --redact string          redact data/tokens in debug output: none, mask, hash, length (default \"mask\")\n")
 		fmt.Fprintf(os.Stderr, "  --bulk                   use bulk protect/reveal endpoints\n")
 		fmt.Fprintf(os.Stderr, "  --batch-size int         batch size for bulk operations (default 50)\n")
 		fmt.Fprintf(os.Stderr, "  --tls string             use HTTPS (true/false, default: config value)\n")
 		fmt.Fprintf(os.Stderr, "  --jwt string             enable JWT authentication (true/false)\n")
 		fmt.Fprintf(os.Stderr, "  --jwt-token string       JWT token for authentication\n")
+		fmt.Fprintf(os.Stderr, "  --header string          extra HTTP header, e.g. \"X-Api-Key: secret\" (repeatable, overrides api.headers)\n")
+		fmt.Fprintf(os.Stderr, "  --workers int            number of concurrent workers (default 1)\n")
+		fmt.Fprintf(os.Stderr, "  --rate-limit float       max requests per second across all endpoints (0 = unlimited)\n")
+		fmt.Fprintf(os.Stderr, "  --item-rate-limit float  max data items per second, bulk requests count batch size (0 = unlimited)\n")
+		fmt.Fprintf(os.Stderr, "  --max-in-flight int      max concurrent requests (0 = unlimited)\n")
+		fmt.Fprintf(os.Stderr, "  --circuit-breaker        fail fast while the server keeps failing (see circuit_breaker in config)\n")
+		fmt.Fprintf(os.Stderr, "  --matrix                 run every combination of the matrix section in config\n")
+		fmt.Fprintf(os.Stderr, "  --output string          write results to file (.json or .csv)\n")
+		fmt.Fprintf(os.Stderr, "  --report string          write self-contained HTML report to file\n")
+		fmt.Fprintf(os.Stderr, "  --junit string           write JUnit XML report to file\n")
+		fmt.Fprintf(os.Stderr, "  --tap string             write TAP report to file\n")
+		fmt.Fprintf(os.Stderr, "  --metrics-addr string    serve Prometheus metrics on this address (e.g. :9090)\n")
+		fmt.Fprintf(os.Stderr, "  --live                   show live dashboard (interval reports when stdout is not a terminal)\n")
+		fmt.Fprintf(os.Stderr, "  --phase-timing           measure DNS/connect/TLS/TTFB/body read time per request\n")
+		fmt.Fprintf(os.Stderr, "  --record string          record every request/response pair to a cassette file\n")
+		fmt.Fprintf(os.Stderr, "  --record-redact string   redact data/tokens in the cassette: none, mask, hash, length (default \"mask\")\n")
+		fmt.Fprintf(os.Stderr, "  --trace-file string      write OTLP/JSON trace spans to file\n")
+		fmt.Fprintf(os.Stderr, "  --trace-endpoint string  send OTLP/HTTP trace spans to collector (e.g. http://localhost:4318/v1/traces)\n")
+		fmt.Fprintf(os.Stderr, "  --assert string          SLO assertion, e.g. \"p99 < 50ms\" (repeatable)\n")
+		fmt.Fprintf(os.Stderr, "  --token-check string     validate protected tokens: same_length, same_charset, prefix=N, suffix=N,\n")
+		fmt.Fprintf(os.Stderr, "                           not_input, deterministic, randomized (repeatable, comma-separated)\n")
+		fmt.Fprintf(os.Stderr, "                           (deterministic/randomized re-protect up to %d sample tokens per policy after the run)\n", runner.RepeatSampleSize)
+		fmt.Fprintf(os.Stderr, "\nExit codes: 0 ok, 1 assertion/run failed, 2 config error, 3 connectivity failure\n")
 	}
 
 	// 플래그 파싱
 	flag.Parse()
 
 	// 설정 파일 로드
-	var cfg *config.Config
-	var err error
-	if *configPath != "" {
-		// 명시적으로 지정된 config 파일 사용
-		cfg, err = config.LoadConfig(*configPath)
-	} else {
-		// 자동 검색
-		cfg, err = config.LoadConfig(config.GetConfigPath())
-	}
-	if err != nil {
-		log.Printf("Warning: failed to load config file: %v. Using defaults.\n", err)
-		cfg = config.DefaultConfig()
-	}
+	cfg := loadConfig(*configPath)
 
 	// CLI 플래그로 설정된 값이 있으면 config 값을 오버라이드
 	// flag.Visit()를 사용하여 실제로 명시적으로 제공된 플래그만 처리
@@ -135,10 +335,30 @@ func main() {
 			if *port != 0 {
 				cfg.API.Port = *port
 			}
+		case "hosts":
+			if *hosts != "" {
+				cfg.API.Hosts = strings.Split(*hosts, ",")
+			}
+		case "balance":
+			if *balance != "" {
+				cfg.API.Balance = *balance
+			}
 		case "policy":
 			if *policy != "" {
 				cfg.Protection.Policy = *policy
 			}
+		case "policies":
+			list, err := runner.ParsePolicies(*policies)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --policies: %v\n", err)
+				os.Exit(exitConfigError)
+			}
+			cfg.Protection.Policies = nil
+			for _, p := range list {
+				cfg.Protection.Policies = append(cfg.Protection.Policies, config.PolicyWeight{Name: p.Name, Weight: p.Weight})
+			}
+		case "policy-mode":
+			cfg.Protection.PolicyMode = *policyMode
 		case "start-data":
 			if *startData != "" {
 				cfg.Execution.StartData = *startData
@@ -147,16 +367,46 @@ func main() {
 			if *iterations != 0 {
 				cfg.Execution.Iterations = *iterations
 			}
+		case "input-file":
+			cfg.Execution.InputFile = *inputFile
+		case "username":
+			cfg.Protection.Username = *username
+		case "external-version":
+			cfg.Protection.ExternalVersion = *externalVersion
 		case "timeout":
 			if *timeout != 0 {
 				cfg.API.Timeout = *timeout
 			}
 		case "verbose":
 			cfg.Output.Verbose = *verbose
+		case "rate-limit":
+			cfg.RateLimit.RequestsPerSec = *rateLimit
+		case "item-rate-limit":
+			cfg.RateLimit.ItemsPerSec = *itemRateLimit
+		case "max-in-flight":
+			cfg.RateLimit.MaxInFlight = *maxInFlight
+		case "circuit-breaker":
+			cfg.CircuitBreaker.Enabled = *circuitBreaker
+		case "log-level":
+			if *logLevel != "" {
+				cfg.Logging.Level = *logLevel
+			}
+		case "log-format":
+			if *logFormat != "" {
+				cfg.Logging.Format = *logFormat
+			}
+		case "log-file":
+			if *logFile != "" {
+				cfg.Logging.File = *logFile
+			}
 		case "show-progress":
 			cfg.Output.ShowProgress = *showProgress
 		case "show-body":
 			cfg.Output.ShowBody = *showBody
+		case "redact":
+			if *redactMode != "" {
+				cfg.Output.Redact = *redactMode
+			}
 		case "bulk":
 			cfg.Batch.Enabled = *useBulk
 		case "batch-size":
@@ -167,6 +417,48 @@ func main() {
 			if *useTLSFlag != "" {
 				cfg.API.TLS = *useTLSFlag == "true"
 			}
+		case "workers":
+			if *workers != 0 {
+				cfg.Parallel.Workers = *workers
+			}
+		case "matrix":
+			cfg.Matrix.Enabled = *useMatrix
+		case "output":
+			if *outputFile != "" {
+				cfg.Output.File = *outputFile
+			}
+		case "report":
+			if *reportFile != "" {
+				cfg.Output.Report = *reportFile
+			}
+		case "junit":
+			if *junitFile != "" {
+				cfg.Output.JUnit = *junitFile
+			}
+		case "tap":
+			if *tapFile != "" {
+				cfg.Output.TAP = *tapFile
+			}
+		case "metrics-addr":
+			if *metricsAddr != "" {
+				cfg.Metrics.Addr = *metricsAddr
+			}
+		case "live":
+			cfg.Output.Live = *live
+		case "phase-timing":
+			cfg.Output.PhaseTiming = *phaseTiming
+		case "record":
+			cfg.Output.Record = *recordFile
+		case "record-redact":
+			cfg.Output.RecordRedact = *recordRedact
+		case "trace-file":
+			if *traceFile != "" {
+				cfg.Tracing.File = *traceFile
+			}
+		case "trace-endpoint":
+			if *traceEndpoint != "" {
+				cfg.Tracing.Endpoint = *traceEndpoint
+			}
 		case "jwt":
 			if *jwtFlag != "" {
 				// jwt 플래그는 별도 처리
@@ -179,104 +471,128 @@ func main() {
 	})
 
 	// JWT 설정 처리
-	jwtEnabled := cfg.Auth.JWT
-	jwtToken := cfg.Auth.JWTToken
 	if *jwtFlag != "" {
-		jwtEnabled = *jwtFlag == "true"
+		cfg.Auth.JWT = *jwtFlag == "true"
 	}
 	if *jwtTokenFlag != "" {
-		jwtToken = *jwtTokenFlag
+		cfg.Auth.JWTToken = *jwtTokenFlag
+	}
+
+	// verbose는 --log-level이 명시되지 않았을 때 debug 레벨로 동작
+	if cfg.Output.Verbose && *logLevel == "" {
+		cfg.Logging.Level = "debug"
 	}
 
 	// show-body가 활성화되면 show-progress도 자동 활성화
+	// 요청/응답 본문 출력과 겹치지 않도록 대시보드는 비활성화
 	if cfg.Output.ShowBody {
 		cfg.Output.ShowProgress = true
+		cfg.Output.Live = false
 	}
 
-	// 클라이언트 생성
-	c := client.NewClient(cfg.API.Host, cfg.API.Port, cfg.Protection.Policy, cfg.API.Timeout, cfg.API.TLS)
-	c.SetShowBody(cfg.Output.ShowBody)
-	
-	// verbose 로그 출력
-	if cfg.Output.Verbose {
-		log.Printf("Config loaded: JWT enabled=%v", jwtEnabled)
-	}
-	
-	c.SetJWT(jwtEnabled, jwtToken)
-
-	// 반복 실행
-	startTime := time.Now()
-	successfulItems := 0
-	matchedItems := 0
-	totalItems := 0
-
-	if cfg.Batch.Enabled {
-		// Bulk 모드: 입력 데이터 생성
-		inputs := generateDataSequence(cfg.Execution.StartData, cfg.Execution.Iterations)
-
-		// 배치 단위로 처리
-		for i := 0; i < len(inputs); i += cfg.Batch.Size {
-			end := i + cfg.Batch.Size
-			if end > len(inputs) {
-				end = len(inputs)
-			}
-			batch := inputs[i:end]
+	// 입력 파일이 있으면 파일의 항목 수만큼 반복 (설정 검증이 덮어쓴 반복 횟수를 보도록 검증 전에 읽음)
+	var items []runner.Item
+	if cfg.Execution.InputFile != "" {
+		var err error
+		items, err = runner.LoadItems(cfg.Execution.InputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitConfigError)
+		}
+		cfg.Execution.Iterations = len(items)
+	}
 
-			result, err := runner.RunBulkIteration(c, batch)
-			if err != nil {
-				if cfg.Output.Verbose {
-					log.Printf("Error at batch %d: %v", i/cfg.Batch.Size+1, err)
-				}
-				continue
-			}
+	// 설정 검증 및 SLO 조건 파싱
+	cfg.Assertions = append(cfg.Assertions, asserts...)
+	cfg.TokenChecks = append(cfg.TokenChecks, tokenChecks...)
+	if err := applyHeaders(cfg, headers); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfigError)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid configuration: %v\n", err)
+		os.Exit(exitConfigError)
+	}
+	assertions, err := assertion.ParseAll(cfg.Assertions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfigError)
+	}
+	validator, err := runner.ParseTokenChecks(cfg.TokenChecks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfigError)
+	}
 
-			totalItems += len(batch)
+	// 로거 생성 (클라이언트, 러너 등 모든 구성요소가 공유)
+	logger, logCloser, err := logging.New(logging.Options{
+		Level:  cfg.Logging.Level,
+		Format: cfg.Logging.Format,
+		File:   cfg.Logging.File,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfigError)
+	}
+	logger.Debug("config loaded", "config", cfg)
 
-			// 성공 및 매칭된 항목 카운트
-			if result.Success {
-				successfulItems += result.RestoredCount
-			}
-			matchedItems += result.MatchedCount
+	mode, _ := redact.ParseMode(cfg.Output.Redact) // Validate에서 검증됨
+	a := &app{cfg: cfg, assertions: assertions, validator: validator, items: items, redactor: redact.New(mode), logger: logger, limiter: newLimiter(cfg)}
+	a.breaker = newBreaker(cfg, logger)
 
-			if cfg.Output.ShowProgress {
-				printBulkProgress(i/cfg.Batch.Size+1, len(batch), result.TimeS,
-					result.ProtectResponse.StatusCode, result.RevealResponse.StatusCode, result.MatchedCount)
-			}
+	// Prometheus 메트릭 리스너
+	if cfg.Metrics.Addr != "" {
+		a.metrics = metrics.NewRegistry()
+		if _, err := metrics.Serve(cfg.Metrics.Addr, a.metrics); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitConfigError)
 		}
-	} else {
-		// 일반 모드 (단일 처리)
-		dataSequence := generateDataSequence(cfg.Execution.StartData, cfg.Execution.Iterations)
-		for i, data := range dataSequence {
-			iterNum := i + 1
-			result, err := runner.RunIteration(c, data)
-			if err != nil {
-				if cfg.Output.Verbose {
-					log.Printf("Error at iteration %d: %v", iterNum, err)
-				}
-				continue
-			}
+		logger.Info("metrics endpoint listening", "url", "http://"+cfg.Metrics.Addr+"/metrics")
+	}
 
-			if result.Success {
-				successfulItems++
-			}
-			if result.Match {
-				matchedItems++
-			}
+	// OpenTelemetry 트레이싱
+	tracer, err := newTracer(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfigError)
+	}
+	a.tracer = tracer
 
-			if cfg.Output.ShowProgress {
-				printIterationProgress(iterNum, data, result.TimeS,
-					result.ProtectResponse.StatusCode, result.RevealResponse.StatusCode,
-					result.Match, cfg.Output.ShowBody)
-			}
+	// 요청/응답 카세트 기록
+	if cfg.Output.Record != "" {
+		recordMode, _ := redact.ParseMode(cfg.Output.RecordRedact) // Validate에서 검증됨
+		a.recorder, err = cassette.NewRecorder(cfg.Output.Record, redact.New(recordMode))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitConfigError)
 		}
 	}
 
-	total := time.Since(startTime)
-
-	// 결과 출력
-	if cfg.Batch.Enabled {
-		printSummary(totalItems, successfulItems, matchedItems, total)
+	var code int
+	if cfg.Matrix.Enabled {
+		code = a.runMatrix()
 	} else {
-		printSummary(cfg.Execution.Iterations, successfulItems, matchedItems, total)
+		code = a.runSingle()
+	}
+
+	if a.recorder != nil {
+		if err := a.recorder.Close(); err != nil {
+			logger.Warn("failed to write cassette", "error", err)
+		} else {
+			fmt.Printf("Recorded %d requests to %s\n", a.recorder.Count(), cfg.Output.Record)
+		}
+	}
+
+	// 남은 스팬 내보내기
+	if err := a.tracer.Shutdown(); err != nil {
+		logger.Warn("failed to export trace spans", "error", err)
+	}
+
+	// 스크레이프할 시간을 주기 위해 종료 전 대기
+	if a.metrics != nil && cfg.Metrics.Linger > 0 {
+		logger.Info("keeping metrics endpoint open", "seconds", cfg.Metrics.Linger)
+		time.Sleep(time.Duration(cfg.Metrics.Linger) * time.Second)
 	}
+	logCloser.Close()
+	os.Exit(code)
 }
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+)
+
+// runMockServer는 메모리 기반 가짜 CRDP 서버를 실행하고 종료 코드를 반환합니다 (Ctrl+C로 종료)
+func runMockServer(args []string) int {
+	fs := flag.NewFlagSet("mock-server", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:32082", "listen address")
+	useTLS := fs.Bool("tls", false, "serve HTTPS with a temporary self-signed certificate")
+	policiesFile := fs.String("policies-file", "", "YAML file with a list of policies")
+	jwtSecret := fs.String("jwt-secret", "", "HS256 secret used to verify bearer tokens")
+	requireJWT := fs.Bool("require-jwt", false, "reject requests without a bearer token")
+	issueToken := fs.String("issue-token", "", "print a token signed with --jwt-secret for this user")
+	latency := fs.Duration("latency", 0, "fixed delay before each response")
+	jitter := fs.Duration("jitter", 0, "additional random delay (0 to jitter)")
+	errorRate := fs.Float64("error-rate", 0, "fraction of requests answered with --error-status (0-1)")
+	errorStatus := fs.Int("error-status", 0, "status code for injected errors")
+	seed := fs.Int64("seed", 0, "random seed for tokens and error injection")
+	maxTokens := fs.Int("max-tokens", fakecrdp.DefaultMaxTokens, "tokens kept per policy before the oldest are evicted")
+	logLevel := fs.String("log-level", "info", "log level: debug, info, warn, error")
+	logFormat := fs.String("log-format", "text", "log format: text, json")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s mock-server [flags]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  --addr string            listen address (default \"127.0.0.1:32082\")\n")
+		fmt.Fprintf(os.Stderr, "  --tls                    serve HTTPS with a temporary self-signed certificate\n")
+		fmt.Fprintf(os.Stderr, "  --policies-file string   YAML file with a list of policies (default: accept any policy name)\n")
+		fmt.Fprintf(os.Stderr, "  --jwt-secret string      HS256 secret used to verify bearer tokens\n")
+		fmt.Fprintf(os.Stderr, "  --require-jwt            reject requests without a bearer token\n")
+		fmt.Fprintf(os.Stderr, "  --issue-token string     print a token signed with --jwt-secret for this user\n")
+		fmt.Fprintf(os.Stderr, "  --latency duration       fixed delay before each response (e.g. 5ms)\n")
+		fmt.Fprintf(os.Stderr, "  --jitter duration        additional random delay (0 to jitter)\n")
+		fmt.Fprintf(os.Stderr, "  --error-rate float       fraction of requests answered with --error-status (0-1)\n")
+		fmt.Fprintf(os.Stderr, "  --error-status int       status code for injected errors (default 503)\n")
+		fmt.Fprintf(os.Stderr, "  --seed int               random seed for tokens and error injection (default: current time)\n")
+		fmt.Fprintf(os.Stderr, "  --max-tokens int         tokens kept per policy before the oldest are evicted (default %d)\n", fakecrdp.DefaultMaxTokens)
+		fmt.Fprintf(os.Stderr, "  --log-level string       log level: debug, info, warn, error (default \"info\")\n")
+		fmt.Fprintf(os.Stderr, "  --log-format string      log format: text, json (default \"text\")\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitConfigError
+	}
+	if *errorRate < 0 || *errorRate > 1 {
+		fmt.Fprintf(os.Stderr, "Error: --error-rate must be between 0 and 1\n")
+		return exitConfigError
+	}
+	if *errorStatus != 0 && (*errorStatus < 400 || *errorStatus > 599) {
+		fmt.Fprintf(os.Stderr, "Error: --error-status must be a 4xx or 5xx status code\n")
+		return exitConfigError
+	}
+	if *maxTokens <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --max-tokens must be positive\n")
+		return exitConfigError
+	}
+
+	logger, logCloser, err := logging.New(logging.Options{Level: *logLevel, Format: *logFormat})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+	defer logCloser.Close()
+
+	cfg := fakecrdp.Config{
+		JWTSecret:   *jwtSecret,
+		RequireJWT:  *requireJWT,
+		Latency:     *latency,
+		Jitter:      *jitter,
+		ErrorRate:   *errorRate,
+		ErrorStatus: *errorStatus,
+		Seed:        *seed,
+		MaxTokens:   *maxTokens,
+	}
+	if *policiesFile != "" {
+		if cfg.Policies, err = loadPolicies(*policiesFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitConfigError
+		}
+	}
+
+	if *issueToken != "" {
+		fmt.Println(fakecrdp.SignToken(*jwtSecret, *issueToken, 24*time.Hour))
+		return exitOK
+	}
+
+	server := fakecrdp.New(cfg)
+	server.SetLogger(logger)
+	srv := &http.Server{Addr: *addr, Handler: server}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to listen on %s: %v\n", *addr, err)
+		return exitConfigError
+	}
+	scheme := "http"
+	if *useTLS {
+		host, _, _ := net.SplitHostPort(*addr)
+		cert, err := fakecrdp.SelfSignedCertificate(host, "localhost", "127.0.0.1")
+		if err != nil {
+			ln.Close()
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitConfigError
+		}
+		ln = tls.NewListener(ln, &tls.Config{Certificates: []tls.Certificate{cert}})
+		scheme = "https"
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("mock server listening", "url", scheme+"://"+ln.Addr().String(), "policies", len(cfg.Policies),
+		"require_jwt", cfg.RequireJWT, "latency", cfg.Latency, "jitter", cfg.Jitter, "error_rate", cfg.ErrorRate)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("mock server failed", "error", err)
+			return exitFailed
+		}
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}
+
+	counts := server.Requests()
+	paths := make([]string, 0, len(counts))
+	for p := range counts {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		logger.Info("requests served", "path", p, "count", counts[p])
+	}
+	return exitOK
+}
+
+// loadPolicies는 가짜 서버 정책 목록 YAML 파일을 읽습니다
+func loadPolicies(path string) ([]fakecrdp.Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policies file: %w", err)
+	}
+	var policies []fakecrdp.Policy
+	if err := yaml.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("failed to parse policies file: %w", err)
+	}
+	for i, p := range policies {
+		if p.Name == "" {
+			return nil, fmt.Errorf("policies[%d]: name is required", i)
+		}
+		for user, a := range p.Users {
+			switch a {
+			case fakecrdp.AccessPlain, fakecrdp.AccessMasked, fakecrdp.AccessDenied:
+			default:
+				return nil, fmt.Errorf("policies[%d].users.%s: invalid access %q (expected plain, masked or denied)", i, user, a)
+			}
+		}
+	}
+	return policies, nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+	"github.com/sjrhee/crdp-cli-go/internal/faultproxy"
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+)
+
+// runProxy는 CRDP 서버 앞에서 장애를 주입하는 프록시를 실행하고 종료 코드를 반환합니다 (Ctrl+C로 종료)
+func runProxy(args []string) int {
+	fs := flag.NewFlagSet("proxy", flag.ContinueOnError)
+	listen := fs.String("listen", "127.0.0.1:32083", "listen address")
+	target := fs.String("target", "", "CRDP server URL to forward to")
+	useTLS := fs.Bool("tls", false, "serve HTTPS with a temporary self-signed certificate")
+	faultsFile := fs.String("faults-file", "", "YAML file with target, faults, schedule and cycle_s")
+	latencyDist := fs.String("latency-dist", "", "latency distribution: fixed, uniform, normal, exponential")
+	latency := fs.Int("latency", 0, "base latency ms")
+	latencySpread := fs.Int("latency-spread", 0, "latency spread ms (uniform range, normal stddev, exponential mean)")
+	resetRate := fs.Float64("reset-rate", 0, "fraction of connections reset without response")
+	errorRate := fs.Float64("error-rate", 0, "fraction of requests answered with an error status")
+	errorStatus := fs.String("error-status", "", "comma-separated error statuses to inject (default 503)")
+	truncateRate := fs.Float64("truncate-rate", 0, "fraction of responses with truncated body")
+	slowReadRate := fs.Float64("slow-read-rate", 0, "fraction of responses sent slowly")
+	slowReadBps := fs.Int("slow-read-bps", 0, "slow response speed in bytes per second")
+	seed := fs.Int64("seed", 0, "random seed for fault decisions")
+	injectionLog := fs.String("injection-log", "", "append injected faults to file as JSON lines")
+	logLevel := fs.String("log-level", "info", "log level: debug, info, warn, error")
+	logFormat := fs.String("log-format", "text", "log format: text, json")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s proxy --target URL [flags]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  --listen string          listen address (default \"127.0.0.1:32083\")\n")
+		fmt.Fprintf(os.Stderr, "  --target string          CRDP server URL to forward to (e.g. https://192.168.0.231:32082)\n")
+		fmt.Fprintf(os.Stderr, "  --tls                    serve HTTPS with a temporary self-signed certificate\n")
+		fmt.Fprintf(os.Stderr, "  --faults-file string     YAML file with target, faults, schedule and cycle_s\n")
+		fmt.Fprintf(os.Stderr, "  --latency-dist string    latency distribution: fixed, uniform, normal, exponential (default \"fixed\")\n")
+		fmt.Fprintf(os.Stderr, "  --latency int            base latency ms\n")
+		fmt.Fprintf(os.Stderr, "  --latency-spread int     latency spread ms (uniform range, normal stddev, exponential mean)\n")
+		fmt.Fprintf(os.Stderr, "  --reset-rate float       fraction of connections reset without response (0-1)\n")
+		fmt.Fprintf(os.Stderr, "  --error-rate float       fraction of requests answered with an error status (0-1)\n")
+		fmt.Fprintf(os.Stderr, "  --error-status string    comma-separated error statuses to inject, e.g. 503,429 (default 503)\n")
+		fmt.Fprintf(os.Stderr, "  --truncate-rate float    fraction of responses with truncated body (0-1)\n")
+		fmt.Fprintf(os.Stderr, "  --slow-read-rate float   fraction of responses sent slowly (0-1)\n")
+		fmt.Fprintf(os.Stderr, "  --slow-read-bps int      slow response speed in bytes per second (default 1024)\n")
+		fmt.Fprintf(os.Stderr, "  --seed int               random seed for fault decisions (default: current time)\n")
+		fmt.Fprintf(os.Stderr, "  --injection-log string   append injected faults to file as JSON lines\n")
+		fmt.Fprintf(os.Stderr, "  --log-level string       log level: debug, info, warn, error (default \"info\")\n")
+		fmt.Fprintf(os.Stderr, "  --log-format string      log format: text, json (default \"text\")\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitConfigError
+	}
+
+	var cfg faultproxy.Config
+	if *faultsFile != "" {
+		data, err := os.ReadFile(*faultsFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to read faults file: %v\n", err)
+			return exitConfigError
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to parse faults file: %v\n", err)
+			return exitConfigError
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "target":
+			cfg.Target = *target
+		case "latency-dist":
+			cfg.Faults.Latency.Distribution = *latencyDist
+		case "latency":
+			cfg.Faults.Latency.BaseMs = *latency
+		case "latency-spread":
+			cfg.Faults.Latency.SpreadMs = *latencySpread
+		case "reset-rate":
+			cfg.Faults.ResetRate = *resetRate
+		case "error-rate":
+			cfg.Faults.ErrorRate = *errorRate
+		case "error-status":
+			cfg.Faults.ErrorStatuses = nil
+			for _, s := range strings.Split(*errorStatus, ",") {
+				code, err := strconv.Atoi(strings.TrimSpace(s))
+				if err != nil {
+					flagErr = fmt.Errorf("--error-status: invalid status %q", s)
+					return
+				}
+				cfg.Faults.ErrorStatuses = append(cfg.Faults.ErrorStatuses, code)
+			}
+		case "truncate-rate":
+			cfg.Faults.TruncateRate = *truncateRate
+		case "slow-read-rate":
+			cfg.Faults.SlowReadRate = *slowReadRate
+		case "slow-read-bps":
+			cfg.Faults.SlowReadBps = *slowReadBps
+		case "seed":
+			cfg.Seed = *seed
+		}
+	})
+	if flagErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", flagErr)
+		return exitConfigError
+	}
+
+	proxy, err := faultproxy.New(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid proxy configuration: %v\n", err)
+		return exitConfigError
+	}
+
+	logger, logCloser, err := logging.New(logging.Options{Level: *logLevel, Format: *logFormat})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+	defer logCloser.Close()
+	proxy.SetLogger(logger)
+
+	if *injectionLog != "" {
+		f, err := os.OpenFile(*injectionLog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to open injection log: %v\n", err)
+			return exitConfigError
+		}
+		defer f.Close()
+		proxy.SetInjectionLog(f)
+	}
+
+	ln, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to listen on %s: %v\n", *listen, err)
+		return exitConfigError
+	}
+	scheme := "http"
+	if *useTLS {
+		host, _, _ := net.SplitHostPort(*listen)
+		cert, err := fakecrdp.SelfSignedCertificate(host, "localhost", "127.0.0.1")
+		if err != nil {
+			ln.Close()
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitConfigError
+		}
+		ln = tls.NewListener(ln, &tls.Config{Certificates: []tls.Certificate{cert}})
+		scheme = "https"
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{Handler: proxy}
+	logger.Info("fault proxy listening", "url", scheme+"://"+ln.Addr().String(), "target", cfg.Target,
+		"stages", len(cfg.Schedule), "cycle_s", cfg.CycleS)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("fault proxy failed", "error", err)
+			return exitFailed
+		}
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}
+
+	requests, counts := proxy.Counts()
+	faults := make([]string, 0, len(counts))
+	for f := range counts {
+		faults = append(faults, f)
+	}
+	sort.Strings(faults)
+	attrs := []interface{}{"requests", requests}
+	for _, f := range faults {
+		attrs = append(attrs, f, counts[f])
+	}
+	logger.Info("fault proxy stopped", attrs...)
+	return exitOK
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/cassette"
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/config"
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+)
+
+// runReplay는 카세트 파일의 요청을 서버로 다시 보내거나(--target) 기록된 응답을 돌려주는 서버를 실행합니다(--serve)
+// 다시 보내기의 종료 코드: exitOK 모든 상태 코드 일치, exitFailed 불일치, exitConnectivity 모든 요청 전송 실패
+func runReplay(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	target := fs.String("target", "", "server URL to re-send recorded requests to")
+	serve := fs.String("serve", "", "serve recorded responses on this address")
+	speed := fs.Float64("speed", 1, "re-send speed relative to recorded timing (0 = back to back)")
+	jwtToken := fs.String("jwt-token", "", "JWT token sent with re-sent requests")
+	timeout := fs.Int("timeout", 10, "per-request timeout seconds when re-sending")
+	configPath := fs.String("config", "", "config file whose rate_limit applies when re-sending")
+	timing := fs.Bool("timing", false, "delay served responses by the recorded elapsed time")
+	useTLS := fs.Bool("tls", false, "serve HTTPS with a temporary self-signed certificate")
+	logLevel := fs.String("log-level", "info", "log level: debug, info, warn, error")
+	logFormat := fs.String("log-format", "text", "log format: text, json")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s replay (--target URL | --serve ADDR) [flags] cassette.jsonl\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  --target string       server URL to re-send recorded requests to (e.g. https://192.168.0.231:32082)\n")
+		fmt.Fprintf(os.Stderr, "  --serve string        serve recorded responses on this address (e.g. 127.0.0.1:32084)\n")
+		fmt.Fprintf(os.Stderr, "  --speed float         re-send speed relative to recorded timing, 0 = back to back (default 1)\n")
+		fmt.Fprintf(os.Stderr, "  --jwt-token string    JWT token sent with re-sent requests (tokens are not recorded)\n")
+		fmt.Fprintf(os.Stderr, "  --timeout int         per-request timeout seconds when re-sending (default 10)\n")
+		fmt.Fprintf(os.Stderr, "  --config string       config file whose rate_limit applies when re-sending\n")
+		fmt.Fprintf(os.Stderr, "  --timing              delay served responses by the recorded elapsed time\n")
+		fmt.Fprintf(os.Stderr, "  --tls                 serve HTTPS with a temporary self-signed certificate\n")
+		fmt.Fprintf(os.Stderr, "  --log-level string    log level: debug, info, warn, error (default \"info\")\n")
+		fmt.Fprintf(os.Stderr, "  --log-format string   log format: text, json (default \"text\")\n")
+		fmt.Fprintf(os.Stderr, "\nExit codes (re-send): 0 all statuses match, 1 status mismatch, 2 usage/file error, 3 all requests failed\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return exitConfigError
+	}
+	if fs.NArg() != 1 || (*target == "") == (*serve == "") {
+		fs.Usage()
+		return exitConfigError
+	}
+	if *speed < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --speed must not be negative\n")
+		return exitConfigError
+	}
+
+	// 다시 보내기도 공유 서버에 요청을 보내므로 설정 파일의 rate_limit을 따름
+	var limiter *client.Limiter
+	if *configPath != "" {
+		cfg, err := config.LoadConfig(*configPath)
+		if err == nil {
+			err = cfg.Validate()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitConfigError
+		}
+		limiter = newLimiter(cfg)
+	}
+
+	c, err := cassette.Load(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+	logger, logCloser, err := logging.New(logging.Options{Level: *logLevel, Format: *logFormat})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitConfigError
+	}
+	defer logCloser.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *serve != "" {
+		return serveCassette(ctx, c, *serve, *timing, *useTLS, logger)
+	}
+
+	if c.Redacted() {
+		logger.Warn("cassette was recorded with redacted data; re-sent requests carry masked values",
+			"redact", c.Header.Redact)
+	}
+	fmt.Printf("Replaying %d requests from %s to %s (speed %g)\n", len(c.Interactions), fs.Arg(0), *target, *speed)
+	summary := cassette.Replay(ctx, c, cassette.ReplayOptions{
+		Target:   *target,
+		Speed:    *speed,
+		JWTToken: *jwtToken,
+		Timeout:  time.Duration(*timeout) * time.Second,
+		Limiter:  limiter,
+		Logger:   logger,
+	})
+	fmt.Printf("Result: %s\n", summary)
+
+	switch {
+	case summary.Sent > 0 && summary.Errors == summary.Sent:
+		return exitConnectivity
+	case summary.Mismatched > 0:
+		return exitFailed
+	}
+	return exitOK
+}
+
+// serveCassette는 기록된 응답을 돌려주는 서버를 ctx가 끝날 때까지 실행합니다
+func serveCassette(ctx context.Context, c *cassette.Cassette, addr string, timing, useTLS bool, logger *slog.Logger) int {
+	player := cassette.NewPlayer(c, timing)
+	player.SetLogger(logger)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to listen on %s: %v\n", addr, err)
+		return exitConfigError
+	}
+	scheme := "http"
+	if useTLS {
+		host, _, _ := net.SplitHostPort(addr)
+		cert, err := fakecrdp.SelfSignedCertificate(host, "localhost", "127.0.0.1")
+		if err != nil {
+			ln.Close()
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitConfigError
+		}
+		ln = tls.NewListener(ln, &tls.Config{Certificates: []tls.Certificate{cert}})
+		scheme = "https"
+	}
+
+	srv := &http.Server{Handler: player}
+	logger.Info("serving recorded responses", "url", scheme+"://"+ln.Addr().String(),
+		"interactions", len(c.Interactions), "redact", c.Header.Redact, "timing", timing)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("replay server failed", "error", err)
+			return exitFailed
+		}
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}
+
+	served, misses := player.Stats()
+	logger.Info("replay server stopped", "served", served, "unmatched", misses,
+		"unused", len(c.Interactions)-served)
+	return exitOK
+}
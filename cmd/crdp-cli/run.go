@@ -0,0 +1,470 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/assertion"
+	"github.com/sjrhee/crdp-cli-go/internal/cassette"
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/config"
+	"github.com/sjrhee/crdp-cli-go/internal/crdpengine"
+	"github.com/sjrhee/crdp-cli-go/internal/dashboard"
+	"github.com/sjrhee/crdp-cli-go/internal/matrix"
+	"github.com/sjrhee/crdp-cli-go/internal/metrics"
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+	"github.com/sjrhee/crdp-cli-go/internal/redact"
+	"github.com/sjrhee/crdp-cli-go/internal/report"
+	"github.com/sjrhee/crdp-cli-go/internal/runner"
+	"github.com/sjrhee/crdp-cli-go/internal/testreport"
+	"github.com/sjrhee/crdp-cli-go/internal/tracing"
+)
+
+// app은 한 번의 CLI 실행에 필요한 설정과 공유 구성요소를 묶습니다
+type app struct {
+	cfg        *config.Config
+	assertions []assertion.Assertion
+	items      []runner.Item          // 입력 파일 항목 (nil이면 start_data부터 생성)
+	validator  *runner.TokenValidator // 토큰 검증 (nil이면 검사하지 않음)
+	metrics    *metrics.Registry      // nil이면 메트릭 수집 비활성화
+	tracer     *tracing.Tracer        // nil이면 트레이싱 비활성화
+	redactor   *redact.Redactor       // 디버그 출력의 민감한 값 가림
+	logger     *slog.Logger           // 모든 구성요소가 공유하는 구조화 로거
+	limiter    *client.Limiter        // 모든 조합이 공유하는 속도/동시 요청 제한 (nil이면 제한 없음)
+	breaker    *client.Breaker        // 모든 조합이 공유하는 서킷 브레이커 (nil이면 비활성화)
+	recorder   *cassette.Recorder     // 요청/응답 카세트 기록 (nil이면 기록하지 않음)
+}
+
+// runResult는 하나의 조합을 실행한 결과입니다
+type runResult struct {
+	summary   *runner.Summary
+	row       output.Row
+	collector *testreport.Collector // JUnit/TAP 리포트가 설정되지 않았으면 nil
+}
+
+// baseCombination은 단일 실행 설정값을 하나의 조합으로 반환합니다
+func (a *app) baseCombination() matrix.Combination {
+	return matrix.Combination{
+		Policy:        a.cfg.Protection.Policy,
+		Bulk:          a.cfg.Batch.Enabled,
+		BatchSize:     a.cfg.Batch.Size,
+		Workers:       a.cfg.Parallel.Workers,
+		TLS:           a.cfg.API.TLS,
+		PayloadLength: a.cfg.Execution.PayloadLength,
+	}
+}
+
+// newClient는 설정값과 조합으로 CRDP 클라이언트를 생성합니다
+// 연결/TLS/JWT/재시도/타임아웃은 공개 SDK(pkg/crdp)와 같은 생성 경로(crdpengine)로 구성하고,
+// 속도 제한, 서킷 브레이커처럼 CLI에서만 쓰는 기능은 생성된 전송 엔진에 붙입니다
+func (a *app) newClient(combo matrix.Combination) *client.Client {
+	cfg := a.cfg
+	settings := crdpengine.Settings{
+		Host:   cfg.API.Host,
+		Port:   cfg.API.Port,
+		Policy: combo.Policy,
+		// CRDP 기본 설치는 자체 서명 인증서를 사용하므로 검증하지 않음
+		TLS:                combo.TLS,
+		InsecureSkipVerify: true,
+		Timeout:            time.Duration(cfg.API.Timeout) * time.Second,
+		MaxRetries:         cfg.API.Retries,
+		Backoff:            time.Duration(cfg.API.RetryBackoffMs) * time.Millisecond,
+		Username:           cfg.Protection.Username,
+		ExternalVersion:    cfg.Protection.ExternalVersion,
+		Logger:             a.logger,
+		Headers:            cfg.API.Headers,
+	}
+	if cfg.Auth.JWT {
+		settings.JWTToken = cfg.Auth.JWTToken
+	}
+	c, err := crdpengine.New(settings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfigError)
+	}
+
+	c.SetShowBody(cfg.Output.ShowBody)
+	c.SetRedactor(a.redactor)
+	c.SetPhaseTiming(cfg.Output.PhaseTiming)
+	c.SetLimiter(a.limiter)
+	c.SetBreaker(a.breaker)
+	if a.metrics != nil {
+		c.SetObserver(a.metrics)
+	}
+	if a.recorder != nil {
+		c.SetRecorder(a.recorder)
+	}
+	return c
+}
+
+// applyHeaders는 설정 파일의 api.headers를 검증하고 --header 플래그("Name: value")를 덮어씁니다
+func applyHeaders(cfg *config.Config, headers []string) error {
+	for name, value := range cfg.API.Headers {
+		if err := client.ValidateHeader(name, value); err != nil {
+			return fmt.Errorf("api.headers: %w", err)
+		}
+	}
+	for _, h := range headers {
+		name, value, err := client.ParseHeader(h)
+		if err != nil {
+			return fmt.Errorf("--header: %w", err)
+		}
+		if cfg.API.Headers == nil {
+			cfg.API.Headers = make(map[string]string)
+		}
+		cfg.API.Headers[name] = value
+	}
+	return nil
+}
+
+// runOptions는 설정값과 조합으로 runner 실행 옵션을 구성합니다
+func (a *app) runOptions(combo matrix.Combination) runner.Options {
+	cfg := a.cfg
+	opts := runner.Options{
+		Iterations:     cfg.Execution.Iterations,
+		StartData:      cfg.Execution.StartData,
+		PayloadLength:  combo.PayloadLength,
+		Bulk:           combo.Bulk,
+		BatchSize:      combo.BatchSize,
+		Workers:        combo.Workers,
+		Items:          a.items,
+		TokenValidator: a.validator,
+		Tracer:         a.tracer,
+		PolicyMode:     cfg.Protection.PolicyMode,
+	}
+	for _, p := range cfg.Protection.Policies {
+		weight := p.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		opts.Policies = append(opts.Policies, runner.PolicyWeight{Name: p.Name, Weight: weight})
+	}
+
+	// 터미널 대시보드가 켜져 있으면 화면이 깨지지 않도록 반복별 진행 출력을 생략
+	showProgress := cfg.Output.ShowProgress && !(cfg.Output.Live && dashboard.IsTerminal(os.Stdout))
+
+	opts.Progress = func(p runner.Progress) {
+		// 오류는 runner가 로그로 남김
+		if p.Err != nil || !showProgress {
+			return
+		}
+
+		r := p.Result
+		if combo.Bulk {
+			printBulkProgress(p.Index, len(p.Inputs), r.TimeS,
+				r.ProtectResponse.StatusCode, r.RevealResponse.StatusCode, r.MatchedCount)
+		} else {
+			printIterationProgress(p.Index, a.redactor.Value(p.Inputs[0]), r.TimeS,
+				r.ProtectResponse.StatusCode, r.RevealResponse.StatusCode,
+				r.Match, cfg.Output.ShowBody)
+		}
+	}
+
+	if a.metrics != nil {
+		addProgress(&opts, a.metrics.ObserveProgress)
+	}
+	return opts
+}
+
+// run은 하나의 조합을 실행하고 결과 행을 만듭니다
+func (a *app) run(name string, combo matrix.Combination) runResult {
+	if a.metrics != nil {
+		a.metrics.SetCombination(name)
+	}
+
+	c := a.newClient(combo)
+	balancer := a.newBalancer()
+	c.SetBalancer(balancer)
+	opts := a.runOptions(combo)
+	opts.Logger = a.logger.With("run", name)
+	collector := a.newCollector(&opts)
+
+	var dash *dashboard.Dashboard
+	if a.cfg.Output.Live {
+		dash = dashboard.New(os.Stdout, dashboard.IsTerminal(os.Stdout), name, opts.Total(),
+			time.Duration(a.cfg.Output.LiveInterval)*time.Second)
+		addProgress(&opts, dash.Record)
+		dash.Start()
+	}
+	summary := runner.Run(c, opts)
+	if dash != nil {
+		dash.Stop()
+	}
+
+	row := output.Row{
+		Name:          name,
+		Policy:        combo.Policy,
+		Bulk:          combo.Bulk,
+		BatchSize:     combo.BatchSize,
+		Workers:       combo.Workers,
+		TLS:           combo.TLS,
+		PayloadLength: combo.PayloadLength,
+	}
+	row.FillSummary(summary)
+	row.FillPolicies(summary)
+	if balancer != nil {
+		row.FillHosts(balancer.Stats())
+	}
+	return runResult{summary: summary, row: row, collector: collector}
+}
+
+// runSingle은 단일 실행 설정으로 반복을 실행하고 종료 코드를 반환합니다
+func (a *app) runSingle() int {
+	res := a.run("run", a.baseCombination())
+
+	// 결과 출력
+	printSummary(res.summary)
+	if len(res.row.Policies) > 0 {
+		fmt.Printf("\nPolicies (%s)\n", policyModeName(a.cfg.Protection.PolicyMode))
+		output.PrintPolicyTable(os.Stdout, res.row.Policies)
+	}
+	if len(res.row.Hosts) > 0 {
+		printHosts(res.row.Hosts)
+	}
+	if a.breaker != nil {
+		printBreaker(a.breaker)
+	}
+	a.writeOutputs([]output.Row{res.row})
+
+	code, results := a.evaluateRow(res.row, "Assertions")
+	if res.collector != nil {
+		a.writeTestReports([]testreport.Suite{res.collector.Suite(res.row.Name, results)})
+	}
+	return code
+}
+
+// policyModeName은 여러 정책 실행 방식의 표시 이름을 반환합니다
+func policyModeName(mode string) string {
+	if mode == "" {
+		return runner.PolicyModeMix
+	}
+	return mode
+}
+
+// runMatrix는 매트릭스 스펙의 모든 조합을 차례로 실행하고 비교 표를 출력합니다
+// 조합별로 SLO 조건을 평가하여 가장 심각한 종료 코드를 반환합니다
+func (a *app) runMatrix() int {
+	combos := matrix.Expand(a.cfg)
+	runs := make([]runResult, 0, len(combos))
+	rows := make([]output.Row, 0, len(combos))
+
+	for i, combo := range combos {
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s\n", i+1, len(combos), combo.Label())
+		res := a.run(combo.Label(), combo)
+		runs = append(runs, res)
+		rows = append(rows, res.row)
+	}
+
+	fmt.Printf("\nMatrix results (%d combinations, %d items each)\n", len(rows), a.cfg.Execution.Iterations)
+	output.PrintTable(os.Stdout, rows)
+	if a.breaker != nil {
+		printBreaker(a.breaker)
+	}
+
+	a.writeOutputs(rows)
+
+	code := exitOK
+	var suites []testreport.Suite
+	for _, res := range runs {
+		rowCode, results := a.evaluateRow(res.row, "Assertions: "+res.row.Name)
+		code = worseExitCode(code, rowCode)
+		if res.collector != nil {
+			suites = append(suites, res.collector.Suite(res.row.Name, results))
+		}
+	}
+	if len(suites) > 0 {
+		a.writeTestReports(suites)
+	}
+	return code
+}
+
+// newLimiter는 rate_limit 설정으로 클라이언트 Limiter를 생성합니다
+// 설정된 제한이 없으면 nil을 반환합니다
+func newLimiter(cfg *config.Config) *client.Limiter {
+	endpoints := make(map[string]client.Limit, len(cfg.RateLimit.Endpoints))
+	for name, l := range cfg.RateLimit.Endpoints {
+		endpoints[name] = clientLimit(l)
+	}
+	return client.NewLimiter(clientLimit(cfg.RateLimit.Limit), endpoints)
+}
+
+// clientLimit는 설정의 제한 값을 client.Limit로 변환합니다
+func clientLimit(l config.Limit) client.Limit {
+	return client.Limit{
+		RequestsPerSec: l.RequestsPerSec,
+		ItemsPerSec:    l.ItemsPerSec,
+		Burst:          l.Burst,
+		MaxInFlight:    l.MaxInFlight,
+	}
+}
+
+// newBalancer는 api.hosts가 설정된 경우 조합 하나의 실행에 사용할 Balancer를 생성합니다
+// 호스트별 집계가 조합마다 따로 남도록 실행마다 새로 만듭니다 (설정되지 않았으면 nil)
+func (a *app) newBalancer() *client.Balancer {
+	cfg := a.cfg
+	if len(cfg.API.Hosts) == 0 {
+		return nil
+	}
+	addrs := make([]string, len(cfg.API.Hosts))
+	for i, h := range cfg.API.Hosts {
+		h = strings.TrimSpace(h)
+		if _, _, err := net.SplitHostPort(h); err != nil {
+			h = net.JoinHostPort(h, strconv.Itoa(cfg.API.Port))
+		}
+		addrs[i] = h
+	}
+	strategy, _ := client.ParseBalanceStrategy(cfg.API.Balance) // Validate에서 검증됨
+	b := client.NewBalancer(addrs, strategy, client.HealthConfig{
+		EjectAfter:    cfg.API.EjectAfter,
+		EjectDuration: time.Duration(cfg.API.EjectMs) * time.Millisecond,
+	})
+	b.SetLogger(a.logger)
+	return b
+}
+
+// newBreaker는 circuit_breaker 설정으로 서킷 브레이커를 생성합니다 (비활성화이면 nil)
+func newBreaker(cfg *config.Config, logger *slog.Logger) *client.Breaker {
+	cb := cfg.CircuitBreaker
+	if !cb.Enabled {
+		return nil
+	}
+	b := client.NewBreaker(client.BreakerConfig{
+		ErrorRatio:     cb.ErrorRatio,
+		Window:         cb.Window,
+		OpenDuration:   time.Duration(cb.OpenMs) * time.Millisecond,
+		HalfOpenProbes: cb.HalfOpenProbes,
+	})
+	b.SetLogger(logger)
+	return b
+}
+
+// newTracer는 tracing 설정에 따라 Exporter를 만들고 Tracer를 생성합니다
+// file과 endpoint가 모두 비어 있으면 nil을 반환합니다
+func newTracer(cfg *config.Config) (*tracing.Tracer, error) {
+	t := cfg.Tracing
+	var exporter tracing.Exporter
+	switch {
+	case t.File != "":
+		fe, err := tracing.NewFileExporter(t.ServiceName, t.File)
+		if err != nil {
+			return nil, err
+		}
+		exporter = fe
+	case t.Endpoint != "":
+		exporter = tracing.NewHTTPExporter(t.ServiceName, t.Endpoint)
+	default:
+		return nil, nil
+	}
+	return tracing.NewTracer(t.ServiceName, exporter, t.SampleRatio), nil
+}
+
+// evaluateRow는 결과 행에 SLO 조건을 적용해 보고서를 출력하고 종료 코드와 평가 결과를 반환합니다
+func (a *app) evaluateRow(row output.Row, title string) (int, []assertion.Result) {
+	code := exitOK
+	var results []assertion.Result
+	if len(a.assertions) > 0 {
+		results = assertion.Evaluate(a.assertions, row)
+		assertion.PrintReport(os.Stdout, title, results)
+		if failed := assertion.Failed(results); failed > 0 {
+			fmt.Printf("Result: FAIL (%d of %d assertions failed)\n", failed, len(results))
+			code = exitFailed
+		} else {
+			fmt.Printf("Result: PASS (%d assertions)\n", len(results))
+		}
+	}
+
+	if connectivityFailed(row) {
+		fmt.Fprintf(os.Stderr, "Error: %s: no response from server (%d request errors)\n", row.Name, row.Errors)
+		return worseExitCode(code, exitConnectivity), results
+	}
+	if row.Attempted > 0 && row.Successful == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %s: no successful iterations\n", row.Name)
+		return worseExitCode(code, exitFailed), results
+	}
+	return code, results
+}
+
+// newCollector는 JUnit/TAP 리포트가 설정된 경우 진행 정보를 수집하는 Collector를 연결합니다
+// 리포트가 설정되지 않았으면 nil을 반환합니다
+func (a *app) newCollector(opts *runner.Options) *testreport.Collector {
+	if a.cfg.Output.JUnit == "" && a.cfg.Output.TAP == "" {
+		return nil
+	}
+	collector := testreport.NewCollector(opts.Bulk, a.cfg.Output.JUnitGroupSize)
+	collector.SetRedactor(a.redactor)
+	addProgress(opts, collector.Record)
+	return collector
+}
+
+// addProgress는 기존 Progress 콜백 뒤에 새 콜백을 연결합니다
+func addProgress(opts *runner.Options, fn func(runner.Progress)) {
+	prev := opts.Progress
+	opts.Progress = func(p runner.Progress) {
+		if prev != nil {
+			prev(p)
+		}
+		fn(p)
+	}
+}
+
+// results는 결과 행들로 결과 파일/리포트에 기록할 데이터를 만듭니다
+func (a *app) results(rows []output.Row) *output.Results {
+	results := &output.Results{
+		Version:     output.FormatVersion,
+		GeneratedAt: time.Now(),
+		Host:        a.cfg.API.Host,
+		Port:        a.cfg.API.Port,
+		Environment: output.CurrentEnvironment(),
+		Rows:        rows,
+	}
+	if cfgYAML, err := a.cfg.MaskedYAML(); err == nil {
+		results.Config = cfgYAML
+	}
+	return results
+}
+
+// writeOutputs는 결과 행들을 output.file 결과 파일과 output.report HTML 리포트로 저장합니다
+func (a *app) writeOutputs(rows []output.Row) {
+	if a.cfg.Output.File == "" && a.cfg.Output.Report == "" {
+		return
+	}
+	results := a.results(rows)
+
+	if a.cfg.Output.File != "" {
+		if err := output.WriteResults(a.cfg.Output.File, results); err != nil {
+			a.logger.Warn("failed to write results file", "error", err)
+		} else {
+			fmt.Printf("Results written to %s\n", a.cfg.Output.File)
+		}
+	}
+	if a.cfg.Output.Report != "" {
+		if err := report.Write(a.cfg.Output.Report, results); err != nil {
+			a.logger.Warn("failed to write HTML report", "error", err)
+		} else {
+			fmt.Printf("HTML report written to %s\n", a.cfg.Output.Report)
+		}
+	}
+}
+
+// writeTestReports는 설정된 경로에 JUnit XML/TAP 리포트를 저장합니다
+func (a *app) writeTestReports(suites []testreport.Suite) {
+	if a.cfg.Output.JUnit != "" {
+		if err := testreport.WriteJUnit(a.cfg.Output.JUnit, suites); err != nil {
+			a.logger.Warn("failed to write JUnit report", "error", err)
+		} else {
+			fmt.Printf("JUnit report written to %s\n", a.cfg.Output.JUnit)
+		}
+	}
+	if a.cfg.Output.TAP != "" {
+		if err := testreport.WriteTAP(a.cfg.Output.TAP, suites); err != nil {
+			a.logger.Warn("failed to write TAP report", "error", err)
+		} else {
+			fmt.Printf("TAP report written to %s\n", a.cfg.Output.TAP)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// sdk 예제는 pkg/crdp로 CRDP 서버에 protect/reveal(단일, 대량)을 요청합니다
+//
+//	go run ./examples/sdk --addr https://192.168.0.231:32082 --policy P03 --jwt "$CRDP_JWT" --insecure
+//	go run ./examples/sdk --addr http://127.0.0.1:32082   # crdp-cli mock-server 대상
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/pkg/crdp"
+)
+
+func main() {
+	addr := flag.String("addr", "http://127.0.0.1:32082", "CRDP server address")
+	policy := flag.String("policy", "P03", "protection policy name")
+	jwt := flag.String("jwt", "", "JWT token (optional)")
+	insecure := flag.Bool("insecure", false, "skip server certificate verification")
+	flag.Parse()
+
+	opts := []crdp.Option{
+		crdp.WithPolicy(*policy),
+		crdp.WithTimeout(5 * time.Second),
+		crdp.WithRetry(2, 100*time.Millisecond),
+		crdp.WithLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))),
+		crdp.WithHooks(crdp.Hooks{
+			OnResponse: func(r crdp.ResponseInfo) {
+				fmt.Printf("  %s -> %d (%s, request_id=%s)\n", r.Endpoint, r.StatusCode, r.Elapsed.Round(time.Microsecond), r.RequestID)
+			},
+		}),
+	}
+	if *jwt != "" {
+		opts = append(opts, crdp.WithJWT(crdp.StaticToken(*jwt)))
+	}
+	if *insecure {
+		opts = append(opts, crdp.WithInsecureSkipVerify())
+	}
+
+	c, err := crdp.New(*addr, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+
+	token, err := c.Protect(ctx, "1234567890123")
+	if err != nil {
+		explain(err)
+		os.Exit(1)
+	}
+	plain, err := c.Reveal(ctx, token)
+	if err != nil {
+		explain(err)
+		os.Exit(1)
+	}
+	fmt.Printf("protect/reveal: %s -> %s -> %s\n", "1234567890123", token, plain)
+
+	tokens, err := c.ProtectBulk(ctx, []string{"1111222233334444", "5555666677778888"})
+	if err != nil {
+		explain(err)
+		os.Exit(1)
+	}
+	values, err := c.RevealBulk(ctx, tokens)
+	if err != nil {
+		explain(err)
+		os.Exit(1)
+	}
+	fmt.Printf("bulk: %v -> %v\n", tokens, values)
+}
+
+// explain은 오류 종류에 따라 안내 메시지를 출력합니다
+func explain(err error) {
+	var apiErr *crdp.APIError
+	var transportErr *crdp.TransportError
+	switch {
+	case errors.Is(err, crdp.ErrUnauthorized):
+		fmt.Fprintln(os.Stderr, "authentication failed, check the JWT:", err)
+	case errors.Is(err, crdp.ErrForbidden):
+		fmt.Fprintln(os.Stderr, "the user is not allowed to use this policy:", err)
+	case errors.Is(err, crdp.ErrPolicyNotFound):
+		fmt.Fprintln(os.Stderr, "the protection policy does not exist, check --policy:", err)
+	case errors.Is(err, crdp.ErrInvalidData):
+		fmt.Fprintln(os.Stderr, "the data does not match the policy format:", err)
+	case errors.As(err, &apiErr):
+		fmt.Fprintf(os.Stderr, "server returned %d (%s): %s\n", apiErr.StatusCode, apiErr.Category, apiErr.Message)
+	case errors.As(err, &transportErr):
+		fmt.Fprintln(os.Stderr, "could not reach the server:", transportErr.Err)
+	default:
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
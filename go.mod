@@ -2,4 +2,4 @@ module github.com/sjrhee/crdp-cli-go
 
 go 1.21
 
-require gopkg.in/yaml.v2 v2.4.0 // indirect
+require gopkg.in/yaml.v2 v2.4.0
@@ -0,0 +1,274 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/crdpengine"
+	"github.com/sjrhee/crdp-cli-go/internal/redact"
+)
+
+// Outcome은 한 신원이 토큰 하나를 reveal한 결과의 종류입니다
+type Outcome string
+
+const (
+	Plain  Outcome = "plain"  // 원본 값 그대로 복원
+	Masked Outcome = "masked" // 2xx이지만 원본과 다른 값 (마스킹)
+	Denied Outcome = "denied" // 403 거부
+	Error  Outcome = "error"  // 그 외 오류 응답 또는 전송 오류
+)
+
+// ParseOutcome은 기대 결과 문자열(plain, masked, denied)을 Outcome으로 변환합니다
+func ParseOutcome(s string) (Outcome, error) {
+	switch o := Outcome(strings.ToLower(strings.TrimSpace(s))); o {
+	case Plain, Masked, Denied:
+		return o, nil
+	}
+	return "", fmt.Errorf("invalid expectation %q (expected plain, masked or denied)", s)
+}
+
+// Identity는 reveal을 요청할 신원과 기대 결과입니다
+type Identity struct {
+	Name        string
+	Username    string         // reveal 요청의 username (비어 있으면 보내지 않음)
+	JWTToken    string         // 비어 있으면 Target의 토큰 사용 (Target.JWT가 꺼져 있어도 지정하면 전송)
+	Expect      Outcome        // 기대 결과
+	MaskPattern *regexp.Regexp // Expect가 Masked일 때 결과가 일치해야 할 정규식 (nil이면 원본과 다르기만 하면 됨)
+}
+
+// Target은 검증 대상 서버와 표본을 protect할 기본 신원입니다
+type Target struct {
+	Host     string
+	Port     int
+	TLS      bool
+	Timeout  int // 초
+	Policy   string
+	JWT      bool
+	JWTToken string            // protect 및 JWT를 지정하지 않은 신원이 사용할 토큰 (JWT가 켜져 있을 때만 전송)
+	Username string            // protect 요청의 username
+	Headers  map[string]string // 모든 요청에 추가할 HTTP 헤더
+	Limiter  *client.Limiter   // 모든 신원의 요청이 공유하는 속도/동시 요청 제한 (nil이면 제한 없음)
+}
+
+// Sample은 protect한 표본 하나입니다
+type Sample struct {
+	Data            string
+	Token           string
+	ExternalVersion string
+}
+
+// Deviation은 기대와 다른 reveal 결과 하나입니다
+type Deviation struct {
+	Sample int     // 표본 번호 (0부터)
+	Got    Outcome // 실제 결과
+	Detail string  // 상태 코드, 가린 값 등
+}
+
+// Result는 한 신원에 대한 검증 결과입니다
+type Result struct {
+	Identity   Identity
+	Outcomes   map[Outcome]int // 결과 종류별 표본 수
+	Deviations []Deviation
+}
+
+// Passed는 모든 표본이 기대대로 reveal되었는지 확인합니다
+func (r *Result) Passed() bool {
+	return len(r.Deviations) == 0
+}
+
+// Report는 접근 정책 검증 결과입니다
+type Report struct {
+	Target     Target
+	Samples    []Sample
+	ProtectErr error // 표본 protect 실패 (있으면 Results는 비어 있음)
+	Results    []Result
+
+	connectivity bool // 서버에 연결하지 못해 실패했는지 여부
+}
+
+// Deviations는 모든 신원의 기대와 다른 결과 수를 반환합니다
+func (r *Report) Deviations() int {
+	n := 0
+	for _, res := range r.Results {
+		n += len(res.Deviations)
+	}
+	return n
+}
+
+// ConnectivityFailed는 서버에 연결하지 못해 실패했는지 확인합니다
+func (r *Report) ConnectivityFailed() bool {
+	return r.connectivity
+}
+
+// Run은 data를 기본 신원으로 한 번 protect한 뒤 신원마다 모든 토큰을 reveal하여 기대 결과와 비교합니다
+func Run(ctx context.Context, t Target, data []string, identities []Identity) *Report {
+	r := &Report{Target: t}
+
+	c, err := newClient(t, t.token(), t.Username)
+	if err != nil {
+		r.ProtectErr = err
+		return r
+	}
+	for _, d := range data {
+		resp, err := c.ProtectContext(ctx, d)
+		if err == nil {
+			err = resp.Err()
+		}
+		if err != nil {
+			r.ProtectErr = err
+			r.connectivity = unreachable(err)
+			return r
+		}
+		token, _ := resp.Body["protected_data"].(string)
+		if token == "" {
+			r.ProtectErr = fmt.Errorf("%w: protect response without protected_data", client.ErrInvalidResponse)
+			return r
+		}
+		version, _ := resp.Body["external_version"].(string)
+		r.Samples = append(r.Samples, Sample{Data: d, Token: token, ExternalVersion: version})
+	}
+
+	for _, id := range identities {
+		res := Result{Identity: id, Outcomes: make(map[Outcome]int)}
+		token := id.JWTToken
+		if token == "" {
+			token = t.token()
+		}
+		ic, err := newClient(t, token, "")
+		if err != nil {
+			r.ProtectErr = err
+			return r
+		}
+		for i, s := range r.Samples {
+			resp, err := ic.RevealWithOptions(ctx, s.Token, client.RequestOptions{Username: id.Username, ExternalVersion: s.ExternalVersion})
+			got, detail := classify(s, resp, err)
+			if unreachable(err) {
+				r.connectivity = true
+			}
+			res.Outcomes[got]++
+			if ok, why := matches(id, got, resp); !ok {
+				if why != "" {
+					detail += ", " + why
+				}
+				res.Deviations = append(res.Deviations, Deviation{Sample: i, Got: got, Detail: detail})
+			}
+		}
+		r.Results = append(r.Results, res)
+	}
+	return r
+}
+
+// token은 대상 신원의 인증 토큰입니다 (JWT가 꺼져 있으면 JWTToken이 있어도 보내지 않음)
+func (t Target) token() string {
+	if !t.JWT {
+		return ""
+	}
+	return t.JWTToken
+}
+
+// newClient는 CLI와 SDK가 쓰는 생성 경로로 대상 서버에 token으로 인증하는 클라이언트를 생성합니다
+func newClient(t Target, token, username string) (*client.Client, error) {
+	c, err := crdpengine.New(crdpengine.Settings{
+		Host:               t.Host,
+		Port:               t.Port,
+		Policy:             t.Policy,
+		TLS:                t.TLS,
+		InsecureSkipVerify: true,
+		JWTToken:           token,
+		Timeout:            time.Duration(t.Timeout) * time.Second,
+		Username:           username,
+		Headers:            t.Headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.SetLimiter(t.Limiter)
+	return c, nil
+}
+
+// unreachable은 서버 응답을 받지 못한 오류인지 확인합니다
+func unreachable(err error) bool {
+	return errors.Is(err, client.ErrTransport)
+}
+
+// classify는 reveal 응답을 결과 종류로 분류하고 보고서에 표시할 설명을 반환합니다
+func classify(s Sample, resp *client.APIResponse, err error) (Outcome, string) {
+	if err != nil {
+		return Error, err.Error()
+	}
+	if apiErr := resp.Err(); apiErr != nil {
+		if resp.StatusCode == 403 {
+			return Denied, "HTTP 403"
+		}
+		return Error, fmt.Sprintf("HTTP %d (%s)", resp.StatusCode, client.Classify(apiErr))
+	}
+	data, ok := resp.Body["data"].(string)
+	switch {
+	case !ok:
+		return Error, fmt.Sprintf("HTTP %d without data", resp.StatusCode)
+	case data == s.Data:
+		return Plain, fmt.Sprintf("HTTP %d, original value", resp.StatusCode)
+	}
+	return Masked, fmt.Sprintf("HTTP %d, %d-char value", resp.StatusCode, len(data))
+}
+
+// matches는 결과가 신원의 기대와 맞는지 확인하고, 맞지 않으면 이유를 반환합니다
+func matches(id Identity, got Outcome, resp *client.APIResponse) (bool, string) {
+	if got != id.Expect {
+		return false, ""
+	}
+	if got == Masked && id.MaskPattern != nil {
+		data, _ := resp.Body["data"].(string)
+		if !id.MaskPattern.MatchString(data) {
+			return false, fmt.Sprintf("does not match mask pattern %q", id.MaskPattern.String())
+		}
+	}
+	return true, ""
+}
+
+// Print는 신원별 검증 결과를 출력합니다 (reveal 값은 redactor 설정에 따라 가림)
+func (r *Report) Print(w io.Writer, redactor *redact.Redactor) {
+	scheme := "http"
+	if r.Target.TLS {
+		scheme = "https"
+	}
+	fmt.Fprintf(w, "Verifying access policy %s on %s://%s\n", r.Target.Policy, scheme, net.JoinHostPort(r.Target.Host, strconv.Itoa(r.Target.Port)))
+	if r.ProtectErr != nil {
+		fmt.Fprintf(w, "  [FAIL] could not protect samples: %v\n", r.ProtectErr)
+		return
+	}
+	fmt.Fprintf(w, "  %d samples protected\n", len(r.Samples))
+	for _, res := range r.Results {
+		status := "PASS"
+		if !res.Passed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(w, "  [%s] %-15s expect %-6s got %s\n", status, res.Identity.Name, res.Identity.Expect, outcomeSummary(res.Outcomes))
+		for i, d := range res.Deviations {
+			if i == 3 {
+				fmt.Fprintf(w, "         ... and %d more\n", len(res.Deviations)-i)
+				break
+			}
+			fmt.Fprintf(w, "         sample %d (%s): got %s, %s\n", d.Sample+1, redactor.Value(r.Samples[d.Sample].Data), d.Got, d.Detail)
+		}
+	}
+}
+
+// outcomeSummary는 결과 종류별 표본 수를 "plain 3, masked 2" 형태로 반환합니다
+func outcomeSummary(counts map[Outcome]int) string {
+	var parts []string
+	for _, o := range []Outcome{Plain, Masked, Denied, Error} {
+		if n := counts[o]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", o, n))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,169 @@
+package access
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+// testTarget은 httptest 서버를 가리키는 검증 대상을 만듭니다
+func testTarget(t *testing.T, srv *httptest.Server) Target {
+	t.Helper()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, portStr, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, _ := strconv.Atoi(portStr)
+	return Target{Host: host, Port: port, Timeout: 5, Policy: "P03"}
+}
+
+// revealAs는 fakecrdp에 username으로 토큰을 reveal하고 응답과 오류를 반환합니다
+func revealAs(t *testing.T, c *client.Client, s Sample, username string) (*client.APIResponse, error) {
+	t.Helper()
+	return c.RevealWithOptions(context.Background(), s.Token, client.RequestOptions{Username: username, ExternalVersion: s.ExternalVersion})
+}
+
+func TestClassifyAndMatches(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{Policies: []fakecrdp.Policy{{
+		Name:  "P03",
+		Users: map[string]fakecrdp.Access{"alice": fakecrdp.AccessPlain, "bob": fakecrdp.AccessMasked, "eve": fakecrdp.AccessDenied},
+	}}})
+	defer srv.Close()
+	target := testTarget(t, srv)
+
+	c, err := newClient(target, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.ProtectContext(context.Background(), "1234567890123")
+	if err != nil || resp.Err() != nil {
+		t.Fatalf("protect: %v, %v", err, resp.Err())
+	}
+	sample := Sample{Data: "1234567890123", Token: resp.Body["protected_data"].(string)}
+
+	tests := []struct {
+		name       string
+		username   string
+		sample     Sample
+		want       Outcome
+		wantDetail string
+		expect     Outcome
+		pattern    string
+		wantMatch  bool
+		wantWhy    string
+	}{
+		{"plain as expected", "alice", sample, Plain, "original value", Plain, "", true, ""},
+		{"plain but masked expected", "alice", sample, Plain, "original value", Masked, "", false, ""},
+		{"masked as expected", "bob", sample, Masked, "13-char value", Masked, "", true, ""},
+		{"masked matching pattern", "bob", sample, Masked, "13-char value", Masked, `^\*+0123$`, true, ""},
+		{"masked not matching pattern", "bob", sample, Masked, "13-char value", Masked, `^\d+$`, false, "does not match mask pattern"},
+		{"denied as expected", "eve", sample, Denied, "HTTP 403", Denied, "", true, ""},
+		{"denied but plain expected", "eve", sample, Denied, "HTTP 403", Plain, "", false, ""},
+		{"unknown token", "alice", Sample{Data: "x", Token: "not-a-token"}, Error, "HTTP 400", Plain, "", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := revealAs(t, c, tt.sample, tt.username)
+			got, detail := classify(tt.sample, resp, err)
+			if got != tt.want || !strings.Contains(detail, tt.wantDetail) {
+				t.Errorf("classify = %q, %q, want %q containing %q", got, detail, tt.want, tt.wantDetail)
+			}
+			id := Identity{Name: tt.name, Username: tt.username, Expect: tt.expect}
+			if tt.pattern != "" {
+				id.MaskPattern = regexp.MustCompile(tt.pattern)
+			}
+			ok, why := matches(id, got, resp)
+			if ok != tt.wantMatch || !strings.Contains(why, tt.wantWhy) {
+				t.Errorf("matches = %v, %q, want %v containing %q", ok, why, tt.wantMatch, tt.wantWhy)
+			}
+		})
+	}
+
+	// 응답을 받지 못한 오류
+	transportErr := &client.TransportError{Endpoint: "/v1/reveal", Err: errors.New("connection refused")}
+	if got, _ := classify(sample, nil, transportErr); got != Error || !unreachable(transportErr) {
+		t.Errorf("transport error classify = %q, unreachable = %v", got, unreachable(transportErr))
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{Policies: []fakecrdp.Policy{{
+		Name:            "P03",
+		ExternalVersion: "v2",
+		Users:           map[string]fakecrdp.Access{"bob": fakecrdp.AccessMasked, "eve": fakecrdp.AccessDenied},
+	}}})
+	defer srv.Close()
+
+	identities := []Identity{
+		{Name: "alice", Username: "alice", Expect: Plain},
+		{Name: "bob", Username: "bob", Expect: Masked, MaskPattern: regexp.MustCompile(`^\*+\d{4}$`)},
+		{Name: "eve", Username: "eve", Expect: Plain},
+	}
+	r := Run(context.Background(), testTarget(t, srv), []string{"1111222233334444", "5555666677778888"}, identities)
+	if r.ProtectErr != nil {
+		t.Fatalf("ProtectErr = %v", r.ProtectErr)
+	}
+	for _, s := range r.Samples {
+		if s.ExternalVersion != "v2" {
+			t.Errorf("sample version = %q, want v2", s.ExternalVersion)
+		}
+	}
+	passed := make([]bool, len(r.Results))
+	for i, res := range r.Results {
+		passed[i] = res.Passed()
+	}
+	if want := []bool{true, true, false}; !reflect.DeepEqual(passed, want) {
+		t.Errorf("passed = %v, want %v", passed, want)
+	}
+	if r.Deviations() != 2 || r.Results[2].Outcomes[Denied] != 2 || r.ConnectivityFailed() {
+		t.Errorf("deviations = %d, eve outcomes = %v, connectivity = %v", r.Deviations(), r.Results[2].Outcomes, r.ConnectivityFailed())
+	}
+}
+
+func TestRunTargetTokenRequiresJWT(t *testing.T) {
+	const secret = "s3cret"
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{JWTSecret: secret})
+	defer srv.Close()
+	target := testTarget(t, srv)
+	// 서명이 맞지 않는 토큰: 보내면 401
+	target.JWTToken = "not.a.jwt"
+
+	identities := []Identity{
+		{Name: "default", Expect: Plain},
+		{Name: "override", JWTToken: "not.a.jwt", Expect: Plain},
+	}
+	r := Run(context.Background(), target, []string{"1234567890123"}, identities)
+	if r.ProtectErr != nil {
+		t.Fatalf("JWT off: ProtectErr = %v, target token should not be sent", r.ProtectErr)
+	}
+	if !r.Results[0].Passed() {
+		t.Errorf("JWT off: default identity deviations = %+v", r.Results[0].Deviations)
+	}
+	// 신원의 자체 토큰은 JWT 설정과 관계없이 전송
+	if r.Results[1].Passed() || r.Results[1].Outcomes[Error] != 1 {
+		t.Errorf("identity token override outcomes = %v, want an error", r.Results[1].Outcomes)
+	}
+
+	target.JWT = true
+	if r := Run(context.Background(), target, []string{"1234567890123"}, nil); !errors.Is(r.ProtectErr, client.ErrUnauthorized) {
+		t.Errorf("JWT on: ProtectErr = %v, want ErrUnauthorized", r.ProtectErr)
+	}
+	target.JWTToken = fakecrdp.SignToken(secret, "svc", 0)
+	if r := Run(context.Background(), target, []string{"1234567890123"}, identities[:1]); r.ProtectErr != nil || !r.Results[0].Passed() {
+		t.Errorf("JWT on with valid token: ProtectErr = %v, results = %+v", r.ProtectErr, r.Results)
+	}
+}
@@ -0,0 +1,209 @@
+package assertion
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+)
+
+// metricKind는 지표의 단위 종류입니다
+type metricKind int
+
+const (
+	kindLatency    metricKind = iota // 밀리초 기준 지연 시간
+	kindRate                         // 0~1 비율
+	kindThroughput                   // 초당 처리 개수
+	kindCount                        // 개수
+)
+
+// metrics는 지원하는 지표와 결과 행에서 값을 꺼내는 함수입니다
+var metrics = map[string]struct {
+	kind  metricKind
+	value func(r output.Row) float64
+}{
+	"mean":             {kindLatency, func(r output.Row) float64 { return r.LatencyMean }},
+	"min":              {kindLatency, func(r output.Row) float64 { return r.LatencyMin }},
+	"max":              {kindLatency, func(r output.Row) float64 { return r.LatencyMax }},
+	"p50":              {kindLatency, func(r output.Row) float64 { return r.LatencyP50 }},
+	"p90":              {kindLatency, func(r output.Row) float64 { return r.LatencyP90 }},
+	"p95":              {kindLatency, func(r output.Row) float64 { return r.LatencyP95 }},
+	"p99":              {kindLatency, func(r output.Row) float64 { return r.LatencyP99 }},
+	"error_rate":       {kindRate, func(r output.Row) float64 { return r.ErrorRate }},
+	"match_rate":       {kindRate, func(r output.Row) float64 { return r.MatchRate }},
+	"success_rate":     {kindRate, func(r output.Row) float64 { return 1 - r.ErrorRate }},
+	"throughput":       {kindThroughput, func(r output.Row) float64 { return r.Throughput }},
+	"errors":           {kindCount, func(r output.Row) float64 { return float64(r.Errors) }},
+	"token_violations": {kindCount, func(r output.Row) float64 { return float64(total(r.TokenViolations)) }},
+}
+
+// total은 집계 값의 합을 반환합니다
+func total(counts map[string]int) int {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
+
+// exprPattern은 "<지표> <연산자> <값><단위>" 형식의 표현식입니다
+var exprPattern = regexp.MustCompile(`^\s*([a-z0-9_]+)\s*(<=|>=|==|!=|<|>)\s*([0-9]*\.?[0-9]+)\s*([a-z%/]*)\s*$`)
+
+// Assertion은 실행 결과에 대한 하나의 임계값 조건입니다
+type Assertion struct {
+	Expr   string  // 원본 표현식
+	Metric string  // 지표 이름
+	Op     string  // 비교 연산자
+	Value  float64 // 지표 기준 단위로 변환된 임계값
+	unit   string  // 표현식에 사용된 단위 (출력용)
+}
+
+// Result는 하나의 조건을 평가한 결과입니다
+type Result struct {
+	Assertion Assertion
+	Actual    float64 // 지표 기준 단위의 실제 값
+	Passed    bool
+}
+
+// Parse는 "p99 < 50ms" 같은 표현식을 Assertion으로 변환합니다
+func Parse(expr string) (Assertion, error) {
+	m := exprPattern.FindStringSubmatch(strings.ToLower(expr))
+	if m == nil {
+		return Assertion{}, fmt.Errorf("invalid assertion %q (expected e.g. \"p99 < 50ms\")", expr)
+	}
+
+	metric, op, unit := m[1], m[2], m[4]
+	def, ok := metrics[metric]
+	if !ok {
+		return Assertion{}, fmt.Errorf("unknown metric %q in assertion %q", metric, expr)
+	}
+
+	value, err := strconv.ParseFloat(m[3], 64)
+	if err != nil {
+		return Assertion{}, fmt.Errorf("invalid value in assertion %q: %w", expr, err)
+	}
+
+	// 단위를 지표 기준 단위로 변환
+	switch def.kind {
+	case kindLatency:
+		switch unit {
+		case "", "ms":
+			unit = "ms"
+		case "s":
+			value *= 1000
+		case "us":
+			value /= 1000
+		default:
+			return Assertion{}, fmt.Errorf("invalid latency unit %q in assertion %q", unit, expr)
+		}
+	case kindRate:
+		switch unit {
+		case "%":
+			value /= 100
+		case "":
+		default:
+			return Assertion{}, fmt.Errorf("invalid rate unit %q in assertion %q", unit, expr)
+		}
+	case kindThroughput:
+		if unit != "" && unit != "/s" {
+			return Assertion{}, fmt.Errorf("invalid throughput unit %q in assertion %q", unit, expr)
+		}
+		unit = "/s"
+	case kindCount:
+		if unit != "" {
+			return Assertion{}, fmt.Errorf("unexpected unit %q in assertion %q", unit, expr)
+		}
+	}
+
+	return Assertion{Expr: strings.TrimSpace(expr), Metric: metric, Op: op, Value: value, unit: unit}, nil
+}
+
+// ParseAll은 여러 표현식을 한 번에 변환합니다
+func ParseAll(exprs []string) ([]Assertion, error) {
+	assertions := make([]Assertion, 0, len(exprs))
+	for _, expr := range exprs {
+		a, err := Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		assertions = append(assertions, a)
+	}
+	return assertions, nil
+}
+
+// Evaluate는 결과 행에 대해 모든 조건을 평가합니다
+func Evaluate(assertions []Assertion, row output.Row) []Result {
+	results := make([]Result, 0, len(assertions))
+	for _, a := range assertions {
+		actual := metrics[a.Metric].value(row)
+		results = append(results, Result{Assertion: a, Actual: actual, Passed: compare(actual, a.Op, a.Value)})
+	}
+	return results
+}
+
+// Failed는 실패한 조건의 개수를 반환합니다
+func Failed(results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if !r.Passed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// PrintReport는 조건 평가 결과를 pass/fail 목록으로 출력합니다
+func PrintReport(w io.Writer, title string, results []Result) {
+	fmt.Fprintf(w, "\n%s\n", title)
+	for _, r := range results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(w, "- [%s] %s (actual %s)\n", status, r.Assertion.Expr, r.ActualString())
+	}
+}
+
+// ActualString은 실제 값을 표현식의 단위로 표시한 문자열을 반환합니다
+func (r Result) ActualString() string {
+	return r.Assertion.format(r.Actual)
+}
+
+// format은 지표 값을 표현식의 단위로 변환한 문자열을 반환합니다
+func (a Assertion) format(v float64) string {
+	switch a.unit {
+	case "ms":
+		return fmt.Sprintf("%.3fms", v)
+	case "s":
+		return fmt.Sprintf("%.4fs", v/1000)
+	case "us":
+		return fmt.Sprintf("%.1fus", v*1000)
+	case "%":
+		return fmt.Sprintf("%.3f%%", v*100)
+	case "/s":
+		return fmt.Sprintf("%.1f/s", v)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// compare는 연산자에 따라 두 값을 비교합니다
+func compare(actual float64, op string, expected float64) bool {
+	switch op {
+	case "<":
+		return actual < expected
+	case "<=":
+		return actual <= expected
+	case ">":
+		return actual > expected
+	case ">=":
+		return actual >= expected
+	case "==":
+		return actual == expected
+	case "!=":
+		return actual != expected
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package assertion
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr   string
+		metric string
+		op     string
+		value  float64
+	}{
+		{"p99 < 50ms", "p99", "<", 50},
+		{"p99<50", "p99", "<", 50},
+		{"  P95 <= 0.2s ", "p95", "<=", 200},
+		{"mean < 500us", "mean", "<", 0.5},
+		{"error_rate < 0.5%", "error_rate", "<", 0.005},
+		{"match_rate == 100%", "match_rate", "==", 1},
+		{"success_rate >= 0.99", "success_rate", ">=", 0.99},
+		{"throughput > 1000/s", "throughput", ">", 1000},
+		{"throughput >= 250", "throughput", ">=", 250},
+		{"errors == 0", "errors", "==", 0},
+		{"token_violations != 3", "token_violations", "!=", 3},
+		{"max > .5ms", "max", ">", 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			a, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.expr, err)
+			}
+			if a.Metric != tt.metric || a.Op != tt.op || math.Abs(a.Value-tt.value) > 1e-12 {
+				t.Errorf("Parse(%q) = %s %s %v, want %s %s %v", tt.expr, a.Metric, a.Op, a.Value, tt.metric, tt.op, tt.value)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"p99",
+		"p99 50ms",
+		"p99 =< 50ms",
+		"latency < 50ms",
+		"p99 < 50%",
+		"error_rate < 1ms",
+		"throughput > 10ms",
+		"errors == 1%",
+		"p99 < -5ms",
+	}
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := Parse(expr); err == nil {
+				t.Errorf("Parse(%q) succeeded, want error", expr)
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	if _, err := ParseAll([]string{"p99 < 50ms", "bogus"}); err == nil {
+		t.Error("ParseAll with an invalid expression succeeded")
+	}
+	got, err := ParseAll(nil)
+	if err != nil || len(got) != 0 {
+		t.Errorf("ParseAll(nil) = %v, %v, want empty", got, err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	row := output.Row{
+		LatencyP99: 42,
+		ErrorRate:  0.01,
+		MatchRate:  1,
+		Throughput: 900,
+		Errors:     10,
+		TokenViolations: map[string]int{
+			"same_length": 2,
+			"prefix":      1,
+		},
+	}
+	tests := []struct {
+		expr   string
+		passed bool
+	}{
+		{"p99 < 50ms", true},
+		{"p99 < 40ms", false},
+		{"p99 <= 0.042s", true},
+		{"error_rate < 0.5%", false},
+		{"error_rate <= 1%", true},
+		{"success_rate >= 99%", true},
+		{"match_rate == 100%", true},
+		{"throughput > 1000/s", false},
+		{"errors != 0", true},
+		{"token_violations == 3", true},
+		{"token_violations == 0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			a, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.expr, err)
+			}
+			results := Evaluate([]Assertion{a}, row)
+			if results[0].Passed != tt.passed {
+				t.Errorf("%q passed = %v (actual %s), want %v", tt.expr, results[0].Passed, results[0].ActualString(), tt.passed)
+			}
+		})
+	}
+}
+
+func TestFailed(t *testing.T) {
+	results := []Result{{Passed: true}, {Passed: false}, {Passed: false}}
+	if got := Failed(results); got != 2 {
+		t.Errorf("Failed() = %d, want 2", got)
+	}
+}
+
+func TestActualString(t *testing.T) {
+	tests := []struct {
+		expr   string
+		actual float64
+		want   string
+	}{
+		{"p99 < 50ms", 12.5, "12.500ms"},
+		{"p99 < 1s", 1500, "1.5000s"},
+		{"mean < 900us", 0.25, "250.0us"},
+		{"error_rate < 1%", 0.0125, "1.250%"},
+		{"throughput > 10/s", 123.45, "123.5/s"},
+		{"errors == 0", 3, "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			a, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.expr, err)
+			}
+			if got := (Result{Assertion: a, Actual: tt.actual}).ActualString(); got != tt.want {
+				t.Errorf("ActualString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
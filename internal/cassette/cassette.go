@@ -0,0 +1,180 @@
+// Package cassette는 CRDP 요청/응답 쌍을 JSON Lines 카세트 파일로 기록하고,
+// 기록한 요청을 다시 보내거나(replay) 기록한 응답을 돌려주는(serve) 기능을 제공합니다
+package cassette
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/redact"
+)
+
+// Version은 카세트 파일 형식 버전입니다
+const Version = 1
+
+// Header는 카세트 파일 첫 줄입니다
+type Header struct {
+	Version    int       `json:"cassette_version"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Redact     string    `json:"redact"` // 기록 시 적용한 가림 방식 (none이 아니면 요청을 그대로 다시 보낼 수 없음)
+}
+
+// Interaction은 기록된 요청/응답 한 쌍입니다
+type Interaction struct {
+	Seq         int             `json:"seq"`
+	OffsetMs    float64         `json:"offset_ms"` // 기록 시작부터 요청 전송까지의 시간
+	Endpoint    string          `json:"endpoint"`
+	URL         string          `json:"url"`
+	RequestID   string          `json:"request_id"`
+	Request     json.RawMessage `json:"request"`
+	Status      int             `json:"status"` // 전송 오류이면 0
+	Response    json.RawMessage `json:"response,omitempty"`
+	ResponseRaw string          `json:"response_raw,omitempty"` // JSON이 아닌 응답 본문
+	ElapsedMs   float64         `json:"elapsed_ms"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// Cassette는 카세트 파일 내용입니다
+type Cassette struct {
+	Header       Header
+	Interactions []Interaction
+}
+
+// Recorder는 client.Recorder를 구현하여 요청/응답 쌍을 카세트 파일에 기록합니다
+// 요청/응답 본문의 data/protected_data 값은 redactor로 가립니다
+type Recorder struct {
+	redactor *redact.Redactor
+
+	mu    sync.Mutex
+	file  *os.File
+	w     *bufio.Writer
+	enc   *json.Encoder
+	start time.Time
+	seq   int
+	err   error
+}
+
+var _ client.Recorder = (*Recorder)(nil)
+
+// NewRecorder는 path에 카세트 파일을 만들고 헤더를 기록합니다
+func NewRecorder(path string, redactor *redact.Redactor) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cassette: %w", err)
+	}
+	r := &Recorder{redactor: redactor, file: f, w: bufio.NewWriter(f), start: time.Now()}
+	r.enc = json.NewEncoder(r.w)
+	r.enc.SetEscapeHTML(false)
+	if err := r.enc.Encode(Header{Version: Version, RecordedAt: r.start, Redact: string(redactor.Mode())}); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return r, nil
+}
+
+// Record는 client.Recorder 구현입니다
+func (r *Recorder) Record(ex client.Exchange) {
+	it := Interaction{
+		Endpoint:  ex.Endpoint,
+		URL:       ex.URL,
+		RequestID: ex.RequestID,
+		Request:   json.RawMessage(r.redactor.JSON(ex.Request)),
+		Status:    ex.StatusCode,
+		ElapsedMs: float64(ex.Elapsed.Microseconds()) / 1000,
+	}
+	if ex.Err != nil {
+		it.Error = ex.Err.Error()
+	}
+	if len(ex.Response) > 0 {
+		if body := r.redactor.JSON(ex.Response); json.Valid([]byte(body)) {
+			it.Response = json.RawMessage(body)
+		} else {
+			it.ResponseRaw = body
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.seq++
+	it.Seq = r.seq
+	it.OffsetMs = float64(ex.Start.Sub(r.start).Microseconds()) / 1000
+	r.err = r.enc.Encode(it)
+}
+
+// Close는 버퍼를 비우고 파일을 닫습니다 (기록 중 발생한 첫 오류를 반환)
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.w.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if r.err != nil {
+		return fmt.Errorf("failed to write cassette: %w", r.err)
+	}
+	return nil
+}
+
+// Count는 지금까지 기록한 요청/응답 쌍의 수를 반환합니다
+func (r *Recorder) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seq
+}
+
+// Load는 카세트 파일을 읽습니다 (요청 전송 시각 순으로 정렬)
+func Load(path string) (*Cassette, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	c := &Cassette{}
+	line := 0
+	for sc.Scan() {
+		line++
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		if line == 1 {
+			if err := json.Unmarshal(sc.Bytes(), &c.Header); err != nil || c.Header.Version == 0 {
+				return nil, fmt.Errorf("%s: not a cassette file (missing header)", path)
+			}
+			if c.Header.Version > Version {
+				return nil, fmt.Errorf("%s: unsupported cassette version %d", path, c.Header.Version)
+			}
+			continue
+		}
+		var it Interaction
+		if err := json.Unmarshal(sc.Bytes(), &it); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		c.Interactions = append(c.Interactions, it)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	if line == 0 {
+		return nil, fmt.Errorf("%s: empty cassette", path)
+	}
+	sortByOffset(c.Interactions)
+	return c, nil
+}
+
+// Redacted는 기록 시 데이터를 가렸는지 확인합니다
+func (c *Cassette) Redacted() bool {
+	return c.Header.Redact != "" && c.Header.Redact != string(redact.None)
+}
@@ -0,0 +1,241 @@
+package cassette
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+	"github.com/sjrhee/crdp-cli-go/internal/redact"
+)
+
+// newClient는 srv에 연결한 클라이언트를 생성합니다
+func newClient(t *testing.T, srv *httptest.Server) *client.Client {
+	t.Helper()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse server URL: %v", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("parse server port: %v", err)
+	}
+	c := client.NewClient(u.Hostname(), port, "P03", 5, false)
+	c.SetShowBody(false)
+	return c
+}
+
+// session은 기록하거나 재현할 요청 순서이며 protect 토큰 목록을 반환합니다
+func session(t *testing.T, c *client.Client) []string {
+	t.Helper()
+	ctx := context.Background()
+	var tokens []string
+	for _, data := range []string{"1234567890123", "9876543210987"} {
+		resp, err := c.ProtectContext(ctx, data)
+		if err != nil || resp.StatusCode != 200 {
+			t.Fatalf("protect %s: %v, %+v", data, err, resp)
+		}
+		token, _ := resp.Body["protected_data"].(string)
+		tokens = append(tokens, token)
+	}
+	resp, err := c.RevealContext(ctx, tokens[0])
+	if err != nil || resp.StatusCode != 200 {
+		t.Fatalf("reveal: %v, %+v", err, resp)
+	}
+	if data, _ := resp.Body["data"].(string); data != "1234567890123" {
+		t.Fatalf("reveal = %q, want the first value", data)
+	}
+	if resp, err := c.ProtectBulkContext(ctx, []string{"1111222233334444", "5555666677778888"}); err != nil || resp.StatusCode != 200 {
+		t.Fatalf("protectbulk: %v, %+v", err, resp)
+	}
+	return tokens
+}
+
+// record는 가짜 CRDP 서버에 session을 보내며 mode로 가려 카세트에 기록하고 파일 경로와 토큰을 반환합니다
+func record(t *testing.T, mode redact.Mode) (string, []string) {
+	t.Helper()
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{Seed: 1})
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	rec, err := NewRecorder(path, redact.New(mode))
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	c := newClient(t, srv)
+	c.SetRecorder(rec)
+	tokens := session(t, c)
+	if rec.Count() != 4 {
+		t.Errorf("Count = %d, want 4", rec.Count())
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return path, tokens
+}
+
+func TestRecordLoadServe(t *testing.T) {
+	path, recorded := record(t, redact.None)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.Header.Version != Version || c.Redacted() {
+		t.Errorf("header = %+v, want version %d without redaction", c.Header, Version)
+	}
+	var endpoints []string
+	for i, it := range c.Interactions {
+		endpoints = append(endpoints, it.Endpoint)
+		if it.Seq != i+1 || it.Status != 200 || it.RequestID == "" {
+			t.Errorf("interaction %d = %+v", i, it)
+		}
+	}
+	if got := strings.Join(endpoints, ","); got != "/v1/protect,/v1/protect,/v1/reveal,/v1/protectbulk" {
+		t.Errorf("endpoints = %s", got)
+	}
+	if !strings.Contains(string(c.Interactions[0].Request), `"1234567890123"`) {
+		t.Errorf("request = %s, want the original data", c.Interactions[0].Request)
+	}
+
+	player := NewPlayer(c, false)
+	srv := httptest.NewServer(player)
+	defer srv.Close()
+	if served := session(t, newClient(t, srv)); strings.Join(served, ",") != strings.Join(recorded, ",") {
+		t.Errorf("served tokens = %v, want recorded %v", served, recorded)
+	}
+
+	// 모든 기록을 사용했으므로 같은 요청도 일치하는 기록이 없음
+	resp, err := newClient(t, srv).ProtectContext(context.Background(), "1234567890123")
+	if err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("extra request: %v, %+v, want 404", err, resp)
+	}
+	if served, misses := player.Stats(); served != 4 || misses != 1 {
+		t.Errorf("Stats = %d served, %d misses, want 4 and 1", served, misses)
+	}
+}
+
+func TestRecordRedactedServe(t *testing.T) {
+	path, recorded := record(t, redact.Mask)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !c.Redacted() || c.Header.Redact != string(redact.Mask) {
+		t.Fatalf("header = %+v, want mask redaction", c.Header)
+	}
+	for _, it := range c.Interactions {
+		if strings.Contains(string(it.Request), "1234567890123") || strings.Contains(string(it.Response), recorded[0]) {
+			t.Errorf("interaction %d leaks unredacted data: %s -> %s", it.Seq, it.Request, it.Response)
+		}
+	}
+
+	// 가린 카세트는 본문이 일치하지 않아도 엔드포인트별 기록 순서대로 응답
+	player := NewPlayer(c, false)
+	srv := httptest.NewServer(player)
+	defer srv.Close()
+	cl := newClient(t, srv)
+	ctx := context.Background()
+	for _, data := range []string{"1234567890123", "9876543210987"} {
+		resp, err := cl.ProtectContext(ctx, data)
+		if err != nil || resp.StatusCode != 200 {
+			t.Fatalf("protect %s: %v, %+v", data, err, resp)
+		}
+		if token, _ := resp.Body["protected_data"].(string); !strings.HasPrefix(token, "*") {
+			t.Errorf("served token = %q, want the masked recording", token)
+		}
+	}
+	if served, misses := player.Stats(); served != 2 || misses != 0 {
+		t.Errorf("Stats = %d served, %d misses, want 2 and 0", served, misses)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"empty", "", "empty cassette"},
+		{"missing header", `{"seq":1,"endpoint":"/v1/protect","status":200}` + "\n", "not a cassette file"},
+		{"newer version", `{"cassette_version":2,"redact":"none"}` + "\n", "unsupported cassette version 2"},
+		{"bad interaction", `{"cassette_version":1,"redact":"none"}` + "\n" + `{"seq":` + "\n", ":2:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cassette.jsonl")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil || !strings.Contains(err.Error(), "failed to open cassette") {
+		t.Errorf("Load missing file err = %v", err)
+	}
+}
+
+func TestLoadSortsByOffset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	content := `{"cassette_version":1,"redact":"none"}` + "\n" +
+		`{"seq":1,"offset_ms":5,"endpoint":"/v1/protect","status":200}` + "\n" +
+		`{"seq":2,"offset_ms":1,"endpoint":"/v1/protect","status":200}` + "\n" +
+		`{"seq":3,"offset_ms":1,"endpoint":"/v1/reveal","status":200}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	var seqs []int
+	for _, it := range c.Interactions {
+		seqs = append(seqs, it.Seq)
+	}
+	if len(seqs) != 3 || seqs[0] != 2 || seqs[1] != 3 || seqs[2] != 1 {
+		t.Errorf("order = %v, want [2 3 1]", seqs)
+	}
+}
+
+func TestPlayerMatch(t *testing.T) {
+	interactions := []Interaction{
+		{Seq: 1, Endpoint: "/v1/protect", Request: []byte(`{"data":"a","protection_policy_name":"P03"}`), Status: 200},
+		{Seq: 2, Endpoint: "/v1/protect", Request: []byte(`{"data":"b","protection_policy_name":"P03"}`), Status: 200},
+	}
+	tests := []struct {
+		name   string
+		redact string
+		bodies []string
+		want   []int // 돌려준 기록 번호 (0이면 일치 없음)
+	}{
+		{"strict exact", "none", []string{`{"protection_policy_name":"P03", "data":"b"}`, `{"data":"a","protection_policy_name":"P03"}`}, []int{2, 1}},
+		{"strict no fallback", "none", []string{`{"data":"c","protection_policy_name":"P03"}`}, []int{0}},
+		{"strict used once", "none", []string{`{"data":"a","protection_policy_name":"P03"}`, `{"data":"a","protection_policy_name":"P03"}`}, []int{1, 0}},
+		{"redacted prefers exact", "mask", []string{`{"data":"b","protection_policy_name":"P03"}`, `{"data":"z"}`}, []int{2, 1}},
+		{"redacted in order", "mask", []string{`{"data":"x"}`, `{"data":"y"}`, `{"data":"z"}`}, []int{1, 2, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlayer(&Cassette{Header: Header{Version: Version, Redact: tt.redact}, Interactions: interactions}, false)
+			for i, body := range tt.bodies {
+				got := 0
+				if e := p.match("/v1/protect", canonical([]byte(body))); e != nil {
+					got = e.it.Seq
+				}
+				if got != tt.want[i] {
+					t.Errorf("request %d matched seq %d, want %d", i+1, got, tt.want[i])
+				}
+			}
+			if e := p.match("/v1/reveal", canonical([]byte(tt.bodies[0]))); e != nil {
+				t.Errorf("reveal matched a protect recording (seq %d)", e.it.Seq)
+			}
+		})
+	}
+}
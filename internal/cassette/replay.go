@@ -0,0 +1,315 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+)
+
+// ReplayOptions는 기록한 요청을 서버로 다시 보낼 때의 설정입니다
+type ReplayOptions struct {
+	Target   string          // 대상 서버 주소 (예: https://192.168.0.231:32082)
+	Speed    float64         // 1이면 기록된 간격 그대로, 2이면 두 배 빠르게, 0이면 간격 없이 순서대로
+	JWTToken string          // 설정하면 Authorization: Bearer 헤더 전송 (카세트에는 토큰을 기록하지 않음)
+	Timeout  time.Duration   // 요청별 타임아웃
+	Limiter  *client.Limiter // 설정하면 요청마다 속도/동시 요청 제한 적용 (nil이면 제한 없음)
+	Logger   *slog.Logger
+}
+
+// ReplayResult는 다시 보낸 요청 한 건의 결과입니다
+type ReplayResult struct {
+	Seq            int     `json:"seq"`
+	Endpoint       string  `json:"endpoint"`
+	RecordedStatus int     `json:"recorded_status"`
+	Status         int     `json:"status"`
+	ElapsedMs      float64 `json:"elapsed_ms"`
+	Error          string  `json:"error,omitempty"`
+	Matched        bool    `json:"matched"` // 상태 코드(전송 오류 여부 포함)가 기록과 같은지
+}
+
+// ReplaySummary는 다시 보내기 결과 요약입니다
+type ReplaySummary struct {
+	Sent       int
+	Matched    int
+	Mismatched int
+	Errors     int // 전송 오류 수
+	Results    []ReplayResult
+}
+
+// Replay는 카세트의 요청을 대상 서버로 다시 보내고 기록된 상태 코드와 비교합니다
+func Replay(ctx context.Context, c *Cassette, opts ReplayOptions) *ReplaySummary {
+	if opts.Logger == nil {
+		opts.Logger = logging.Discard()
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	httpClient := &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, MaxIdleConnsPerHost: 100},
+	}
+	target := strings.TrimRight(opts.Target, "/")
+
+	results := make([]ReplayResult, len(c.Interactions))
+	n := len(c.Interactions) // 취소되면 보낸 요청 수
+	send := func(i int) {
+		it := c.Interactions[i]
+		res := ReplayResult{Seq: it.Seq, Endpoint: it.Endpoint, RecordedStatus: it.Status}
+		release, _, err := opts.Limiter.Acquire(ctx, it.Endpoint, requestItems(it.Request))
+		if err != nil {
+			res.Error = fmt.Sprintf("rate limiter: %v", err)
+			opts.Logger.Warn("request not replayed", "seq", it.Seq, "endpoint", it.Endpoint, "error", res.Error)
+			results[i] = res
+			return
+		}
+		defer release()
+		start := time.Now()
+		status, err := post(ctx, httpClient, target+it.Endpoint, it, opts.JWTToken)
+		res.ElapsedMs = float64(time.Since(start).Microseconds()) / 1000
+		res.Status = status
+		if err != nil {
+			res.Error = err.Error()
+		}
+		res.Matched = status == it.Status
+		if !res.Matched {
+			opts.Logger.Warn("replayed status differs", "seq", it.Seq, "endpoint", it.Endpoint,
+				"request_id", it.RequestID, "recorded_status", it.Status, "status", status, "error", res.Error)
+		} else {
+			opts.Logger.Debug("request replayed", "seq", it.Seq, "endpoint", it.Endpoint, "status", status)
+		}
+		results[i] = res
+	}
+
+	if opts.Speed <= 0 {
+		for i := range c.Interactions {
+			if ctx.Err() != nil {
+				n = i
+				break
+			}
+			send(i)
+		}
+	} else {
+		// 기록된 전송 시각에 맞춰 보내므로 동시에 보낸 요청은 다시 동시에 보냄
+		var wg sync.WaitGroup
+		start := time.Now()
+		for i, it := range c.Interactions {
+			at := start.Add(time.Duration(it.OffsetMs / opts.Speed * float64(time.Millisecond)))
+			select {
+			case <-time.After(time.Until(at)):
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				n = i
+				break
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				send(i)
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	s := &ReplaySummary{Results: results[:n]}
+	for _, r := range s.Results {
+		s.Sent++
+		if r.Matched {
+			s.Matched++
+		} else {
+			s.Mismatched++
+		}
+		if r.Status == 0 {
+			s.Errors++
+		}
+	}
+	return s
+}
+
+// requestItems는 기록된 요청 본문의 데이터 개수를 반환합니다 (bulk 요청은 배열 길이, 그 외 1)
+func requestItems(body []byte) int {
+	var req struct {
+		DataArray          []json.RawMessage `json:"data_array"`
+		ProtectedDataArray []json.RawMessage `json:"protected_data_array"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return 1
+	}
+	switch {
+	case len(req.DataArray) > 0:
+		return len(req.DataArray)
+	case len(req.ProtectedDataArray) > 0:
+		return len(req.ProtectedDataArray)
+	}
+	return 1
+}
+
+// post는 기록된 요청 본문을 그대로 보내고 상태 코드를 반환합니다 (전송 오류이면 0)
+func post(ctx context.Context, httpClient *http.Client, url string, it Interaction, jwtToken string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(it.Request))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", it.RequestID)
+	if jwtToken != "" {
+		req.Header.Set("Authorization", "Bearer "+jwtToken)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, err
+	}
+	return resp.StatusCode, nil
+}
+
+// Player는 기록된 응답을 돌려주는 http.Handler입니다 (오프라인 재현 테스트용)
+// 엔드포인트와 요청 본문이 같은 기록 중 아직 사용하지 않은 첫 기록을 돌려주며,
+// 데이터를 가린 카세트는 본문이 일치할 수 없으므로 엔드포인트별 기록 순서대로 돌려줍니다
+type Player struct {
+	strict bool
+	timing bool
+	logger *slog.Logger
+
+	mu         sync.Mutex
+	byEndpoint map[string][]*entry
+	served     int
+	misses     int
+}
+
+// entry는 Player가 돌려줄 기록 하나입니다
+type entry struct {
+	it        Interaction
+	canonical string
+	used      bool
+}
+
+// NewPlayer는 카세트로 Player를 생성합니다
+// timing이 true이면 기록된 응답 시간만큼 기다렸다가 응답합니다
+func NewPlayer(c *Cassette, timing bool) *Player {
+	p := &Player{
+		strict:     !c.Redacted(),
+		timing:     timing,
+		logger:     logging.Discard(),
+		byEndpoint: make(map[string][]*entry),
+	}
+	for _, it := range c.Interactions {
+		p.byEndpoint[it.Endpoint] = append(p.byEndpoint[it.Endpoint], &entry{it: it, canonical: canonical(it.Request)})
+	}
+	return p
+}
+
+// SetLogger는 요청 처리 로그를 기록할 로거를 설정합니다
+func (p *Player) SetLogger(l *slog.Logger) {
+	p.logger = l
+}
+
+// Stats는 기록으로 응답한 요청 수와 일치하는 기록이 없던 요청 수를 반환합니다
+func (p *Player) Stats() (served, misses int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.served, p.misses
+}
+
+// ServeHTTP는 http.Handler 구현입니다
+func (p *Player) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	e := p.match(r.URL.Path, canonical(body))
+	if e == nil {
+		p.logger.Warn("no recorded interaction", "path", r.URL.Path, "request_id", r.Header.Get("X-Request-ID"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"status": "Error", "error": "no recorded interaction matches this request"})
+		return
+	}
+	p.logger.Debug("serving recorded interaction", "path", r.URL.Path, "seq", e.it.Seq, "status", e.it.Status)
+
+	if p.timing && e.it.ElapsedMs > 0 {
+		select {
+		case <-time.After(time.Duration(e.it.ElapsedMs * float64(time.Millisecond))):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if e.it.Status == 0 {
+		// 기록 당시 전송 오류였으므로 응답 없이 연결을 끊음
+		panic(http.ErrAbortHandler)
+	}
+	if len(e.it.Response) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(e.it.Status)
+		w.Write(e.it.Response)
+		return
+	}
+	w.WriteHeader(e.it.Status)
+	io.WriteString(w, e.it.ResponseRaw)
+}
+
+// match는 요청에 돌려줄 기록을 찾아 사용 처리합니다
+func (p *Player) match(endpoint, body string) *entry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var fallback *entry
+	for _, e := range p.byEndpoint[endpoint] {
+		if e.used {
+			continue
+		}
+		if e.canonical == body {
+			fallback = e
+			break
+		}
+		if fallback == nil && !p.strict {
+			fallback = e
+		}
+	}
+	if fallback == nil {
+		p.misses++
+		return nil
+	}
+	fallback.used = true
+	p.served++
+	return fallback
+}
+
+// canonical은 키 순서와 공백에 관계없이 비교할 수 있도록 JSON을 다시 인코딩합니다
+func canonical(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return string(bytes.TrimSpace(b))
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return string(b)
+	}
+	return string(out)
+}
+
+// sortByOffset은 기록을 요청 전송 시각 순으로 정렬합니다 (같으면 기록 순서)
+func sortByOffset(its []Interaction) {
+	sort.SliceStable(its, func(i, j int) bool {
+		if its[i].OffsetMs != its[j].OffsetMs {
+			return its[i].OffsetMs < its[j].OffsetMs
+		}
+		return its[i].Seq < its[j].Seq
+	})
+}
+
+// String은 요약을 한 줄로 반환합니다
+func (s *ReplaySummary) String() string {
+	return fmt.Sprintf("%d sent, %d matched, %d mismatched, %d transport errors", s.Sent, s.Matched, s.Mismatched, s.Errors)
+}
@@ -0,0 +1,57 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+func TestReplayRateLimit(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{})
+	defer srv.Close()
+
+	c := &Cassette{Header: Header{Version: Version, Redact: "none"}}
+	for i := 1; i <= 4; i++ {
+		c.Interactions = append(c.Interactions, Interaction{
+			Seq:      i,
+			Endpoint: "/v1/protect",
+			Request:  json.RawMessage(`{"data":"1234567890123","protection_policy_name":"P03"}`),
+			Status:   200,
+		})
+	}
+
+	// 초당 20건, 버킷 1: 첫 요청 이후 3건은 각각 50ms씩 기다려야 함
+	limiter := client.NewLimiter(client.Limit{}, map[string]client.Limit{"protect": {RequestsPerSec: 20}})
+	start := time.Now()
+	s := Replay(context.Background(), c, ReplayOptions{Target: srv.URL, Limiter: limiter})
+	elapsed := time.Since(start)
+
+	if s.Sent != 4 || s.Matched != 4 {
+		t.Fatalf("summary = %s, want 4 sent and matched", s)
+	}
+	if elapsed < 140*time.Millisecond {
+		t.Errorf("replay took %v, want at least 150ms under a 20 req/s limit", elapsed)
+	}
+}
+
+func TestRequestItems(t *testing.T) {
+	tests := []struct {
+		body string
+		want int
+	}{
+		{`{"data":"123"}`, 1},
+		{`{"data_array":["1","2","3"]}`, 3},
+		{`{"protected_data_array":[{"protected_data":"1"},{"protected_data":"2"}]}`, 2},
+		{`{"data_array":[]}`, 1},
+		{`not json`, 1},
+	}
+	for _, tt := range tests {
+		if got := requestItems([]byte(tt.body)); got != tt.want {
+			t.Errorf("requestItems(%s) = %d, want %d", tt.body, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,460 @@
+package check
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/crdpengine"
+)
+
+// Status는 점검 항목의 결과입니다
+type Status string
+
+const (
+	Pass Status = "PASS"
+	Warn Status = "WARN" // 통과했지만 주의가 필요함
+	Fail Status = "FAIL"
+	Skip Status = "SKIP" // 설정상 해당 없음 또는 앞 단계 실패로 건너뜀
+)
+
+// Result는 점검 항목 하나의 결과입니다
+type Result struct {
+	Name   string
+	Status Status
+	Detail string // 측정값이나 오류 내용
+	Hint   string // 실패/경고 시 조치 방법
+}
+
+// Target은 점검 대상과 인증/정책 설정입니다
+type Target struct {
+	Host     string
+	Port     int
+	TLS      bool
+	Timeout  int // 초
+	Policy   string
+	JWT      bool
+	JWTToken string
+	Headers  map[string]string // 모든 요청에 추가할 HTTP 헤더
+	Canary   string            // protect/reveal 왕복에 사용할 값
+}
+
+// Report는 한 대상에 대한 점검 결과 목록입니다
+type Report struct {
+	Target  Target
+	Results []Result
+
+	connectivity bool // 연결 단계(DNS/TCP/TLS/전송)에서 실패했는지 여부
+}
+
+// Failed는 실패한 항목 수를 반환합니다
+func (r *Report) Failed() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == Fail {
+			n++
+		}
+	}
+	return n
+}
+
+// Warnings는 경고 항목 수를 반환합니다
+func (r *Report) Warnings() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == Warn {
+			n++
+		}
+	}
+	return n
+}
+
+// ConnectivityFailed는 서버에 연결하지 못해 실패했는지 확인합니다
+func (r *Report) ConnectivityFailed() bool {
+	return r.connectivity
+}
+
+// Run은 DNS, TCP 연결, TLS, JWT, protect/reveal 왕복을 차례로 점검합니다
+// 앞 단계가 실패하면 뒤 단계는 Skip으로 기록합니다
+func Run(t Target) *Report {
+	r := &Report{Target: t}
+	timeout := time.Duration(t.Timeout) * time.Second
+	addr := net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+
+	if !r.add(checkResolve(t.Host, timeout)) {
+		r.connectivity = true
+		r.skip("TCP connect", "TLS handshake", "JWT token", "Authentication", "Protect canary", "Reveal canary", "Round-trip")
+		return r
+	}
+	if !r.add(checkConnect(addr, timeout)) {
+		r.connectivity = true
+		r.skip("TLS handshake", "JWT token", "Authentication", "Protect canary", "Reveal canary", "Round-trip")
+		return r
+	}
+	if !r.add(checkTLS(t, addr, timeout)) {
+		r.connectivity = true
+		r.skip("JWT token", "Authentication", "Protect canary", "Reveal canary", "Round-trip")
+		return r
+	}
+	jwtOK := r.add(checkJWT(t, time.Now()))
+
+	c, err := newClient(t, timeout)
+	if err != nil {
+		r.skip("Authentication")
+		r.add(Result{Name: "Protect canary", Status: Fail, Detail: err.Error(), Hint: "Check api.headers and --header values."})
+		r.skip("Reveal canary", "Round-trip")
+		return r
+	}
+	ctx := context.Background()
+
+	// protect: 전송, 인증, 정책 순으로 판정
+	resp, err := c.ProtectContext(ctx, t.Canary)
+	if err != nil {
+		r.connectivity = errors.Is(err, client.ErrTransport)
+		r.skip("Authentication")
+		r.add(Result{Name: "Protect canary", Status: Fail, Detail: err.Error(), Hint: transportHint(t, err)})
+		r.skip("Reveal canary", "Round-trip")
+		return r
+	}
+	if !r.add(checkAuth(t, resp, jwtOK, "protect")) {
+		r.skip("Protect canary", "Reveal canary", "Round-trip")
+		return r
+	}
+	token, ok := protectResult(r, t, resp)
+	if !ok {
+		r.skip("Reveal canary", "Round-trip")
+		return r
+	}
+
+	// reveal 및 왕복 비교
+	resp, err = c.RevealContext(ctx, token)
+	if err != nil {
+		r.connectivity = errors.Is(err, client.ErrTransport)
+		r.add(Result{Name: "Reveal canary", Status: Fail, Detail: err.Error(), Hint: transportHint(t, err)})
+		r.skip("Round-trip")
+		return r
+	}
+	restored, ok := revealResult(r, t, resp)
+	if !ok {
+		r.skip("Round-trip")
+		return r
+	}
+	if restored == t.Canary {
+		r.add(Result{Name: "Round-trip", Status: Pass, Detail: "revealed value matches canary"})
+	} else {
+		r.add(Result{Name: "Round-trip", Status: Fail,
+			Detail: fmt.Sprintf("revealed value differs from canary (%d chars vs %d)", len(restored), len(t.Canary)),
+			Hint:   "The user may only be allowed masked or no reveal under this policy. Check the policy's access rules for this JWT subject."})
+	}
+	return r
+}
+
+// newClient는 CLI와 SDK가 쓰는 생성 경로로 점검용 클라이언트를 생성합니다 (재시도 없음)
+func newClient(t Target, timeout time.Duration) (*client.Client, error) {
+	settings := crdpengine.Settings{
+		Host:               t.Host,
+		Port:               t.Port,
+		Policy:             t.Policy,
+		TLS:                t.TLS,
+		InsecureSkipVerify: true,
+		Timeout:            timeout,
+		Headers:            t.Headers,
+	}
+	if t.JWT {
+		settings.JWTToken = t.JWTToken
+	}
+	return crdpengine.New(settings)
+}
+
+// add는 결과를 추가하고 실패가 아니면 true를 반환합니다
+func (r *Report) add(res Result) bool {
+	r.Results = append(r.Results, res)
+	return res.Status != Fail
+}
+
+// skip은 앞 단계 실패로 실행하지 않은 항목을 기록합니다
+func (r *Report) skip(names ...string) {
+	for _, name := range names {
+		r.Results = append(r.Results, Result{Name: name, Status: Skip, Detail: "not run (previous check failed)"})
+	}
+}
+
+// checkResolve는 호스트 이름을 확인합니다
+func checkResolve(host string, timeout time.Duration) Result {
+	res := Result{Name: "Resolve host"}
+	if net.ParseIP(host) != nil {
+		res.Status, res.Detail = Pass, host+" (IP address)"
+		return res
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		res.Status, res.Detail = Fail, err.Error()
+		res.Hint = fmt.Sprintf("Cannot resolve %q. Check api.host (--host) for typos and that this machine can reach its DNS server.", host)
+		return res
+	}
+	res.Status, res.Detail = Pass, strings.Join(addrs, ", ")
+	return res
+}
+
+// checkConnect는 TCP 연결을 확인합니다
+func checkConnect(addr string, timeout time.Duration) Result {
+	res := Result{Name: "TCP connect"}
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		res.Status, res.Detail = Fail, err.Error()
+		var netErr net.Error
+		switch {
+		case errors.Is(err, syscall.ECONNREFUSED):
+			res.Hint = fmt.Sprintf("Nothing is listening on %s. Check api.port (--port) and that the CRDP service is running.", addr)
+		case errors.As(err, &netErr) && netErr.Timeout():
+			res.Hint = fmt.Sprintf("Connection to %s timed out. Check firewall rules and the network path, or raise api.timeout.", addr)
+		default:
+			res.Hint = fmt.Sprintf("Cannot open a TCP connection to %s. Check api.host and api.port.", addr)
+		}
+		return res
+	}
+	conn.Close()
+	res.Status, res.Detail = Pass, fmt.Sprintf("%s in %.1fms", addr, float64(time.Since(start).Microseconds())/1000)
+	return res
+}
+
+// checkTLS는 TLS 핸드셰이크와 인증서 유효 기간을 확인합니다
+// 클라이언트는 인증서 체인을 검증하지 않으므로 신뢰할 수 없는 인증서는 실패로 보지 않습니다
+func checkTLS(t Target, addr string, timeout time.Duration) Result {
+	res := Result{Name: "TLS handshake"}
+	if !t.TLS {
+		res.Status, res.Detail = Skip, "disabled (api.tls: false)"
+		return res
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{InsecureSkipVerify: true, ServerName: t.Host})
+	if err != nil {
+		res.Status, res.Detail = Fail, err.Error()
+		res.Hint = "TLS handshake failed. If the server serves plain HTTP on this port, set api.tls: false (--tls false)."
+		return res
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	res.Status = Pass
+	res.Detail = tls.VersionName(state.Version)
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		res.Detail += fmt.Sprintf(", CN=%s, expires %s", cert.Subject.CommonName, cert.NotAfter.Format("2006-01-02"))
+		now := time.Now()
+		switch {
+		case now.After(cert.NotAfter):
+			res.Status = Warn
+			res.Hint = "The server certificate has expired. Renew it before other clients that verify certificates start failing."
+		case now.Before(cert.NotBefore):
+			res.Status = Warn
+			res.Hint = "The server certificate is not valid yet. Check the server clock and certificate."
+		}
+	}
+	return res
+}
+
+// checkJWT는 JWT 토큰의 형식과 유효 기간을 로컬에서 확인합니다 (서명은 서버가 검증)
+func checkJWT(t Target, now time.Time) Result {
+	res := Result{Name: "JWT token"}
+	if !t.JWT {
+		res.Status, res.Detail = Skip, "disabled (auth.jwt: false)"
+		return res
+	}
+	if t.JWTToken == "" {
+		res.Status, res.Detail = Fail, "auth.jwt is enabled but no token is configured"
+		res.Hint = "Set auth.jwt_token in config.yaml or pass --jwt-token."
+		return res
+	}
+
+	claims, err := decodeClaims(t.JWTToken)
+	if err != nil {
+		res.Status, res.Detail = Warn, err.Error()
+		res.Hint = "The token could not be decoded locally; the server will still validate it. Check that it is a complete JWT (header.payload.signature)."
+		return res
+	}
+
+	var parts []string
+	if claims.Subject != "" {
+		parts = append(parts, "sub="+claims.Subject)
+	}
+	if claims.NotBefore > 0 && now.Before(time.Unix(claims.NotBefore, 0)) {
+		res.Status, res.Detail = Fail, "token not valid before "+time.Unix(claims.NotBefore, 0).Format(time.RFC3339)
+		res.Hint = "The token is not valid yet. Check the issuer's and this machine's clocks."
+		return res
+	}
+	if claims.ExpiresAt > 0 {
+		exp := time.Unix(claims.ExpiresAt, 0)
+		if now.After(exp) {
+			res.Status, res.Detail = Fail, "token expired at "+exp.Format(time.RFC3339)
+			res.Hint = "Issue a new token and update auth.jwt_token (--jwt-token)."
+			return res
+		}
+		parts = append(parts, "expires in "+humanDuration(exp.Sub(now)))
+	} else {
+		parts = append(parts, "no exp claim")
+	}
+	res.Status, res.Detail = Pass, strings.Join(parts, ", ")
+	return res
+}
+
+// humanDuration은 남은 시간을 읽기 쉬운 문자열로 반환합니다 (2일 이상이면 일 단위)
+func humanDuration(d time.Duration) string {
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%d days", int(d/(24*time.Hour)))
+	}
+	return d.Round(time.Minute).String()
+}
+
+// claims는 점검에 필요한 JWT 클레임입니다
+type claims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+}
+
+// decodeClaims는 JWT 페이로드를 서명 검증 없이 디코딩합니다
+func decodeClaims(token string) (*claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token has %d parts, expected 3", len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token payload: %w", err)
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+	return &c, nil
+}
+
+// checkAuth는 응답 상태 코드로 인증/인가 결과를 판정합니다
+func checkAuth(t Target, resp *client.APIResponse, jwtOK bool, op string) Result {
+	res := Result{Name: "Authentication"}
+	switch resp.StatusCode {
+	case 401:
+		res.Status, res.Detail = Fail, "HTTP 401 Unauthorized"+serverMessage(resp)
+		switch {
+		case !t.JWT:
+			res.Hint = "The server requires a JWT. Set auth.jwt: true and auth.jwt_token (--jwt true --jwt-token ...)."
+		case !jwtOK:
+			res.Hint = "The configured token is missing or expired. Issue a new token."
+		default:
+			res.Hint = "The token was rejected. Check that it is signed by the key CRDP is configured to trust and has not been revoked."
+		}
+	case 403:
+		res.Status, res.Detail = Fail, "HTTP 403 Forbidden"+serverMessage(resp)
+		res.Hint = fmt.Sprintf("The token was accepted but the user may not %s with policy %q. Check the policy's user set for this JWT subject.", op, t.Policy)
+	default:
+		res.Status = Pass
+		if t.JWT {
+			res.Detail = "token accepted"
+		} else {
+			res.Detail = "no authentication required"
+		}
+	}
+	return res
+}
+
+// protectResult는 protect 응답을 판정하고 보호된 토큰을 반환합니다
+func protectResult(r *Report, t Target, resp *client.APIResponse) (string, bool) {
+	res := Result{Name: "Protect canary"}
+	token, _ := resp.Body["protected_data"].(string)
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300 && token != "":
+		res.Status = Pass
+		res.Detail = fmt.Sprintf("policy %q, %d-char token in %.1fms", t.Policy, len(token), float64(resp.Elapsed.Microseconds())/1000)
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		res.Status, res.Detail = Fail, fmt.Sprintf("HTTP %d without protected_data", resp.StatusCode)
+		res.Hint = "The server answered but not like a CRDP protect endpoint. Check api.host/api.port point at CRDP and not a proxy or another service."
+	case resp.StatusCode >= 500:
+		res.Status, res.Detail = Fail, fmt.Sprintf("HTTP %d", resp.StatusCode)+serverMessage(resp)
+		res.Hint = "The server failed internally. Check the CRDP logs and its connection to the key manager."
+	default:
+		res.Status, res.Detail = Fail, fmt.Sprintf("HTTP %d", resp.StatusCode)+serverMessage(resp)
+		res.Hint = fmt.Sprintf("Policy %q may not exist, or the canary does not fit its data format. Check protection.policy (--policy) and pass a suitable value with --canary.", t.Policy)
+	}
+	r.add(res)
+	return token, res.Status == Pass
+}
+
+// revealResult는 reveal 응답을 판정하고 복원된 값을 반환합니다
+func revealResult(r *Report, t Target, resp *client.APIResponse) (string, bool) {
+	res := Result{Name: "Reveal canary"}
+	data, hasData := resp.Body["data"].(string)
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300 && hasData:
+		res.Status = Pass
+		res.Detail = fmt.Sprintf("%.1fms", float64(resp.Elapsed.Microseconds())/1000)
+	case resp.StatusCode == 401 || resp.StatusCode == 403:
+		res.Status, res.Detail = Fail, fmt.Sprintf("HTTP %d", resp.StatusCode)+serverMessage(resp)
+		res.Hint = fmt.Sprintf("The user can protect but not reveal with policy %q. Check the policy's reveal permissions for this JWT subject.", t.Policy)
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		res.Status, res.Detail = Fail, fmt.Sprintf("HTTP %d without data", resp.StatusCode)
+		res.Hint = "The reveal response has no data field. Check that the server is a compatible CRDP version."
+	default:
+		res.Status, res.Detail = Fail, fmt.Sprintf("HTTP %d", resp.StatusCode)+serverMessage(resp)
+		res.Hint = "Reveal failed for a token the server just issued. Check the CRDP logs for this request."
+	}
+	r.add(res)
+	return data, res.Status == Pass
+}
+
+// transportHint는 요청 전송 오류에 대한 조치 방법을 반환합니다
+func transportHint(t Target, err error) string {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, client.ErrRequestSetup):
+		return "The request could not be built before sending. Check auth.jwt_token and api.headers."
+	case strings.Contains(msg, "server gave HTTP response to HTTPS client"):
+		return "The server speaks plain HTTP. Set api.tls: false (--tls false)."
+	case !t.TLS && (strings.Contains(msg, "malformed HTTP response") || errors.Is(err, io.EOF) || strings.Contains(msg, "EOF")):
+		return "The server closed the plain HTTP connection; it may expect HTTPS. Set api.tls: true (--tls true)."
+	case strings.Contains(msg, "Client.Timeout") || strings.Contains(msg, "deadline exceeded"):
+		return "The server accepted the connection but did not answer in time. Raise api.timeout or check server load."
+	}
+	return "The request could not be completed. Check that api.host/api.port point at the CRDP REST port."
+}
+
+// serverMessage는 오류 응답 본문의 메시지를 " (message)" 형태로 반환합니다 (없으면 빈 문자열)
+func serverMessage(resp *client.APIResponse) string {
+	for _, key := range []string{"message", "error", "error_message", "raw"} {
+		if msg, ok := resp.Body[key].(string); ok && msg != "" {
+			if len(msg) > 200 {
+				msg = msg[:200] + "..."
+			}
+			return " (" + strings.TrimSpace(msg) + ")"
+		}
+	}
+	return ""
+}
+
+// Print는 점검 결과를 체크리스트 형식으로 출력합니다
+func (r *Report) Print(w io.Writer) {
+	scheme := "http"
+	if r.Target.TLS {
+		scheme = "https"
+	}
+	fmt.Fprintf(w, "Checking %s://%s (policy %s)\n", scheme, net.JoinHostPort(r.Target.Host, strconv.Itoa(r.Target.Port)), r.Target.Policy)
+	for _, res := range r.Results {
+		fmt.Fprintf(w, "  [%s] %-15s %s\n", res.Status, res.Name, res.Detail)
+		if res.Hint != "" {
+			fmt.Fprintf(w, "         hint: %s\n", res.Hint)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package check
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+// unsignedToken은 claims를 페이로드로 하는 서명 없는 JWT 형식 문자열을 만듭니다
+func unsignedToken(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString(payload) + ".sig"
+}
+
+func TestCheckJWT(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		target     Target
+		want       Status
+		detail     string // Detail에 포함되어야 할 문자열
+		hintPrefix string
+	}{
+		{"disabled", Target{JWT: false}, Skip, "auth.jwt: false", ""},
+		{"missing token", Target{JWT: true}, Fail, "no token is configured", "Set auth.jwt_token"},
+		{"malformed", Target{JWT: true, JWTToken: "not-a-jwt"}, Warn, "1 parts", "The token could not be decoded"},
+		{"expired", Target{JWT: true, JWTToken: unsignedToken(t, map[string]interface{}{"sub": "alice", "exp": now.Add(-time.Hour).Unix()})},
+			Fail, "token expired at", "Issue a new token"},
+		{"not yet valid", Target{JWT: true, JWTToken: unsignedToken(t, map[string]interface{}{"sub": "alice", "nbf": now.Add(time.Hour).Unix()})},
+			Fail, "token not valid before", "The token is not valid yet"},
+		{"valid", Target{JWT: true, JWTToken: unsignedToken(t, map[string]interface{}{"sub": "alice", "exp": now.Add(72 * time.Hour).Unix()})},
+			Pass, "sub=alice, expires in 3 days", ""},
+		{"no exp", Target{JWT: true, JWTToken: unsignedToken(t, map[string]interface{}{"sub": "bob"})},
+			Pass, "sub=bob, no exp claim", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := checkJWT(tt.target, now)
+			if res.Status != tt.want {
+				t.Errorf("status = %s, want %s (detail %q)", res.Status, tt.want, res.Detail)
+			}
+			if !strings.Contains(res.Detail, tt.detail) {
+				t.Errorf("detail = %q, want it to contain %q", res.Detail, tt.detail)
+			}
+			if !strings.HasPrefix(res.Hint, tt.hintPrefix) || (tt.hintPrefix == "" && res.Hint != "") {
+				t.Errorf("hint = %q, want prefix %q", res.Hint, tt.hintPrefix)
+			}
+		})
+	}
+}
+
+func TestDecodeClaims(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"two parts", "a.b", "token has 2 parts"},
+		{"bad base64", "a.!!!.c", "failed to decode token payload"},
+		{"bad json", "a." + base64.RawURLEncoding.EncodeToString([]byte("not json")) + ".c", "failed to parse token claims"},
+		{"padded payload", "a." + base64.URLEncoding.EncodeToString([]byte(`{"sub":"alice"}`)) + ".c", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := decodeClaims(tt.token)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || c.Subject != "alice" {
+				t.Errorf("decodeClaims = %+v, %v, want sub alice", c, err)
+			}
+		})
+	}
+}
+
+func TestCheckAuthHints(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     Target
+		status     int
+		jwtOK      bool
+		want       Status
+		hintPrefix string
+	}{
+		{"401 without jwt", Target{}, 401, true, Fail, "The server requires a JWT"},
+		{"401 with bad local token", Target{JWT: true}, 401, false, Fail, "The configured token is missing or expired"},
+		{"401 with valid local token", Target{JWT: true}, 401, true, Fail, "The token was rejected"},
+		{"403", Target{JWT: true, Policy: "P03"}, 403, true, Fail, "The token was accepted but the user may not protect with policy \"P03\""},
+		{"200 with jwt", Target{JWT: true}, 200, true, Pass, ""},
+		{"200 without jwt", Target{}, 200, true, Pass, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &client.APIResponse{StatusCode: tt.status, Body: map[string]interface{}{}}
+			res := checkAuth(tt.target, resp, tt.jwtOK, "protect")
+			if res.Status != tt.want {
+				t.Errorf("status = %s, want %s", res.Status, tt.want)
+			}
+			if !strings.HasPrefix(res.Hint, tt.hintPrefix) || (tt.hintPrefix == "" && res.Hint != "") {
+				t.Errorf("hint = %q, want prefix %q", res.Hint, tt.hintPrefix)
+			}
+		})
+	}
+}
+
+func TestProtectResultHints(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       map[string]interface{}
+		want       Status
+		hintPrefix string
+	}{
+		{"token", 200, map[string]interface{}{"protected_data": "5678010446818"}, Pass, ""},
+		{"no protected_data", 200, map[string]interface{}{}, Fail, "The server answered but not like a CRDP protect endpoint"},
+		{"server error", 500, map[string]interface{}{"message": "key manager unreachable"}, Fail, "The server failed internally"},
+		{"bad policy", 400, map[string]interface{}{"message": "unknown policy"}, Fail, "Policy \"P03\" may not exist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Report{}
+			resp := &client.APIResponse{StatusCode: tt.status, Body: tt.body}
+			token, ok := protectResult(r, Target{Policy: "P03"}, resp)
+			res := r.Results[0]
+			if res.Status != tt.want || ok != (tt.want == Pass) {
+				t.Errorf("status = %s, ok = %v, want %s", res.Status, ok, tt.want)
+			}
+			if ok && token != "5678010446818" {
+				t.Errorf("token = %q", token)
+			}
+			if !strings.HasPrefix(res.Hint, tt.hintPrefix) || (tt.hintPrefix == "" && res.Hint != "") {
+				t.Errorf("hint = %q, want prefix %q", res.Hint, tt.hintPrefix)
+			}
+			if msg, _ := tt.body["message"].(string); msg != "" && !strings.Contains(res.Detail, msg) {
+				t.Errorf("detail = %q, want server message %q", res.Detail, msg)
+			}
+		})
+	}
+}
+
+func TestRunRequireJWT(t *testing.T) {
+	const secret = "check-secret"
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{RequireJWT: true, JWTSecret: secret})
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse server URL: %v", err)
+	}
+	port, _ := strconv.Atoi(u.Port())
+	base := Target{Host: u.Hostname(), Port: port, Timeout: 5, Policy: "P03", Canary: "1234567890123"}
+
+	tests := []struct {
+		name   string
+		jwt    bool
+		token  string
+		failed string // 실패해야 할 항목 (비어 있으면 모두 통과)
+		hint   string
+	}{
+		{"valid token", true, fakecrdp.SignToken(secret, "alice", time.Hour), "", ""},
+		{"jwt disabled", false, "", "Authentication", "The server requires a JWT"},
+		{"wrong secret", true, fakecrdp.SignToken("other", "alice", time.Hour), "Authentication", "The token was rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := base
+			target.JWT, target.JWTToken = tt.jwt, tt.token
+			r := Run(target)
+			if r.ConnectivityFailed() {
+				t.Fatalf("ConnectivityFailed = true, results %+v", r.Results)
+			}
+			statuses := make(map[string]Status)
+			for _, res := range r.Results {
+				statuses[res.Name] = res.Status
+				if res.Name == tt.failed && !strings.HasPrefix(res.Hint, tt.hint) {
+					t.Errorf("%s hint = %q, want prefix %q", res.Name, res.Hint, tt.hint)
+				}
+			}
+			if tt.failed == "" {
+				if r.Failed() != 0 || statuses["Round-trip"] != Pass {
+					t.Errorf("results = %+v, want every check to pass", r.Results)
+				}
+				return
+			}
+			if r.Failed() != 1 || statuses[tt.failed] != Fail {
+				t.Errorf("results = %+v, want only %s to fail", r.Results, tt.failed)
+			}
+			if statuses["Round-trip"] != Skip {
+				t.Errorf("Round-trip = %s, want skip after %s failed", statuses["Round-trip"], tt.failed)
+			}
+		})
+	}
+}
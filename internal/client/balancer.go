@@ -0,0 +1,181 @@
+package client
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+)
+
+// BalanceStrategy는 여러 CRDP 호스트 중 요청을 보낼 호스트를 고르는 방식입니다
+type BalanceStrategy string
+
+const (
+	RoundRobin    BalanceStrategy = "round-robin"    // 차례로 선택
+	LeastInFlight BalanceStrategy = "least-inflight" // 진행 중인 요청이 가장 적은 호스트 선택
+	Random        BalanceStrategy = "random"         // 무작위 선택
+)
+
+// ParseBalanceStrategy는 문자열을 BalanceStrategy로 변환합니다
+func ParseBalanceStrategy(s string) (BalanceStrategy, error) {
+	switch b := BalanceStrategy(strings.ToLower(strings.TrimSpace(s))); b {
+	case RoundRobin, LeastInFlight, Random:
+		return b, nil
+	case "":
+		return RoundRobin, nil
+	}
+	return "", fmt.Errorf("invalid balance strategy %q (expected round-robin, least-inflight or random)", s)
+}
+
+// HealthConfig는 수동(passive) 상태 점검 설정입니다
+// 실제 요청 결과만으로 판단하며 별도의 점검 요청은 보내지 않습니다
+type HealthConfig struct {
+	EjectAfter    int           // 연속 실패(전송 오류, 429, 5xx)가 이 횟수에 이르면 제외 (0이면 제외하지 않음)
+	EjectDuration time.Duration // 제외 상태를 유지할 시간 (이후 다시 선택 대상)
+}
+
+// HostStats는 호스트별 요청 집계입니다
+type HostStats struct {
+	Addr      string
+	Requests  int       // 응답을 받았거나 전송 오류가 난 요청 수
+	Errors    int       // 실패(전송 오류, 429, 5xx)로 집계된 요청 수
+	Ejections int       // 상태 점검으로 제외된 횟수
+	Latencies []float64 // 응답을 받은 요청의 소요 시간 (초)
+}
+
+// Balancer는 여러 호스트에 요청을 분산하고 연속 실패한 호스트를 일정 시간 제외합니다
+type Balancer struct {
+	strategy BalanceStrategy
+	health   HealthConfig
+	logger   *slog.Logger
+
+	mu       sync.Mutex
+	backends []*backend
+	next     int
+	rnd      *rand.Rand
+}
+
+// backend는 하나의 호스트 상태입니다
+type backend struct {
+	HostStats
+	inFlight     int
+	consecutive  int       // 연속 실패 수
+	ejectedUntil time.Time // 0이 아니면 제외된 상태
+}
+
+// NewBalancer는 "host:port" 주소 목록으로 Balancer를 생성합니다
+func NewBalancer(addrs []string, strategy BalanceStrategy, health HealthConfig) *Balancer {
+	b := &Balancer{
+		strategy: strategy,
+		health:   health,
+		logger:   logging.Discard(),
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, addr := range addrs {
+		b.backends = append(b.backends, &backend{HostStats: HostStats{Addr: addr}})
+	}
+	return b
+}
+
+// SetLogger는 호스트 제외/복귀를 기록할 로거를 설정합니다
+func (b *Balancer) SetLogger(l *slog.Logger) {
+	b.logger = l
+}
+
+// Stats는 호스트별 집계를 설정 순서대로 반환합니다
+func (b *Balancer) Stats() []HostStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := make([]HostStats, len(b.backends))
+	for i, be := range b.backends {
+		stats[i] = be.HostStats
+		stats[i].Latencies = append([]float64(nil), be.Latencies...)
+	}
+	return stats
+}
+
+// pick은 요청을 보낼 호스트를 고르고 진행 중 요청 수를 늘립니다
+// 모든 호스트가 제외된 상태이면 제외 기간이 가장 먼저 끝나는 호스트를 고릅니다
+func (b *Balancer) pick() *backend {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	var candidates []*backend
+	for _, be := range b.backends {
+		if !be.ejectedUntil.IsZero() && !now.Before(be.ejectedUntil) {
+			be.ejectedUntil = time.Time{}
+			be.consecutive = 0
+			b.logger.Info("host returned to rotation", "host", be.Addr)
+		}
+		if be.ejectedUntil.IsZero() {
+			candidates = append(candidates, be)
+		}
+	}
+
+	var chosen *backend
+	switch {
+	case len(candidates) == 0:
+		for _, be := range b.backends {
+			if chosen == nil || be.ejectedUntil.Before(chosen.ejectedUntil) {
+				chosen = be
+			}
+		}
+	case b.strategy == LeastInFlight:
+		for _, be := range candidates {
+			if chosen == nil || be.inFlight < chosen.inFlight {
+				chosen = be
+			}
+		}
+	case b.strategy == Random:
+		chosen = candidates[b.rnd.Intn(len(candidates))]
+	default:
+		// 제외된 호스트는 건너뛰며 설정 순서대로 돌아감
+		for i := 0; i < len(b.backends); i++ {
+			be := b.backends[(b.next+i)%len(b.backends)]
+			if be.ejectedUntil.IsZero() {
+				chosen = be
+				b.next = (b.next + i + 1) % len(b.backends)
+				break
+			}
+		}
+	}
+	chosen.inFlight++
+	return chosen
+}
+
+// done은 pick으로 고른 호스트의 요청 결과를 반영합니다
+// elapsed가 0이면(응답 없음) 지연 시간은 집계하지 않습니다
+func (b *Balancer) done(be *backend, elapsed time.Duration, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	be.inFlight--
+	be.Requests++
+	if elapsed > 0 {
+		be.Latencies = append(be.Latencies, elapsed.Seconds())
+	}
+	if !failed {
+		be.consecutive = 0
+		return
+	}
+	be.Errors++
+	be.consecutive++
+	if b.health.EjectAfter > 0 && be.consecutive >= b.health.EjectAfter && be.ejectedUntil.IsZero() {
+		be.ejectedUntil = time.Now().Add(b.health.EjectDuration)
+		be.Ejections++
+		b.logger.Warn("host ejected", "host", be.Addr, "consecutive_failures", be.consecutive,
+			"eject_for", b.health.EjectDuration)
+	}
+}
+
+// abandon은 pick으로 고른 호스트에 보내지 않았거나 호출자가 취소한 요청을 집계 없이 정리합니다
+func (b *Balancer) abandon(be *backend) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	be.inFlight--
+}
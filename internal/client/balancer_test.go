@@ -0,0 +1,133 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseBalanceStrategy(t *testing.T) {
+	tests := []struct {
+		in   string
+		want BalanceStrategy
+	}{
+		{"", RoundRobin},
+		{"round-robin", RoundRobin},
+		{" Least-Inflight ", LeastInFlight},
+		{"random", Random},
+	}
+	for _, tt := range tests {
+		got, err := ParseBalanceStrategy(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseBalanceStrategy(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseBalanceStrategy("weighted"); err == nil {
+		t.Error("ParseBalanceStrategy(\"weighted\") succeeded, want error")
+	}
+}
+
+// picks는 n번 호스트를 고르고 바로 성공으로 반영한 주소 목록을 반환합니다
+func picks(b *Balancer, n int) []string {
+	var addrs []string
+	for i := 0; i < n; i++ {
+		be := b.pick()
+		addrs = append(addrs, be.Addr)
+		b.done(be, time.Millisecond, false)
+	}
+	return addrs
+}
+
+func TestBalancerRoundRobin(t *testing.T) {
+	b := NewBalancer([]string{"a:1", "b:1", "c:1"}, RoundRobin, HealthConfig{})
+	want := []string{"a:1", "b:1", "c:1", "a:1", "b:1"}
+	if got := picks(b, 5); !reflect.DeepEqual(got, want) {
+		t.Errorf("picks = %v, want %v", got, want)
+	}
+}
+
+func TestBalancerLeastInFlight(t *testing.T) {
+	b := NewBalancer([]string{"a:1", "b:1", "c:1"}, LeastInFlight, HealthConfig{})
+	first, second := b.pick(), b.pick()
+	if first.Addr != "a:1" || second.Addr != "b:1" {
+		t.Fatalf("picked %s, %s, want a:1, b:1", first.Addr, second.Addr)
+	}
+	b.done(first, time.Millisecond, false)
+	// a는 끝났고 b는 진행 중이므로 a 또는 c 중 앞의 a
+	if got := b.pick(); got.Addr != "a:1" {
+		t.Errorf("picked %s, want a:1", got.Addr)
+	}
+}
+
+func TestBalancerRandom(t *testing.T) {
+	b := NewBalancer([]string{"a:1", "b:1"}, Random, HealthConfig{})
+	seen := make(map[string]bool)
+	for _, addr := range picks(b, 100) {
+		seen[addr] = true
+	}
+	if len(seen) != 2 {
+		t.Errorf("random strategy picked only %v in 100 requests", seen)
+	}
+}
+
+func TestBalancerEjection(t *testing.T) {
+	b := NewBalancer([]string{"a:1", "b:1"}, RoundRobin, HealthConfig{EjectAfter: 2, EjectDuration: 20 * time.Millisecond})
+
+	// a가 두 번 연속 실패하면 제외
+	for i := 0; i < 2; i++ {
+		a := b.pick()
+		if a.Addr != "a:1" {
+			t.Fatalf("picked %s, want a:1", a.Addr)
+		}
+		b.done(a, 0, true)
+		b.done(b.pick(), time.Millisecond, false)
+	}
+	if got := picks(b, 3); !reflect.DeepEqual(got, []string{"b:1", "b:1", "b:1"}) {
+		t.Errorf("picks while a is ejected = %v, want only b:1", got)
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	seen := make(map[string]bool)
+	for _, addr := range picks(b, 2) {
+		seen[addr] = true
+	}
+	if !seen["a:1"] {
+		t.Error("a:1 did not return to rotation after the ejection period")
+	}
+
+	stats := b.Stats()
+	if stats[0].Errors != 2 || stats[0].Ejections != 1 || stats[1].Errors != 0 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if len(stats[0].Latencies) != 1 {
+		t.Errorf("a:1 latencies = %v, want only the successful request after return", stats[0].Latencies)
+	}
+}
+
+func TestBalancerAllEjected(t *testing.T) {
+	b := NewBalancer([]string{"a:1", "b:1"}, RoundRobin, HealthConfig{EjectAfter: 1, EjectDuration: time.Minute})
+	a := b.pick()
+	b.done(a, 0, true)
+	time.Sleep(time.Millisecond)
+	bb := b.pick()
+	b.done(bb, 0, true)
+
+	// 모두 제외되면 제외 기간이 먼저 끝나는 호스트(a)를 고름
+	if got := b.pick(); got.Addr != "a:1" {
+		t.Errorf("picked %s with all hosts ejected, want a:1", got.Addr)
+	}
+}
+
+func TestBalancerAbandon(t *testing.T) {
+	b := NewBalancer([]string{"a:1"}, RoundRobin, HealthConfig{EjectAfter: 1, EjectDuration: time.Minute})
+	for i := 0; i < 3; i++ {
+		b.abandon(b.pick())
+	}
+	stats := b.Stats()[0]
+	if stats.Requests != 0 || stats.Errors != 0 || stats.Ejections != 0 {
+		t.Errorf("stats after abandoned requests = %+v, want nothing counted", stats)
+	}
+	if be := b.pick(); be.inFlight != 1 {
+		t.Errorf("inFlight = %d, want 1", be.inFlight)
+	}
+}
@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+)
+
+// ErrCircuitOpen은 서킷 브레이커가 열려 있어 요청을 보내지 않았을 때 반환됩니다
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState는 서킷 브레이커 상태입니다
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // 정상: 모든 요청 허용
+	BreakerOpen                         // 차단: 요청을 보내지 않고 즉시 실패
+	BreakerHalfOpen                     // 시험: 제한된 수의 요청으로 복구 여부 확인
+)
+
+// String은 상태 이름을 반환합니다
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// BreakerConfig는 서킷 브레이커 설정입니다
+type BreakerConfig struct {
+	ErrorRatio     float64       // 최근 요청 중 실패 비율이 이 값 이상이면 열림 (0~1)
+	Window         int           // 실패 비율을 계산할 최근 요청 수 (이만큼 쌓이기 전에는 열리지 않음)
+	OpenDuration   time.Duration // 열린 상태를 유지할 시간 (이후 half-open)
+	HalfOpenProbes int           // half-open 상태에서 허용할 시험 요청 수 (모두 성공하면 닫힘)
+}
+
+// BreakerTransition은 한 번의 상태 전환 기록입니다
+type BreakerTransition struct {
+	At         time.Time
+	From, To   BreakerState
+	ErrorRatio float64 // 전환 시점의 최근 실패 비율
+}
+
+// Breaker는 실패 비율 기반 서킷 브레이커입니다
+// 여러 Client가 같은 Breaker를 공유하면 대상 서버 하나에 대한 상태를 함께 판단합니다
+type Breaker struct {
+	cfg    BreakerConfig
+	logger *slog.Logger
+
+	mu          sync.Mutex
+	state       BreakerState
+	generation  uint64 // 상태가 바뀔 때마다 증가 (이전 상태에서 허용된 요청 결과는 무시)
+	results     []bool // 최근 요청 결과 링 버퍼 (true = 실패)
+	next        int
+	filled      int
+	failures    int
+	openedAt    time.Time
+	probes      int // half-open 상태에서 허용한 시험 요청 수
+	probeOK     int // half-open 상태에서 성공한 시험 요청 수
+	rejected    int // 열린 상태에서 거부한 요청 수
+	transitions []BreakerTransition
+}
+
+// NewBreaker는 새로운 Breaker를 생성합니다
+func NewBreaker(cfg BreakerConfig) *Breaker {
+	if cfg.Window < 1 {
+		cfg.Window = 1
+	}
+	if cfg.HalfOpenProbes < 1 {
+		cfg.HalfOpenProbes = 1
+	}
+	return &Breaker{
+		cfg:     cfg,
+		logger:  logging.Discard(),
+		results: make([]bool, cfg.Window),
+	}
+}
+
+// SetLogger는 상태 전환을 기록할 로거를 설정합니다
+func (b *Breaker) SetLogger(l *slog.Logger) {
+	b.logger = l
+}
+
+// State는 현재 상태를 반환합니다
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Rejected는 열린 상태에서 거부한 요청 수를 반환합니다
+func (b *Breaker) Rejected() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rejected
+}
+
+// Transitions는 지금까지의 상태 전환 기록을 반환합니다
+func (b *Breaker) Transitions() []BreakerTransition {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]BreakerTransition(nil), b.transitions...)
+}
+
+// allow는 요청을 보내도 되는지 확인하고, 결과 기록에 사용할 세대 번호를 반환합니다
+func (b *Breaker) allow() (uint64, error) {
+	if b == nil {
+		return 0, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen {
+		if time.Since(b.openedAt) < b.cfg.OpenDuration {
+			b.rejected++
+			return 0, ErrCircuitOpen
+		}
+		b.transition(BreakerHalfOpen)
+	}
+	if b.state == BreakerHalfOpen {
+		if b.probes >= b.cfg.HalfOpenProbes {
+			b.rejected++
+			return 0, ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return b.generation, nil
+}
+
+// record는 allow로 허용된 요청의 결과를 반영합니다
+func (b *Breaker) record(generation uint64, failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+	switch b.state {
+	case BreakerHalfOpen:
+		if failed {
+			b.transition(BreakerOpen)
+			return
+		}
+		b.probeOK++
+		if b.probeOK >= b.cfg.HalfOpenProbes {
+			b.transition(BreakerClosed)
+		}
+	case BreakerClosed:
+		if b.results[b.next] {
+			b.failures--
+		}
+		b.results[b.next] = failed
+		if failed {
+			b.failures++
+		}
+		b.next = (b.next + 1) % len(b.results)
+		if b.filled < len(b.results) {
+			b.filled++
+		}
+		if b.filled == len(b.results) && b.ratio() >= b.cfg.ErrorRatio {
+			b.transition(BreakerOpen)
+		}
+	}
+}
+
+// forget은 allow로 허용했지만 결과를 반영하지 않을 요청을 정리합니다
+// half-open 상태이면 시험 요청 슬롯을 돌려주어 다른 요청이 복구 여부를 확인할 수 있게 합니다
+func (b *Breaker) forget(generation uint64) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation == b.generation && b.state == BreakerHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+// ratio는 최근 요청의 실패 비율을 반환합니다
+func (b *Breaker) ratio() float64 {
+	if b.filled == 0 {
+		return 0
+	}
+	return float64(b.failures) / float64(b.filled)
+}
+
+// transition은 상태를 바꾸고 기록을 남깁니다 (mu를 잡은 상태에서 호출)
+func (b *Breaker) transition(to BreakerState) {
+	t := BreakerTransition{At: time.Now(), From: b.state, To: to, ErrorRatio: b.ratio()}
+	b.transitions = append(b.transitions, t)
+	b.state = to
+	b.generation++
+	b.probes, b.probeOK = 0, 0
+
+	switch to {
+	case BreakerOpen:
+		b.openedAt = t.At
+		b.logger.Warn("circuit breaker opened", "from", t.From.String(), "error_ratio", t.ErrorRatio,
+			"open_for", b.cfg.OpenDuration)
+	case BreakerHalfOpen:
+		b.logger.Info("circuit breaker half-open", "probes", b.cfg.HalfOpenProbes)
+	case BreakerClosed:
+		// 닫히면 이전 실패 기록을 비우고 새로 계산
+		for i := range b.results {
+			b.results[i] = false
+		}
+		b.next, b.filled, b.failures = 0, 0, 0
+		b.logger.Info("circuit breaker closed")
+	}
+}
+
+// breakerCounts는 요청 결과를 서킷 브레이커와 호스트 집계에 반영할지 확인합니다
+// 요청을 보내기 전에 실패한 오류(JWT 토큰 공급자 오류 등)와 호출자가 취소한 요청은 서버 상태와 무관하므로 제외합니다
+func breakerCounts(ctx context.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+	return !errors.Is(err, ErrRequestSetup) && ctx.Err() == nil
+}
+
+// breakerFailure는 서버 상태 이상으로 볼 수 있는 결과인지 확인합니다 (breakerCounts로 걸러진 결과만 전달)
+// 서버와 통신하다 실패한 전송 오류, 429, 5xx 응답을 실패로 보고 4xx 요청 오류는 성공으로 봅니다
+func breakerFailure(resp *APIResponse, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+func TestBreakerOpens(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []bool // true = 실패
+		want    BreakerState
+	}{
+		{"all ok", []bool{false, false, false, false}, BreakerClosed},
+		{"below ratio", []bool{false, false, false, true}, BreakerClosed},
+		{"at ratio", []bool{false, true, false, true}, BreakerOpen},
+		{"window not filled", []bool{true, true, true}, BreakerClosed},
+		{"old failures slide out", []bool{true, false, false, false, false, false}, BreakerClosed},
+		{"failures after window", []bool{false, false, false, false, true, true}, BreakerOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(BreakerConfig{ErrorRatio: 0.5, Window: 4, OpenDuration: time.Minute})
+			for _, failed := range tt.results {
+				gen, err := b.allow()
+				if err != nil {
+					break
+				}
+				b.record(gen, failed)
+			}
+			if got := b.State(); got != tt.want {
+				t.Errorf("state = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerRecovery(t *testing.T) {
+	tests := []struct {
+		name   string
+		probes []bool // half-open 시험 요청 결과 (true = 실패)
+		want   BreakerState
+	}{
+		{"probes succeed", []bool{false, false}, BreakerClosed},
+		{"probe fails", []bool{false, true}, BreakerOpen},
+		{"not enough probes yet", []bool{false}, BreakerHalfOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(BreakerConfig{ErrorRatio: 1, Window: 1, OpenDuration: 10 * time.Millisecond, HalfOpenProbes: 2})
+			gen, _ := b.allow()
+			b.record(gen, true)
+			if b.State() != BreakerOpen {
+				t.Fatalf("state = %s after failure, want open", b.State())
+			}
+			if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("allow() while open: err = %v, want ErrCircuitOpen", err)
+			}
+
+			time.Sleep(15 * time.Millisecond)
+			for _, failed := range tt.probes {
+				gen, err := b.allow()
+				if err != nil {
+					t.Fatalf("probe rejected: %v", err)
+				}
+				b.record(gen, failed)
+			}
+			if got := b.State(); got != tt.want {
+				t.Errorf("state = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerHalfOpenLimitsProbes(t *testing.T) {
+	b := NewBreaker(BreakerConfig{ErrorRatio: 1, Window: 1, OpenDuration: time.Millisecond, HalfOpenProbes: 1})
+	gen, _ := b.allow()
+	b.record(gen, true)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := b.allow(); err != nil {
+		t.Fatalf("first probe rejected: %v", err)
+	}
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("second probe while first is in flight: err = %v, want ErrCircuitOpen", err)
+	}
+	if got := b.Rejected(); got != 1 {
+		t.Errorf("Rejected() = %d, want 1", got)
+	}
+	var states []BreakerState
+	for _, tr := range b.Transitions() {
+		states = append(states, tr.To)
+	}
+	if len(states) != 2 || states[0] != BreakerOpen || states[1] != BreakerHalfOpen {
+		t.Errorf("transitions = %v, want [open half-open]", states)
+	}
+}
+
+func TestBreakerIgnoresStaleResults(t *testing.T) {
+	b := NewBreaker(BreakerConfig{ErrorRatio: 0.5, Window: 2, OpenDuration: time.Millisecond, HalfOpenProbes: 1})
+	stale, _ := b.allow()
+	g1, _ := b.allow()
+	b.record(g1, true)
+	g2, _ := b.allow()
+	b.record(g2, true)
+	if b.State() != BreakerOpen {
+		t.Fatalf("state = %s, want open", b.State())
+	}
+	time.Sleep(5 * time.Millisecond)
+	probe, err := b.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 열리기 전에 허용된 요청의 성공은 half-open 판정에 쓰이지 않음
+	b.record(stale, false)
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("stale result changed state to %s", b.State())
+	}
+	b.record(probe, false)
+	if b.State() != BreakerClosed {
+		t.Errorf("state = %s after successful probe, want closed", b.State())
+	}
+}
+
+func TestNilBreaker(t *testing.T) {
+	var b *Breaker
+	gen, err := b.allow()
+	if err != nil {
+		t.Fatalf("nil Breaker allow() error: %v", err)
+	}
+	b.record(gen, true)
+}
+
+func TestBreakerFailure(t *testing.T) {
+	tests := []struct {
+		status int
+		err    error
+		want   bool
+	}{
+		{http.StatusOK, nil, false},
+		{http.StatusBadRequest, nil, false},
+		{http.StatusUnauthorized, nil, false},
+		{http.StatusTooManyRequests, nil, true},
+		{http.StatusInternalServerError, nil, true},
+		{http.StatusServiceUnavailable, nil, true},
+		{0, errors.New("connection refused"), true},
+	}
+	for _, tt := range tests {
+		var resp *APIResponse
+		if tt.err == nil {
+			resp = &APIResponse{StatusCode: tt.status}
+		}
+		if got := breakerFailure(resp, tt.err); got != tt.want {
+			t.Errorf("breakerFailure(%d, %v) = %v, want %v", tt.status, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestBreakerCounts(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{"response", context.Background(), nil, true},
+		{"network error", context.Background(), errors.New("connection refused"), true},
+		{"token source error", context.Background(), &SetupError{Endpoint: "/v1/protect", Err: errors.New("vault unavailable")}, false},
+		{"caller canceled", canceled, context.Canceled, false},
+	}
+	for _, tt := range tests {
+		if got := breakerCounts(tt.ctx, tt.err); got != tt.want {
+			t.Errorf("%s: breakerCounts = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBreakerForgetReturnsProbe(t *testing.T) {
+	b := NewBreaker(BreakerConfig{ErrorRatio: 1, Window: 1, OpenDuration: 10 * time.Millisecond, HalfOpenProbes: 1})
+	gen, _ := b.allow()
+	b.record(gen, true)
+	time.Sleep(20 * time.Millisecond)
+
+	gen, err := b.allow()
+	if err != nil {
+		t.Fatalf("allow() after open duration: %v", err)
+	}
+	b.forget(gen)
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("state = %s after forgotten probe, want half-open", b.State())
+	}
+	gen, err = b.allow()
+	if err != nil {
+		t.Fatalf("allow() after forgotten probe: err = %v, want the probe slot back", err)
+	}
+	b.record(gen, false)
+	if b.State() != BreakerClosed {
+		t.Errorf("state = %s, want closed", b.State())
+	}
+}
+
+func TestBreakerIgnoresTokenSourceErrors(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{})
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	b := NewBreaker(BreakerConfig{ErrorRatio: 0.5, Window: 2, OpenDuration: time.Minute})
+	c.SetBreaker(b)
+	c.SetTokenSource(func(ctx context.Context) (string, error) {
+		return "", errors.New("vault unavailable")
+	})
+
+	for i := 0; i < 4; i++ {
+		if _, err := c.ProtectContext(context.Background(), "1234567890123"); err == nil {
+			t.Fatal("ProtectContext succeeded, want token source error")
+		}
+	}
+	if b.State() != BreakerClosed {
+		t.Errorf("state = %s after token source errors, want closed", b.State())
+	}
+}
@@ -2,30 +2,86 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+	"github.com/sjrhee/crdp-cli-go/internal/redact"
+	"github.com/sjrhee/crdp-cli-go/internal/tracing"
 )
 
 // APIResponse는 API 응답을 나타냅니다
 type APIResponse struct {
+	Endpoint   string // 예: /v1/protect
 	StatusCode int
 	Body       map[string]interface{}
+	Elapsed    time.Duration // 요청 전송부터 응답 본문 읽기 완료까지의 시간 (재시도 시 마지막 시도)
+	Timing     *PhaseTiming  // 연결 단계별 소요 시간 (SetPhaseTiming 활성화 시에만 설정)
+	Throttled  time.Duration // 속도/동시 요청 제한으로 대기한 시간 (재시도 포함 합계)
+}
+
+// Observer는 HTTP 요청 단위 이벤트를 수신합니다 (메트릭 수집 등)
+type Observer interface {
+	// RequestStarted는 요청 전송 직전에 호출됩니다
+	RequestStarted(endpoint string)
+	// RequestFinished는 응답 수신(또는 전송 오류) 후 호출됩니다
+	RequestFinished(endpoint string, statusCode int, elapsed time.Duration, err error)
+	// RequestRetried는 재시도 직전에 호출됩니다 (attempt는 1부터 시작)
+	RequestRetried(endpoint string, attempt int)
+}
+
+// Exchange는 한 번의 HTTP 요청/응답 쌍입니다 (재시도는 각각 하나의 Exchange)
+type Exchange struct {
+	Start      time.Time
+	Endpoint   string
+	URL        string
+	RequestID  string
+	Request    []byte // 요청 JSON 본문 (원본)
+	StatusCode int    // 전송 오류이면 0
+	Response   []byte // 응답 본문 (원본)
+	Elapsed    time.Duration
+	Err        error
+}
+
+// Recorder는 요청/응답 쌍을 기록합니다 (record/replay용)
+// 여러 워커가 동시에 호출하므로 구현은 동시 호출에 안전해야 합니다
+type Recorder interface {
+	Record(ex Exchange)
 }
 
 // Client는 CRDP API 클라이언트입니다
 type Client struct {
-	baseURL    string
-	policy     string
-	timeout    time.Duration
-	client     *http.Client
-	showBody   bool
-	jwtEnabled bool
-	jwtToken   string
+	baseURL      string
+	policy       string
+	timeout      time.Duration
+	client       *http.Client
+	transport    *http.Transport
+	middlewares  []Middleware
+	showBody     bool
+	jwtEnabled   bool
+	jwtToken     string
+	tokenSource  func(ctx context.Context) (string, error)
+	maxRetries   int
+	retryBackoff time.Duration
+	observer     Observer
+	phaseTiming  bool
+	redactor     *redact.Redactor
+	logger       *slog.Logger
+	limiter      *Limiter
+	breaker      *Breaker
+	scheme       string
+	balancer     *Balancer
+	recorder     Recorder
+	defaults     RequestOptions
 }
 
 // NewClient는 새로운 CRDP 클라이언트를 생성합니다
@@ -65,12 +121,16 @@ func NewClient(host string, port int, policy string, timeoutSec int, useTLS bool
 
 	return &Client{
 		baseURL:    baseURL,
+		scheme:     protocol,
 		policy:     policy,
 		timeout:    time.Duration(timeoutSec) * time.Second,
 		client:     httpClient,
+		transport:  transport,
 		showBody:   false,
 		jwtEnabled: false,
 		jwtToken:   "",
+		redactor:   redact.New(redact.Mask),
+		logger:     logging.Discard(),
 	}
 }
 
@@ -79,52 +139,365 @@ func (c *Client) SetShowBody(show bool) {
 	c.showBody = show
 }
 
+// SetLogger는 요청 로그를 기록할 로거를 설정합니다
+// 요청별 로그에는 request_id가 포함되며 같은 값이 X-Request-ID 헤더로 전송됩니다
+// ctx에 logging.NewContext로 담긴 로거가 있으면 그 로거를 우선 사용합니다
+func (c *Client) SetLogger(l *slog.Logger) {
+	c.logger = l
+}
+
+// SetRedactor는 show-body 출력에서 data/protected_data/Authorization 값을 가리는 방식을 설정합니다
+// 기본값은 redact.Mask입니다
+func (c *Client) SetRedactor(r *redact.Redactor) {
+	c.redactor = r
+}
+
 // SetJWT는 JWT 토큰과 활성화 여부를 설정합니다
 func (c *Client) SetJWT(enabled bool, token string) {
 	c.jwtEnabled = enabled
 	c.jwtToken = token
 }
 
+// SetTokenSource는 요청마다 JWT 토큰을 가져올 함수를 설정합니다 (SetJWT의 고정 토큰보다 우선)
+// 토큰을 가져오지 못하면 요청을 보내지 않고 오류를 반환합니다
+func (c *Client) SetTokenSource(src func(ctx context.Context) (string, error)) {
+	c.tokenSource = src
+}
+
+// SetTimeout은 요청별 타임아웃을 설정합니다 (NewClient의 timeoutSec보다 세밀한 값)
+func (c *Client) SetTimeout(d time.Duration) {
+	c.timeout = d
+	c.client.Timeout = d
+}
+
+// SetTLSConfig는 HTTPS 연결에 사용할 TLS 설정을 지정합니다
+// NewClient는 인증서를 검증하지 않으므로 검증이 필요하면 InsecureSkipVerify가 false인 설정을 넘깁니다
+func (c *Client) SetTLSConfig(cfg *tls.Config) {
+	c.transport.TLSClientConfig = cfg
+}
+
+// SetRetry는 전송 오류 및 일시적 오류 응답(429, 502, 503, 504)에 대한 재시도 정책을 설정합니다
+// backoff는 첫 재시도 대기 시간이며 재시도마다 두 배로 늘어납니다 (최대 MaxRetryBackoff)
+func (c *Client) SetRetry(maxRetries int, backoff time.Duration) {
+	c.maxRetries = maxRetries
+	c.retryBackoff = backoff
+}
+
+// SetObserver는 요청 이벤트를 수신할 Observer를 설정합니다
+func (c *Client) SetObserver(o Observer) {
+	c.observer = o
+}
+
+// SetPhaseTiming은 요청마다 httptrace로 DNS/TCP 연결/TLS/TTFB/본문 읽기 시간을 측정할지 설정합니다
+// 측정 결과는 APIResponse.Timing에 저장됩니다
+func (c *Client) SetPhaseTiming(enabled bool) {
+	c.phaseTiming = enabled
+}
+
+// SetLimiter는 요청 전송 전에 적용할 속도/동시 요청 제한을 설정합니다 (nil이면 제한 없음)
+// 재시도도 각각 하나의 요청으로 제한을 받습니다
+func (c *Client) SetLimiter(l *Limiter) {
+	c.limiter = l
+}
+
+// SetBreaker는 서킷 브레이커를 설정합니다 (nil이면 사용하지 않음)
+// 브레이커가 열려 있으면 요청을 보내지 않고 ErrCircuitOpen을 반환합니다
+func (c *Client) SetBreaker(b *Breaker) {
+	c.breaker = b
+}
+
+// SetBalancer는 여러 호스트에 요청을 분산할 Balancer를 설정합니다
+// 설정하면 NewClient의 host/port 대신 요청(재시도 포함)마다 Balancer가 고른 호스트로 보냅니다
+func (c *Client) SetBalancer(b *Balancer) {
+	c.balancer = b
+}
+
+// SetRecorder는 모든 요청/응답 쌍을 받을 Recorder를 설정합니다 (nil이면 기록하지 않음)
+func (c *Client) SetRecorder(r Recorder) {
+	c.recorder = r
+}
+
+// Policy는 클라이언트의 기본 보호 정책명을 반환합니다 (요청별 정책은 RequestOptions.Policy)
+func (c *Client) Policy() string {
+	return c.policy
+}
+
 // PostJSON은 JSON 페이로드로 POST 요청을 보냅니다
 func (c *Client) PostJSON(endpoint string, payload map[string]interface{}) (*APIResponse, error) {
+	return c.PostJSONContext(context.Background(), endpoint, payload)
+}
+
+// PostJSONContext는 ctx를 사용하여 JSON 페이로드로 POST 요청을 보냅니다
+// ctx에 트레이싱 스팬이 있으면 자식 스팬을 만들고 traceparent 헤더를 전파합니다
+// 응답을 받지 못하면 *TransportError를 반환하고, 2xx가 아닌 응답은 오류 없이 반환합니다 (resp.Err()로 *APIError 확인)
+func (c *Client) PostJSONContext(ctx context.Context, endpoint string, payload map[string]interface{}) (resp *APIResponse, err error) {
 	url := c.baseURL + endpoint
 
+	ctx, span := tracing.StartChild(ctx, "crdp."+strings.TrimPrefix(endpoint, "/v1/"), tracing.KindClient)
+	if span != nil {
+		span.SetAttribute("crdp.policy", c.payloadPolicy(payload))
+		span.SetAttribute("crdp.endpoint", endpoint)
+		span.SetAttribute("crdp.batch_size", payloadSize(payload))
+		defer func() {
+			span.SetAttribute("url.full", url) // Balancer 사용 시 마지막 시도의 호스트
+			if err != nil {
+				span.SetStatus(tracing.StatusError, err.Error())
+			} else {
+				span.SetAttribute("http.response.status_code", resp.StatusCode)
+				if resp.StatusCode >= 400 {
+					span.SetStatus(tracing.StatusError, http.StatusText(resp.StatusCode))
+				}
+			}
+			span.Finish()
+		}()
+	}
+
 	// JSON 인코딩
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	// show-body 옵션이 활성화된 경우 요청 정보 출력
+	// 재시도 정책에 따라 요청 전송
+	requestID := logging.NewRequestID()
+	log := logging.FromContext(ctx, c.logger).With("request_id", requestID, "endpoint", endpoint)
+	var respBody []byte
+	var throttled time.Duration
+	metadata := metadataFrom(ctx)
+	for attempt := 0; ; attempt++ {
+		generation, berr := c.breaker.allow()
+		if berr != nil {
+			log.Debug("request rejected", "attempt", attempt+1, "error", berr)
+			if attempt == 0 {
+				return nil, berr
+			}
+			break // 재시도 중 열렸으면 마지막 시도 결과를 반환
+		}
+		release, waited, lerr := c.limiter.Acquire(ctx, endpoint, payloadSize(payload))
+		throttled += waited
+		if lerr != nil {
+			c.breaker.forget(generation)
+			return nil, &TransportError{Endpoint: endpoint, Err: fmt.Errorf("rate limiter: %w", lerr)}
+		}
+		if waited >= time.Millisecond {
+			log.Debug("request throttled", "attempt", attempt+1, "wait_ms", milliseconds(waited))
+		}
+
+		// 여러 호스트가 설정되었으면 시도마다 호스트 선택 (재시도는 다른 호스트로 갈 수 있음)
+		alog := log
+		var host *backend
+		if c.balancer != nil {
+			host = c.balancer.pick()
+			url = c.scheme + "://" + host.Addr + endpoint
+			alog = log.With("host", host.Addr)
+		}
+
+		info := &RequestInfo{Endpoint: endpoint, RequestID: requestID, Attempt: attempt + 1,
+			Policy: c.payloadPolicy(payload), Items: payloadSize(payload), Metadata: metadata}
+		resp, respBody, err = c.send(ctx, info, url, body)
+		release()
+		// 요청을 보내기 전 실패나 호출자 취소는 서버 상태와 무관하므로 브레이커와 호스트 집계에서 제외
+		if breakerCounts(ctx, err) {
+			failed := breakerFailure(resp, err)
+			c.breaker.record(generation, failed)
+			if host != nil {
+				var elapsed time.Duration
+				if resp != nil {
+					elapsed = resp.Elapsed
+				}
+				c.balancer.done(host, elapsed, failed)
+			}
+		} else {
+			c.breaker.forget(generation)
+			if host != nil {
+				c.balancer.abandon(host)
+			}
+		}
+		switch {
+		case err != nil:
+			alog.Warn("request failed", "attempt", attempt+1, "error", err)
+		case resp.StatusCode >= 400:
+			alog.Warn("request returned error status", "attempt", attempt+1, "status", resp.StatusCode,
+				"elapsed_ms", milliseconds(resp.Elapsed))
+		default:
+			alog.Debug("request completed", "attempt", attempt+1, "status", resp.StatusCode,
+				"elapsed_ms", milliseconds(resp.Elapsed))
+		}
+		if attempt >= c.maxRetries || !retryable(ctx, resp, err) {
+			break
+		}
+		if c.observer != nil {
+			c.observer.RequestRetried(endpoint, attempt+1)
+		}
+		backoff := retryDelay(c.retryBackoff, attempt)
+		log.Info("retrying request", "attempt", attempt+2, "backoff", backoff)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &TransportError{Endpoint: endpoint, Err: ctx.Err()}
+		}
+	}
+	if err != nil {
+		if errors.Is(err, ErrRequestSetup) {
+			return nil, err
+		}
+		return nil, &TransportError{Endpoint: endpoint, Err: err}
+	}
+	resp.Throttled = throttled
+
+	// show-body 옵션이 활성화된 경우 응답 정보 출력
 	if c.showBody {
-		method := "POST"
-		fmt.Printf("%s %s\n%s\n", method, url, string(body))
+		fmt.Printf("%s\n", c.redactor.JSON(respBody))
+	}
+
+	return resp, nil
+}
+
+// MaxRetryBackoff는 재시도 대기 시간의 상한입니다
+const MaxRetryBackoff = 30 * time.Second
+
+// retryDelay는 attempt번째 재시도(0부터) 전 대기 시간을 계산합니다
+// base를 재시도마다 두 배로 늘리되 MaxRetryBackoff를 넘지 않아 시프트 오버플로가 없습니다
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	if base >= MaxRetryBackoff {
+		return MaxRetryBackoff
+	}
+	delay := base
+	for i := 0; i < attempt; i++ {
+		if delay >= MaxRetryBackoff/2 {
+			return MaxRetryBackoff
+		}
+		delay *= 2
+	}
+	return delay
+}
+
+// retryable은 재시도할 수 있는 오류 또는 응답인지 확인합니다
+// 실행이 취소되었거나 요청을 보내기 전에 실패한 오류(JWT 토큰 공급자 오류 등)는 재시도하지 않습니다
+func retryable(ctx context.Context, resp *APIResponse, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, ErrRequestSetup) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// milliseconds는 로그용 밀리초 값을 반환합니다
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// payloadPolicy는 요청 본문의 보호 정책 이름을 반환합니다 (없으면 클라이언트 정책)
+func (c *Client) payloadPolicy(payload map[string]interface{}) string {
+	if name, ok := payload["protection_policy_name"].(string); ok && name != "" {
+		return name
+	}
+	return c.policy
+}
+
+// payloadSize는 요청 페이로드에 포함된 데이터 개수를 반환합니다 (단일 요청은 1)
+func payloadSize(payload map[string]interface{}) int {
+	switch v := payload["data_array"].(type) {
+	case []string:
+		return len(v)
+	}
+	switch v := payload["protected_data_array"].(type) {
+	case []map[string]interface{}:
+		return len(v)
+	}
+	return 1
+}
+
+// send는 한 번의 POST 요청을 보내고 파싱된 응답과 원본 응답 본문을 반환합니다
+// info는 ctx에 담겨 미들웨어에 전달됩니다
+func (c *Client) send(ctx context.Context, info *RequestInfo, url string, body []byte) (apiResp *APIResponse, respBody []byte, err error) {
+	endpoint, requestID := info.Endpoint, info.RequestID
+	ctx = context.WithValue(ctx, requestInfoKey{}, info)
+	if c.recorder != nil {
+		sent := time.Now()
+		defer func() {
+			ex := Exchange{Start: sent, Endpoint: endpoint, URL: url, RequestID: requestID, Request: body,
+				Response: respBody, Elapsed: time.Since(sent), Err: err}
+			if apiResp != nil {
+				ex.StatusCode = apiResp.StatusCode
+			}
+			c.recorder.Record(ex)
+		}()
+	}
+
+	var phases *phaseRecorder
+	if c.phaseTiming {
+		phases = &phaseRecorder{}
+		ctx = phases.withTrace(ctx)
 	}
 
 	// POST 요청 생성
-	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
-		return nil, err
+		return nil, nil, &SetupError{Endpoint: endpoint, Err: err}
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", requestID)
+	tracing.Inject(ctx, req.Header)
 
 	// JWT 헤더 추가
-	if c.jwtEnabled && c.jwtToken != "" {
+	if c.tokenSource != nil {
+		token, err := c.tokenSource(ctx)
+		if err != nil {
+			return nil, nil, &SetupError{Endpoint: endpoint, Err: fmt.Errorf("failed to get JWT token: %w", err)}
+		}
+		if token != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+	} else if c.jwtEnabled && c.jwtToken != "" {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.jwtToken))
 	}
 
+	// show-body 옵션이 활성화된 경우 첫 시도의 요청 정보 출력 (민감한 값은 가림)
+	// Authorization은 고정 토큰이든 토큰 공급자든 실제로 설정된 헤더 값을 보여줍니다
+	if c.showBody && info.Attempt == 1 {
+		fmt.Printf("%s %s\n", req.Method, url)
+		if auth := req.Header.Get("Authorization"); auth != "" {
+			fmt.Printf("Authorization: %s\n", c.redactor.Authorization(auth))
+		}
+		fmt.Printf("%s\n", c.redactor.JSON(body))
+	}
+
+	if c.observer != nil {
+		c.observer.RequestStarted(endpoint)
+	}
+	start := time.Now()
+
 	// 요청 전송
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		if c.observer != nil {
+			c.observer.RequestFinished(endpoint, 0, time.Since(start), err)
+		}
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
 	// 응답 본문 읽기
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err = io.ReadAll(resp.Body)
+	bodyDone := time.Now()
+	if c.observer != nil {
+		c.observer.RequestFinished(endpoint, resp.StatusCode, time.Since(start), err)
+	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// JSON 파싱
@@ -136,57 +509,166 @@ func (c *Client) PostJSON(endpoint string, payload map[string]interface{}) (*API
 		}
 	}
 
-	// show-body 옵션이 활성화된 경우 응답 정보 출력
-	if c.showBody {
-		fmt.Printf("%s\n", string(respBody))
-	}
-
-	return &APIResponse{
+	apiResp = &APIResponse{
+		Endpoint:   endpoint,
 		StatusCode: resp.StatusCode,
 		Body:       data,
-	}, nil
+		Elapsed:    bodyDone.Sub(start),
+	}
+	if phases != nil {
+		apiResp.Timing = phases.timing(bodyDone)
+	}
+	return apiResp, respBody, nil
+}
+
+// RequestOptions는 protect/reveal 요청에 추가하는 선택 필드입니다 (빈 값은 보내지 않음)
+type RequestOptions struct {
+	// Policy는 이 요청에 사용할 보호 정책입니다 (비어 있으면 클라이언트 정책)
+	Policy string
+	// Username은 정책의 사용자별 접근 권한(평문/마스킹/거부)을 판단할 사용자 이름입니다
+	Username string
+	// ExternalVersion은 토큰 밖에 보관하는 키 버전입니다 (reveal에서 protect 응답 값을 다시 전달)
+	ExternalVersion string
+}
+
+// ProtectedItem은 bulk reveal의 항목 하나입니다
+type ProtectedItem struct {
+	ProtectedData   string
+	ExternalVersion string // 비어 있으면 RequestOptions.ExternalVersion 사용
+}
+
+// SetRequestDefaults는 요청별로 지정하지 않았을 때 사용할 username/external_version을 설정합니다
+func (c *Client) SetRequestDefaults(opts RequestOptions) {
+	c.defaults = opts
+}
+
+// withDefaults는 비어 있는 필드를 클라이언트 기본값으로 채웁니다
+func (c *Client) withDefaults(opts RequestOptions) RequestOptions {
+	if opts.Policy == "" {
+		opts.Policy = c.policy
+	}
+	if opts.Username == "" {
+		opts.Username = c.defaults.Username
+	}
+	if opts.ExternalVersion == "" {
+		opts.ExternalVersion = c.defaults.ExternalVersion
+	}
+	return opts
 }
 
 // Protect는 데이터를 보호합니다
 func (c *Client) Protect(data string) (*APIResponse, error) {
+	return c.ProtectContext(context.Background(), data)
+}
+
+// ProtectContext는 ctx를 사용하여 데이터를 보호합니다
+func (c *Client) ProtectContext(ctx context.Context, data string) (*APIResponse, error) {
+	return c.ProtectWithOptions(ctx, data, RequestOptions{})
+}
+
+// ProtectWithOptions는 요청별 정책과 username을 사용하여 데이터를 보호합니다
+// 외부 버전을 쓰는 정책이면 응답 본문의 external_version을 reveal에 다시 전달해야 합니다
+func (c *Client) ProtectWithOptions(ctx context.Context, data string, opts RequestOptions) (*APIResponse, error) {
+	opts = c.withDefaults(opts)
 	payload := map[string]interface{}{
 		"data":                      data,
-		"protection_policy_name": c.policy,
+		"protection_policy_name": opts.Policy,
+	}
+	if opts.Username != "" {
+		payload["username"] = opts.Username
 	}
-	return c.PostJSON("/v1/protect", payload)
+	return c.PostJSONContext(ctx, "/v1/protect", payload)
 }
 
 // Reveal은 보호된 데이터를 복원합니다
 func (c *Client) Reveal(protectedData string) (*APIResponse, error) {
+	return c.RevealContext(context.Background(), protectedData)
+}
+
+// RevealContext는 ctx를 사용하여 보호된 데이터를 복원합니다
+func (c *Client) RevealContext(ctx context.Context, protectedData string) (*APIResponse, error) {
+	return c.RevealWithOptions(ctx, protectedData, RequestOptions{})
+}
+
+// RevealWithOptions는 요청별 정책과 username/external_version을 사용하여 보호된 데이터를 복원합니다
+func (c *Client) RevealWithOptions(ctx context.Context, protectedData string, opts RequestOptions) (*APIResponse, error) {
+	opts = c.withDefaults(opts)
 	payload := map[string]interface{}{
 		"protected_data":              protectedData,
-		"protection_policy_name": c.policy,
+		"protection_policy_name": opts.Policy,
 	}
-	return c.PostJSON("/v1/reveal", payload)
+	if opts.Username != "" {
+		payload["username"] = opts.Username
+	}
+	if opts.ExternalVersion != "" {
+		payload["external_version"] = opts.ExternalVersion
+	}
+	return c.PostJSONContext(ctx, "/v1/reveal", payload)
 }
 
 // ProtectBulk는 여러 데이터를 한 번에 보호합니다 (Thales API 형식)
 func (c *Client) ProtectBulk(dataList []string) (*APIResponse, error) {
+	return c.ProtectBulkContext(context.Background(), dataList)
+}
+
+// ProtectBulkContext는 ctx를 사용하여 여러 데이터를 한 번에 보호합니다
+func (c *Client) ProtectBulkContext(ctx context.Context, dataList []string) (*APIResponse, error) {
+	return c.ProtectBulkWithOptions(ctx, dataList, RequestOptions{})
+}
+
+// ProtectBulkWithOptions는 요청별 정책과 username을 사용하여 여러 데이터를 한 번에 보호합니다
+// 응답의 protected_data_array 항목마다 external_version이 있을 수 있습니다
+func (c *Client) ProtectBulkWithOptions(ctx context.Context, dataList []string, opts RequestOptions) (*APIResponse, error) {
+	opts = c.withDefaults(opts)
 	payload := map[string]interface{}{
-		"protection_policy_name": c.policy,
+		"protection_policy_name": opts.Policy,
 		"data_array":             dataList,
 	}
-	return c.PostJSON("/v1/protectbulk", payload)
+	if opts.Username != "" {
+		payload["username"] = opts.Username
+	}
+	return c.PostJSONContext(ctx, "/v1/protectbulk", payload)
 }
 
 // RevealBulk은 여러 보호된 데이터를 한 번에 복원합니다 (Thales API 형식)
 func (c *Client) RevealBulk(protectedDataList []string) (*APIResponse, error) {
-	// protected_data_array 형태로 구성
-	pdArray := make([]map[string]interface{}, len(protectedDataList))
+	return c.RevealBulkContext(context.Background(), protectedDataList)
+}
+
+// RevealBulkContext는 ctx를 사용하여 여러 보호된 데이터를 한 번에 복원합니다
+func (c *Client) RevealBulkContext(ctx context.Context, protectedDataList []string) (*APIResponse, error) {
+	items := make([]ProtectedItem, len(protectedDataList))
 	for i, pd := range protectedDataList {
+		items[i] = ProtectedItem{ProtectedData: pd}
+	}
+	return c.RevealBulkWithOptions(ctx, items, RequestOptions{})
+}
+
+// RevealBulkWithOptions는 요청별 정책, 항목별 external_version과 username을 사용하여 여러 보호된 데이터를 한 번에 복원합니다
+// username은 요청 전체에 하나만 보낼 수 있습니다
+func (c *Client) RevealBulkWithOptions(ctx context.Context, items []ProtectedItem, opts RequestOptions) (*APIResponse, error) {
+	opts = c.withDefaults(opts)
+	// protected_data_array 형태로 구성
+	pdArray := make([]map[string]interface{}, len(items))
+	for i, item := range items {
 		pdArray[i] = map[string]interface{}{
-			"protected_data": pd,
+			"protected_data": item.ProtectedData,
+		}
+		version := item.ExternalVersion
+		if version == "" {
+			version = opts.ExternalVersion
+		}
+		if version != "" {
+			pdArray[i]["external_version"] = version
 		}
 	}
 
 	payload := map[string]interface{}{
-		"protection_policy_name": c.policy,
+		"protection_policy_name": opts.Policy,
 		"protected_data_array":   pdArray,
 	}
-	return c.PostJSON("/v1/revealbulk", payload)
+	if opts.Username != "" {
+		payload["username"] = opts.Username
+	}
+	return c.PostJSONContext(ctx, "/v1/revealbulk", payload)
 }
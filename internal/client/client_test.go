@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+// newTestClient는 가짜 CRDP 서버에 연결한 클라이언트를 생성합니다
+func newTestClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse server URL: %v", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("parse server port: %v", err)
+	}
+	c := NewClient(u.Hostname(), port, "P03", 5, false)
+	c.SetShowBody(false)
+	return c
+}
+
+func TestProtectRevealRoundTrip(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{Seed: 1})
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	resp, err := c.Protect("1234567890123")
+	if err != nil {
+		t.Fatalf("Protect: %v", err)
+	}
+	if err := resp.Err(); err != nil {
+		t.Fatalf("Protect status: %v", err)
+	}
+	token, _ := resp.Body["protected_data"].(string)
+	if token == "" || token == "1234567890123" || len(token) != 13 {
+		t.Fatalf("protected_data = %q, want a 13-digit token different from the input", token)
+	}
+
+	resp, err = c.Reveal(token)
+	if err != nil {
+		t.Fatalf("Reveal: %v", err)
+	}
+	if got, _ := resp.Body["data"].(string); got != "1234567890123" {
+		t.Errorf("revealed data = %q, want %q", got, "1234567890123")
+	}
+}
+
+func TestBulkRoundTrip(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{Seed: 1})
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	data := []string{"1111111111111", "2222222222222", "3333333333333"}
+	resp, err := c.ProtectBulk(data)
+	if err != nil {
+		t.Fatalf("ProtectBulk: %v", err)
+	}
+	items, _ := resp.Body["protected_data_array"].([]interface{})
+	if len(items) != len(data) {
+		t.Fatalf("protected_data_array has %d items, want %d", len(items), len(data))
+	}
+	tokens := make([]string, len(items))
+	for i, item := range items {
+		m, _ := item.(map[string]interface{})
+		tokens[i], _ = m["protected_data"].(string)
+	}
+
+	resp, err = c.RevealBulk(tokens)
+	if err != nil {
+		t.Fatalf("RevealBulk: %v", err)
+	}
+	revealed, _ := resp.Body["data_array"].([]interface{})
+	if len(revealed) != len(data) {
+		t.Fatalf("data_array has %d items, want %d", len(revealed), len(data))
+	}
+	for i, item := range revealed {
+		m, _ := item.(map[string]interface{})
+		if got, _ := m["data"].(string); got != data[i] {
+			t.Errorf("item %d: revealed %q, want %q", i, got, data[i])
+		}
+	}
+}
+
+func TestJWTRejected(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{RequireJWT: true, JWTSecret: "secret"})
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		token   string
+		status  int
+	}{
+		{"missing", false, "", http.StatusUnauthorized},
+		{"wrong secret", true, fakecrdp.SignToken("other", "dev-user01", time.Hour), http.StatusUnauthorized},
+		{"malformed", true, "not-a-jwt", http.StatusUnauthorized},
+		{"valid", true, fakecrdp.SignToken("secret", "dev-user01", time.Hour), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, srv)
+			c.SetJWT(tt.enabled, tt.token)
+			resp, err := c.Protect("1234567890123")
+			if err != nil {
+				t.Fatalf("Protect: %v", err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.status == http.StatusUnauthorized {
+				if err := resp.Err(); !errors.Is(err, ErrUnauthorized) || Classify(err) != CategoryAuth {
+					t.Errorf("Err() = %v, want ErrUnauthorized classified as %q", err, CategoryAuth)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenSourceError(t *testing.T) {
+	handler := fakecrdp.New(fakecrdp.Config{})
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	c.SetRetry(3, time.Millisecond)
+	c.SetTokenSource(func(ctx context.Context) (string, error) {
+		return "", errors.New("token endpoint unavailable")
+	})
+
+	_, err := c.Protect("1234567890123")
+	if !errors.Is(err, ErrRequestSetup) || errors.Is(err, ErrTransport) || Classify(err) != CategoryRequestSetup {
+		t.Fatalf("Protect err = %v, want a request setup error that is not a transport error", err)
+	}
+	if n := handler.Requests()["/v1/protect"]; n != 0 {
+		t.Errorf("server received %d requests, want 0 (token source errors are not sent or retried)", n)
+	}
+}
+
+func TestInjectedServerError(t *testing.T) {
+	handler := fakecrdp.New(fakecrdp.Config{ErrorRate: 1})
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	c.SetRetry(2, time.Millisecond)
+
+	resp, err := c.Protect("1234567890123")
+	if err != nil {
+		t.Fatalf("Protect: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", resp.StatusCode)
+	}
+	if err := resp.Err(); !errors.Is(err, ErrServer) || Classify(err) != CategoryServer {
+		t.Errorf("Err() = %v, want ErrServer classified as %q", err, CategoryServer)
+	}
+	if n := handler.Requests()["/v1/protect"]; n != 3 {
+		t.Errorf("server received %d requests, want 3 (1 attempt + 2 retries)", n)
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{ErrorRate: 1})
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	c.SetRetry(5, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.ProtectContext(ctx, "1234567890123")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ProtectContext returned after %v, want the backoff to stop on cancellation", elapsed)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		base    time.Duration
+		attempt int
+		want    time.Duration
+	}{
+		{100 * time.Millisecond, 0, 100 * time.Millisecond},
+		{100 * time.Millisecond, 3, 800 * time.Millisecond},
+		{100 * time.Millisecond, 70, MaxRetryBackoff},
+		{time.Minute, 0, MaxRetryBackoff},
+		{0, 5, 0},
+		{-time.Second, 1, 0},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.base, tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(%v, %d) = %v, want %v", tt.base, tt.attempt, got, tt.want)
+		}
+	}
+}
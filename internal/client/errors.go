@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrorCategory는 실패한 요청의 원인 분류입니다 (요약/결과 파일의 집계 키)
+type ErrorCategory string
+
+const (
+	CategoryAuth            ErrorCategory = "auth"             // 401: JWT 누락/만료/서명 오류
+	CategoryForbidden       ErrorCategory = "forbidden"        // 403: 사용자에게 정책 권한 없음
+	CategoryPolicyNotFound  ErrorCategory = "policy_not_found" // 보호 정책이 없음
+	CategoryInvalidData     ErrorCategory = "invalid_data"     // 정책에 맞지 않는 데이터 형식/길이/문자
+	CategoryBadRequest      ErrorCategory = "bad_request"      // 그 외 400
+	CategoryNotFound        ErrorCategory = "not_found"        // 404 (정책 외)
+	CategoryRateLimited     ErrorCategory = "rate_limited"     // 429
+	CategoryServer          ErrorCategory = "server"           // 5xx
+	CategoryClient          ErrorCategory = "client_error"     // 그 외 4xx
+	CategoryTimeout         ErrorCategory = "timeout"          // 응답 전 타임아웃
+	CategoryTransport       ErrorCategory = "transport"        // 연결 실패, 연결 끊김, TLS 오류 등
+	CategoryRequestSetup    ErrorCategory = "request_setup"    // 요청을 보내기 전 실패 (JWT 토큰 공급자 오류 등)
+	CategoryCircuitOpen     ErrorCategory = "circuit_open"     // 서킷 브레이커가 요청을 거부
+	CategoryInvalidResponse ErrorCategory = "invalid_response" // 2xx이지만 기대한 필드가 없음
+)
+
+// errors.Is로 비교할 수 있는 오류 종류입니다
+var (
+	ErrBadRequest      = errors.New("crdp: bad request")      // 400 (ErrPolicyNotFound, ErrInvalidData 포함)
+	ErrUnauthorized    = errors.New("crdp: unauthorized")     // 401
+	ErrForbidden       = errors.New("crdp: forbidden")        // 403
+	ErrNotFound        = errors.New("crdp: not found")        // 404
+	ErrPolicyNotFound  = errors.New("crdp: policy not found") // 400/404 중 정책이 없다는 응답
+	ErrInvalidData     = errors.New("crdp: invalid data")     // 400 중 데이터 형식 오류 응답
+	ErrRateLimited     = errors.New("crdp: rate limited")     // 429
+	ErrServer          = errors.New("crdp: server error")     // 5xx
+	ErrTimeout         = errors.New("crdp: timeout")          // 응답 전 타임아웃
+	ErrTransport       = errors.New("crdp: transport error")  // 요청을 보냈지만 응답을 받지 못한 오류
+	ErrRequestSetup    = errors.New("crdp: request setup")    // 요청을 보내기 전 실패 (SetupError)
+	ErrInvalidResponse = errors.New("crdp: invalid response") // 2xx이지만 기대한 필드가 없음
+)
+
+// APIError는 서버가 2xx가 아닌 상태 코드로 응답한 오류입니다
+type APIError struct {
+	Endpoint   string
+	StatusCode int
+	Category   ErrorCategory
+	Code       string                 // 응답 본문의 code 값 (없으면 빈 문자열)
+	Message    string                 // 응답 본문의 message/error 값 (없으면 빈 문자열)
+	Body       map[string]interface{} // 파싱된 응답 본문
+}
+
+// NewAPIError는 오류 응답의 상태 코드와 본문으로 APIError를 생성합니다
+func NewAPIError(endpoint string, status int, body map[string]interface{}) *APIError {
+	e := &APIError{Endpoint: endpoint, StatusCode: status, Body: body}
+	for _, key := range []string{"message", "error", "error_message", "raw"} {
+		if msg, ok := body[key].(string); ok && strings.TrimSpace(msg) != "" {
+			e.Message = strings.TrimSpace(msg)
+			break
+		}
+	}
+	switch code := body["code"].(type) {
+	case string:
+		e.Code = code
+	case float64:
+		e.Code = strconv.FormatFloat(code, 'f', -1, 64)
+	}
+	e.Category = classifyStatus(status, e.Message)
+	return e
+}
+
+// Error는 error 구현입니다
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("crdp: %s returned status %d", e.Endpoint, e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is는 오류 분류에 해당하는 오류 종류와 비교합니다
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.Category == CategoryAuth
+	case ErrForbidden:
+		return e.Category == CategoryForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrPolicyNotFound:
+		return e.Category == CategoryPolicyNotFound
+	case ErrInvalidData:
+		return e.Category == CategoryInvalidData
+	case ErrRateLimited:
+		return e.Category == CategoryRateLimited
+	case ErrServer:
+		return e.Category == CategoryServer
+	}
+	return false
+}
+
+// classifyStatus는 상태 코드와 서버 메시지로 오류를 분류합니다
+// CRDP는 정책 없음과 데이터 형식 오류를 모두 400으로 응답하므로 메시지로 구분합니다
+func classifyStatus(status int, message string) ErrorCategory {
+	msg := strings.ToLower(message)
+	switch {
+	case status == http.StatusUnauthorized:
+		return CategoryAuth
+	case status == http.StatusForbidden:
+		return CategoryForbidden
+	case status == http.StatusTooManyRequests:
+		return CategoryRateLimited
+	case status >= 500:
+		return CategoryServer
+	case (status == http.StatusBadRequest || status == http.StatusNotFound) && policyMissing(msg):
+		return CategoryPolicyNotFound
+	case status == http.StatusBadRequest && invalidData(msg):
+		return CategoryInvalidData
+	case status == http.StatusBadRequest:
+		return CategoryBadRequest
+	case status == http.StatusNotFound:
+		return CategoryNotFound
+	}
+	return CategoryClient
+}
+
+// policyMissing은 정책이 없다는 오류 메시지인지 확인합니다
+func policyMissing(msg string) bool {
+	if !strings.Contains(msg, "policy") {
+		return false
+	}
+	for _, s := range []string{"not found", "does not exist", "not exist", "unknown", "no such"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidDataMessages는 CRDP가 정책에 맞지 않는 데이터에 대해 보내는 오류 메시지 구절입니다 (소문자)
+// "data"나 "token"처럼 다른 400 오류 메시지에도 흔한 단어만으로는 판단하지 않습니다
+var invalidDataMessages = []string{
+	"invalid data",
+	"invalid input",
+	"invalid protected data",
+	"data length",
+	"input length",
+	"data of length",
+	"data is too short",
+	"data is too long",
+	"character set",
+	"invalid character",
+	"unsupported character",
+	"data format",
+	"does not match the format",
+	"no characters to tokenize",
+	"not issued by this policy",
+}
+
+// invalidData는 데이터 형식 오류 메시지인지 확인합니다
+func invalidData(msg string) bool {
+	for _, s := range invalidDataMessages {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// TransportError는 응답을 받지 못한 오류입니다 (연결 실패, 타임아웃, TLS 오류 등)
+type TransportError struct {
+	Endpoint string
+	Err      error
+}
+
+// Error는 error 구현입니다
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("crdp: %s: %v", e.Endpoint, e.Err)
+}
+
+// Unwrap은 원인 오류를 반환합니다 (context.DeadlineExceeded, syscall.ECONNREFUSED 등과 비교 가능)
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Is는 ErrTransport와, 타임아웃이면 ErrTimeout과 같다고 판단합니다
+func (e *TransportError) Is(target error) bool {
+	switch target {
+	case ErrTransport:
+		return true
+	case ErrTimeout:
+		return isTimeout(e.Err)
+	}
+	return false
+}
+
+// SetupError는 요청을 보내기 전에 실패한 오류입니다 (요청 생성 또는 JWT 토큰 공급자 오류)
+// 서버와 통신하지 않았으므로 ErrTransport가 아니며 재시도하지 않습니다
+type SetupError struct {
+	Endpoint string
+	Err      error
+}
+
+// Error는 error 구현입니다
+func (e *SetupError) Error() string {
+	return fmt.Sprintf("crdp: %s: %v", e.Endpoint, e.Err)
+}
+
+// Unwrap은 원인 오류를 반환합니다
+func (e *SetupError) Unwrap() error {
+	return e.Err
+}
+
+// Is는 ErrRequestSetup과 같다고 판단합니다
+func (e *SetupError) Is(target error) bool {
+	return target == ErrRequestSetup
+}
+
+// isTimeout은 타임아웃 오류인지 확인합니다
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Err는 2xx가 아닌 응답을 *APIError로 반환합니다 (2xx이면 nil)
+// PostJSON은 상태 코드와 본문을 검사할 수 있도록 오류 응답도 오류 없이 반환합니다
+func (r *APIResponse) Err() error {
+	if r == nil || (r.StatusCode >= 200 && r.StatusCode < 300) {
+		return nil
+	}
+	return NewAPIError(r.Endpoint, r.StatusCode, r.Body)
+}
+
+// Classify는 오류를 분류합니다 (nil이면 빈 문자열)
+func Classify(err error) ErrorCategory {
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &apiErr):
+		return apiErr.Category
+	case errors.Is(err, ErrCircuitOpen):
+		return CategoryCircuitOpen
+	case errors.Is(err, ErrInvalidResponse):
+		return CategoryInvalidResponse
+	case errors.Is(err, ErrRequestSetup):
+		return CategoryRequestSetup
+	case isTimeout(err):
+		return CategoryTimeout
+	}
+	return CategoryTransport
+}
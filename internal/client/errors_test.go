@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     map[string]interface{}
+		message  string
+		code     string
+		category ErrorCategory
+	}{
+		{"message key", 400, map[string]interface{}{"message": " Policy P99 not found "}, "Policy P99 not found", "", CategoryPolicyNotFound},
+		{"error key", 400, map[string]interface{}{"status": "Error", "error": "protection policy \"P99\" not found"}, "protection policy \"P99\" not found", "", CategoryPolicyNotFound},
+		{"message before error", 400, map[string]interface{}{"message": "Invalid input data", "error": "Bad Request"}, "Invalid input data", "", CategoryInvalidData},
+		{"blank message falls through", 400, map[string]interface{}{"message": "  ", "error_message": "data length 3 is below the minimum"}, "data length 3 is below the minimum", "", CategoryInvalidData},
+		{"raw body", 502, map[string]interface{}{"raw": "<html>bad gateway</html>"}, "<html>bad gateway</html>", "", CategoryServer},
+		{"string code", 400, map[string]interface{}{"code": "E1001", "message": "missing external_version"}, "missing external_version", "E1001", CategoryBadRequest},
+		{"numeric code", 401, map[string]interface{}{"code": 1001.0}, "", "1001", CategoryAuth},
+		{"no body", 403, nil, "", "", CategoryForbidden},
+		{"404 policy", 404, map[string]interface{}{"message": "policy does not exist"}, "policy does not exist", "", CategoryPolicyNotFound},
+		{"404 other", 404, map[string]interface{}{}, "", "", CategoryNotFound},
+		{"rate limited", 429, map[string]interface{}{}, "", "", CategoryRateLimited},
+		{"other 4xx", 409, map[string]interface{}{}, "", "", CategoryClient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewAPIError("/v1/protect", tt.status, tt.body)
+			if e.Message != tt.message || e.Code != tt.code || e.Category != tt.category {
+				t.Errorf("NewAPIError = message %q, code %q, category %q, want %q, %q, %q",
+					e.Message, e.Code, e.Category, tt.message, tt.code, tt.category)
+			}
+		})
+	}
+}
+
+func TestClassifyStatusInvalidData(t *testing.T) {
+	tests := []struct {
+		message string
+		want    ErrorCategory
+	}{
+		{"Invalid input data", CategoryInvalidData},
+		{"data length 3 is below the minimum for this policy", CategoryInvalidData},
+		{"Input contains characters outside the character set", CategoryInvalidData},
+		{"data has no characters to tokenize outside the preserved prefix/suffix", CategoryInvalidData},
+		{"protected data was not issued by this policy", CategoryInvalidData},
+		// "data", "token", "input" 같은 단어만 있는 다른 400 오류는 invalid_data가 아님
+		{"invalid JSON body: unexpected end of JSON input", CategoryBadRequest},
+		{"missing data field", CategoryBadRequest},
+		{"external_version is required for policy \"P03\"", CategoryBadRequest},
+		{"token header malformed", CategoryBadRequest},
+	}
+	for _, tt := range tests {
+		if got := classifyStatus(400, tt.message); got != tt.want {
+			t.Errorf("classifyStatus(400, %q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	all := []error{ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrPolicyNotFound,
+		ErrInvalidData, ErrRateLimited, ErrServer, ErrTransport, ErrTimeout, ErrRequestSetup}
+	tests := []struct {
+		name   string
+		status int
+		msg    string
+		want   []error
+	}{
+		{"policy not found", 400, "policy P99 not found", []error{ErrBadRequest, ErrPolicyNotFound}},
+		{"invalid data", 400, "invalid data", []error{ErrBadRequest, ErrInvalidData}},
+		{"bad request", 400, "bad request", []error{ErrBadRequest}},
+		{"unauthorized", 401, "", []error{ErrUnauthorized}},
+		{"forbidden", 403, "", []error{ErrForbidden}},
+		{"policy 404", 404, "unknown policy", []error{ErrNotFound, ErrPolicyNotFound}},
+		{"not found", 404, "", []error{ErrNotFound}},
+		{"rate limited", 429, "", []error{ErrRateLimited}},
+		{"server", 503, "", []error{ErrServer}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error = NewAPIError("/v1/protect", tt.status, map[string]interface{}{"message": tt.msg})
+			err = fmt.Errorf("iteration 3: %w", err)
+			for _, target := range all {
+				want := false
+				for _, w := range tt.want {
+					want = want || w == target
+				}
+				if got := errors.Is(err, target); got != want {
+					t.Errorf("errors.Is(%v, %v) = %v, want %v", err, target, got, want)
+				}
+			}
+		})
+	}
+}
+
+// timeoutErr는 Timeout이 true인 net.Error입니다
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+var _ net.Error = timeoutErr{}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCategory
+	}{
+		{"nil", nil, ""},
+		{"api error", NewAPIError("/v1/reveal", 403, nil), CategoryForbidden},
+		{"wrapped api error", fmt.Errorf("batch: %w", NewAPIError("/v1/reveal", 401, nil)), CategoryAuth},
+		{"circuit open", ErrCircuitOpen, CategoryCircuitOpen},
+		{"invalid response", fmt.Errorf("%w: missing data", ErrInvalidResponse), CategoryInvalidResponse},
+		{"deadline", &TransportError{Endpoint: "/v1/protect", Err: context.DeadlineExceeded}, CategoryTimeout},
+		{"net timeout", &TransportError{Endpoint: "/v1/protect", Err: timeoutErr{}}, CategoryTimeout},
+		{"connection refused", &TransportError{Endpoint: "/v1/protect", Err: errors.New("connection refused")}, CategoryTransport},
+		{"token source", &SetupError{Endpoint: "/v1/protect", Err: errors.New("vault unavailable")}, CategoryRequestSetup},
+		{"token source deadline", &SetupError{Endpoint: "/v1/protect", Err: context.DeadlineExceeded}, CategoryRequestSetup},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.err); got != tt.want {
+			t.Errorf("%s: Classify(%v) = %q, want %q", tt.name, tt.err, got, tt.want)
+		}
+	}
+
+	setup := &SetupError{Endpoint: "/v1/protect", Err: errors.New("vault unavailable")}
+	if errors.Is(setup, ErrTransport) || !errors.Is(setup, ErrRequestSetup) {
+		t.Errorf("SetupError must match ErrRequestSetup and not ErrTransport")
+	}
+	if tr := (&TransportError{Err: timeoutErr{}}); !errors.Is(tr, ErrTransport) || !errors.Is(tr, ErrTimeout) {
+		t.Errorf("timed out TransportError must match ErrTransport and ErrTimeout")
+	}
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit는 요청 속도와 동시 요청 수 제한입니다 (0이면 해당 항목 제한 없음)
+type Limit struct {
+	RequestsPerSec float64 // 초당 요청 수
+	ItemsPerSec    float64 // 초당 데이터 개수 (bulk 요청은 배치 크기, 단일 요청은 1)
+	Burst          int     // 버킷 크기 (0이면 1, 즉 요청 간격을 고르게 유지)
+	MaxInFlight    int     // 동시에 진행 중인 요청 수 상한
+}
+
+// enabled는 제한 항목이 하나라도 설정되었는지 확인합니다
+func (l Limit) enabled() bool {
+	return l.RequestsPerSec > 0 || l.ItemsPerSec > 0 || l.MaxInFlight > 0
+}
+
+// Limiter는 전체 및 엔드포인트별 토큰 버킷 속도 제한과 동시 요청 수 제한을 적용합니다
+// 여러 Client가 같은 Limiter를 공유하면 프로세스 전체에 하나의 제한이 적용됩니다
+type Limiter struct {
+	global    *gate
+	endpoints map[string]*gate // 키는 "protect", "revealbulk" 등 /v1/ 뒤의 엔드포인트 이름
+}
+
+// NewLimiter는 전체 제한과 엔드포인트별 제한으로 Limiter를 생성합니다
+// 설정된 제한이 하나도 없으면 nil을 반환합니다
+func NewLimiter(global Limit, endpoints map[string]Limit) *Limiter {
+	l := &Limiter{endpoints: make(map[string]*gate)}
+	if global.enabled() {
+		l.global = newGate(global)
+	}
+	for name, limit := range endpoints {
+		if limit.enabled() {
+			l.endpoints[name] = newGate(limit)
+		}
+	}
+	if l.global == nil && len(l.endpoints) == 0 {
+		return nil
+	}
+	return l
+}
+
+// Acquire는 엔드포인트 요청 하나(items개 데이터)를 보낼 수 있을 때까지 기다립니다
+// 반환된 release는 요청이 끝나면 반드시 호출해야 하며, waited는 대기한 시간입니다
+// 엔드포인트별 제한을 먼저 적용한 뒤 전체 제한을 적용합니다 (동시 요청 슬롯 획득 순서 고정)
+func (l *Limiter) Acquire(ctx context.Context, endpoint string, items int) (release func(), waited time.Duration, err error) {
+	if l == nil {
+		return func() {}, 0, nil
+	}
+	start := time.Now()
+	var releases []func()
+	release = func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+	for _, g := range []*gate{l.endpoints[strings.TrimPrefix(endpoint, "/v1/")], l.global} {
+		if g == nil {
+			continue
+		}
+		r, err := g.acquire(ctx, items)
+		if err != nil {
+			release()
+			return nil, time.Since(start), err
+		}
+		releases = append(releases, r)
+	}
+	return release, time.Since(start), nil
+}
+
+// gate는 하나의 Limit(요청/데이터 버킷, 동시 요청 슬롯)를 구현합니다
+type gate struct {
+	requests *bucket
+	items    *bucket
+	slots    chan struct{}
+}
+
+// newGate는 Limit 설정으로 gate를 생성합니다
+func newGate(l Limit) *gate {
+	g := &gate{
+		requests: newBucket(l.RequestsPerSec, l.Burst),
+		items:    newBucket(l.ItemsPerSec, l.Burst),
+	}
+	if l.MaxInFlight > 0 {
+		g.slots = make(chan struct{}, l.MaxInFlight)
+	}
+	return g
+}
+
+// acquire는 속도 제한 토큰과 동시 요청 슬롯을 차례로 얻습니다
+func (g *gate) acquire(ctx context.Context, items int) (func(), error) {
+	if err := g.requests.wait(ctx, 1); err != nil {
+		return nil, err
+	}
+	if err := g.items.wait(ctx, items); err != nil {
+		return nil, err
+	}
+	if g.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case g.slots <- struct{}{}:
+		return func() { <-g.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// bucket은 토큰 버킷입니다 (rate가 0이면 nil로 제한 없음)
+// 토큰이 부족해도 먼저 차감(예약)하므로 버킷 크기보다 큰 요청도 rate에 맞춰 대기합니다
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64 // 초당 토큰 수
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newBucket은 rate가 0 이하이면 nil을 반환합니다
+func newBucket(rate float64, burst int) *bucket {
+	if rate <= 0 {
+		return nil
+	}
+	b := math.Max(1, float64(burst))
+	return &bucket{rate: rate, burst: b, tokens: b, last: time.Now()}
+}
+
+// wait는 n개의 토큰을 예약하고 토큰이 채워질 때까지 기다립니다
+func (b *bucket) wait(ctx context.Context, n int) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens -= float64(n)
+	deficit := -b.tokens
+	b.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(time.Duration(deficit / b.rate * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewLimiterDisabled(t *testing.T) {
+	if l := NewLimiter(Limit{}, map[string]Limit{"protect": {Burst: 5}}); l != nil {
+		t.Fatalf("NewLimiter without limits = %v, want nil", l)
+	}
+	var l *Limiter
+	release, waited, err := l.Acquire(context.Background(), "/v1/protect", 1)
+	if err != nil || waited != 0 {
+		t.Fatalf("nil Limiter Acquire = %v, %v", waited, err)
+	}
+	release()
+}
+
+func TestLimiterRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		global   Limit
+		endpoint string
+		items    int
+		requests int
+		minWait  time.Duration
+		maxWait  time.Duration
+	}{
+		// 버킷 크기 1: 첫 요청은 바로, 이후 요청마다 10ms 간격
+		{"requests per second", Limit{RequestsPerSec: 100}, "/v1/protect", 1, 5, 35 * time.Millisecond, time.Second},
+		// 버킷 크기 5: 처음 5개는 대기 없이 통과
+		{"burst", Limit{RequestsPerSec: 100, Burst: 5}, "/v1/protect", 1, 5, 0, 5 * time.Millisecond},
+		// 배치 20개씩 초당 1000개: 첫 배치부터 19개 부족
+		{"items per second", Limit{ItemsPerSec: 1000}, "/v1/protectbulk", 20, 2, 35 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter(tt.global, nil)
+			var total time.Duration
+			for i := 0; i < tt.requests; i++ {
+				release, waited, err := l.Acquire(context.Background(), tt.endpoint, tt.items)
+				if err != nil {
+					t.Fatalf("Acquire() error: %v", err)
+				}
+				release()
+				total += waited
+			}
+			if total < tt.minWait || total > tt.maxWait {
+				t.Errorf("waited %v in total, want between %v and %v", total, tt.minWait, tt.maxWait)
+			}
+		})
+	}
+}
+
+func TestLimiterEndpoint(t *testing.T) {
+	l := NewLimiter(Limit{}, map[string]Limit{"reveal": {RequestsPerSec: 10}})
+	// protect에는 제한이 없으므로 대기 없이 통과
+	for i := 0; i < 5; i++ {
+		release, waited, err := l.Acquire(context.Background(), "/v1/protect", 1)
+		if err != nil || waited > 5*time.Millisecond {
+			t.Fatalf("unlimited endpoint waited %v, %v", waited, err)
+		}
+		release()
+	}
+	release, _, err := l.Acquire(context.Background(), "/v1/reveal", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := l.Acquire(ctx, "/v1/reveal", 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second reveal within 100ms: err = %v, want deadline exceeded", err)
+	}
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	l := NewLimiter(Limit{MaxInFlight: 1}, nil)
+	release, _, err := l.Acquire(context.Background(), "/v1/protect", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := l.Acquire(ctx, "/v1/protect", 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Acquire while slot is taken: err = %v, want deadline exceeded", err)
+	}
+
+	release()
+	release2, _, err := l.Acquire(context.Background(), "/v1/protect", 1)
+	if err != nil {
+		t.Fatalf("Acquire after release: %v", err)
+	}
+	release2()
+}
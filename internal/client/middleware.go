@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Middleware는 HTTP 요청 전송(http.RoundTripper)을 감싸는 함수입니다
+// 헤더 추가, 요청 서명, 바이트 집계 등에 사용하며 먼저 등록한 미들웨어가 바깥쪽에서 실행됩니다
+// http.RoundTripper 규약에 따라 요청을 바꿀 때는 req.Clone으로 복사한 뒤 바꿉니다
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc는 함수를 http.RoundTripper로 사용합니다
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip은 http.RoundTripper 구현입니다
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RequestInfo는 미들웨어가 요청 context에서 꺼내 쓸 수 있는 요청 단위 정보입니다
+type RequestInfo struct {
+	Endpoint  string            // 예: /v1/protect
+	RequestID string            // X-Request-ID 헤더 값 (재시도 간 동일)
+	Attempt   int               // 1부터 시작하는 시도 번호
+	Policy    string            // 보호 정책 이름
+	Items     int               // 요청에 포함된 데이터 개수 (단일 요청은 1)
+	Metadata  map[string]string // WithMetadata로 ctx에 담은 값 (읽기 전용)
+}
+
+type requestInfoKey struct{}
+type metadataKey struct{}
+
+// RequestInfoFrom은 미들웨어에서 현재 요청의 RequestInfo를 꺼냅니다
+func RequestInfoFrom(ctx context.Context) (*RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return info, ok
+}
+
+// WithMetadata는 ctx로 보내는 요청의 RequestInfo.Metadata에 key=value를 추가합니다
+func WithMetadata(ctx context.Context, key, value string) context.Context {
+	parent := metadataFrom(ctx)
+	md := make(map[string]string, len(parent)+1)
+	for k, v := range parent {
+		md[k] = v
+	}
+	md[key] = value
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// metadataFrom은 ctx에 담긴 메타데이터를 반환합니다 (없으면 nil)
+func metadataFrom(ctx context.Context) map[string]string {
+	md, _ := ctx.Value(metadataKey{}).(map[string]string)
+	return md
+}
+
+// Hook은 요청 전후에 호출되는 콜백입니다 (HookMiddleware로 Middleware로 바꿔 사용)
+type Hook interface {
+	// BeforeRequest는 요청 전송 직전에 호출되며, 오류를 반환하면 요청을 보내지 않습니다
+	// req는 복사본이므로 헤더를 바꿔도 됩니다
+	BeforeRequest(req *http.Request, info *RequestInfo) error
+	// AfterResponse는 응답 헤더를 받았거나 전송 오류가 난 뒤 호출됩니다 (resp는 nil일 수 있음)
+	AfterResponse(req *http.Request, resp *http.Response, info *RequestInfo, err error)
+}
+
+// HookMiddleware는 Hook을 Middleware로 바꿉니다
+func HookMiddleware(h Hook) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := RequestInfoFrom(req.Context())
+			if info == nil {
+				info = &RequestInfo{}
+			}
+			req = req.Clone(req.Context())
+			if err := h.BeforeRequest(req, info); err != nil {
+				h.AfterResponse(req, nil, info, err)
+				return nil, err
+			}
+			resp, err := next.RoundTrip(req)
+			h.AfterResponse(req, resp, info, err)
+			return resp, err
+		})
+	}
+}
+
+// HeaderMiddleware는 모든 요청에 고정 헤더를 추가합니다 (같은 이름의 기존 헤더는 덮어씀)
+// "Host"는 요청의 Host 값으로 설정합니다
+func HeaderMiddleware(headers map[string]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if len(headers) == 0 {
+			return next
+		}
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for k, v := range headers {
+				if strings.EqualFold(k, "Host") {
+					req.Host = v
+					continue
+				}
+				req.Header.Set(k, v)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// ValidateHeader는 커스텀 헤더의 이름과 값이 HTTP 헤더로 보낼 수 있는지 확인합니다
+func ValidateHeader(name, value string) error {
+	if name == "" {
+		return fmt.Errorf("header name must not be empty")
+	}
+	if strings.ContainsAny(name, " \t\r\n:") {
+		return fmt.Errorf("invalid header name %q", name)
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("header %s: value must not contain line breaks", name)
+	}
+	return nil
+}
+
+// ParseHeader는 "Name: value" 형식의 문자열을 헤더 이름과 값으로 나눕니다
+func ParseHeader(s string) (string, string, error) {
+	name, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", "", fmt.Errorf("invalid header %q (expected \"Name: value\")", s)
+	}
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if err := ValidateHeader(name, value); err != nil {
+		return "", "", err
+	}
+	return name, value, nil
+}
+
+// Use는 요청 전송에 미들웨어를 추가합니다 (요청을 보내기 전에 호출)
+func (c *Client) Use(mw ...Middleware) {
+	c.middlewares = append(c.middlewares, mw...)
+	var rt http.RoundTripper = c.transport
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		rt = c.middlewares[i](rt)
+	}
+	c.client.Transport = rt
+}
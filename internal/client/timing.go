@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// PhaseTiming은 한 번의 HTTP 요청에서 연결 단계별 소요 시간입니다
+// 재사용된 연결에서는 DNS/Connect/TLS가 0입니다
+type PhaseTiming struct {
+	DNS      time.Duration // DNS 조회
+	Connect  time.Duration // TCP 연결
+	TLS      time.Duration // TLS 핸드셰이크
+	TTFB     time.Duration // 요청 전송 완료부터 첫 응답 바이트까지 (서버 처리 + 네트워크 왕복)
+	BodyRead time.Duration // 첫 응답 바이트부터 응답 본문 읽기 완료까지
+	Reused   bool          // keep-alive 연결 재사용 여부
+}
+
+// phaseRecorder는 httptrace 콜백에서 단계별 시각을 기록합니다
+// 콜백은 서로 다른 고루틴에서 호출될 수 있으므로 잠금으로 보호합니다
+type phaseRecorder struct {
+	mu sync.Mutex
+
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	wroteRequest, firstByte   time.Time
+	reused                    bool
+}
+
+// withTrace는 단계별 시각을 기록하는 httptrace.ClientTrace를 ctx에 연결합니다
+func (r *phaseRecorder) withTrace(ctx context.Context) context.Context {
+	// 여러 주소로 연결을 시도하는 경우 첫 시작 시각과 마지막 완료 시각을 사용
+	first := func(t *time.Time) {
+		r.mu.Lock()
+		if t.IsZero() {
+			*t = time.Now()
+		}
+		r.mu.Unlock()
+	}
+	last := func(t *time.Time) {
+		r.mu.Lock()
+		*t = time.Now()
+		r.mu.Unlock()
+	}
+
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { first(&r.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { last(&r.dnsDone) },
+		ConnectStart:      func(string, string) { first(&r.connectStart) },
+		ConnectDone:       func(string, string, error) { last(&r.connectDone) },
+		TLSHandshakeStart: func() { first(&r.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { last(&r.tlsDone) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			r.mu.Lock()
+			r.reused = info.Reused
+			r.mu.Unlock()
+		},
+		WroteRequest:         func(httptrace.WroteRequestInfo) { last(&r.wroteRequest) },
+		GotFirstResponseByte: func() { first(&r.firstByte) },
+	})
+}
+
+// timing은 기록된 시각으로 단계별 소요 시간을 계산합니다 (bodyDone은 본문 읽기 완료 시각)
+func (r *phaseRecorder) timing(bodyDone time.Time) *PhaseTiming {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &PhaseTiming{
+		DNS:      span(r.dnsStart, r.dnsDone),
+		Connect:  span(r.connectStart, r.connectDone),
+		TLS:      span(r.tlsStart, r.tlsDone),
+		TTFB:     span(r.wroteRequest, r.firstByte),
+		BodyRead: span(r.firstByte, bodyDone),
+		Reused:   r.reused,
+	}
+}
+
+// span은 두 시각이 모두 기록된 경우 그 차이를 반환합니다
+func span(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
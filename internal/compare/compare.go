@@ -0,0 +1,177 @@
+package compare
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+)
+
+// Thresholds는 회귀 판정 기준을 나타냅니다
+type Thresholds struct {
+	MaxRegressionPct     float64 // 처리량/지연 시간의 허용 악화 비율 (%)
+	MaxErrorRateIncrease float64 // 오류율의 허용 증가폭 (%p)
+	Alpha                float64 // 유의 수준 (p-value가 이 값보다 작아야 유의미한 차이로 판단)
+}
+
+// Metric은 하나의 지표에 대한 비교 결과를 나타냅니다
+type Metric struct {
+	Name      string
+	Unit      string
+	Base      float64
+	New       float64
+	ChangePct float64 // 기준 대비 변화율 (%)
+	PValue    float64 // 유의성 검정 p-value (검정하지 않은 경우 NaN)
+	Regressed bool
+}
+
+// RowComparison은 같은 이름의 결과 행 두 개를 비교한 결과입니다
+type RowComparison struct {
+	Name      string
+	Metrics   []Metric
+	Regressed bool
+}
+
+// Report는 두 결과 파일의 전체 비교 결과입니다
+type Report struct {
+	Thresholds Thresholds
+	Rows       []RowComparison
+	OnlyBase   []string // 기준 파일에만 있는 행
+	OnlyNew    []string // 새 파일에만 있는 행
+}
+
+// Regressed는 하나 이상의 행에서 회귀가 감지되었는지 반환합니다
+func (r *Report) Regressed() bool {
+	for _, row := range r.Rows {
+		if row.Regressed {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare는 기준 결과와 새 결과를 행 이름 기준으로 비교합니다
+func Compare(base, current *output.Results, th Thresholds) *Report {
+	report := &Report{Thresholds: th}
+
+	newRows := make(map[string]output.Row, len(current.Rows))
+	for _, row := range current.Rows {
+		newRows[row.Name] = row
+	}
+	seen := make(map[string]bool, len(base.Rows))
+
+	for _, b := range base.Rows {
+		n, ok := newRows[b.Name]
+		if !ok {
+			report.OnlyBase = append(report.OnlyBase, b.Name)
+			continue
+		}
+		seen[b.Name] = true
+		report.Rows = append(report.Rows, compareRow(b, n, th))
+	}
+	for _, n := range current.Rows {
+		if !seen[n.Name] {
+			report.OnlyNew = append(report.OnlyNew, n.Name)
+		}
+	}
+	return report
+}
+
+// compareRow는 두 행의 처리량, 지연 시간, 오류율을 비교합니다
+func compareRow(b, n output.Row, th Thresholds) RowComparison {
+	rc := RowComparison{Name: b.Name}
+
+	// 처리량: 단일 관측값이므로 변화율만으로 판정
+	tp := newMetric("throughput", "items/s", b.Throughput, n.Throughput)
+	tp.Regressed = tp.ChangePct < -th.MaxRegressionPct
+	rc.Metrics = append(rc.Metrics, tp)
+
+	// 평균 지연 시간: 변화율과 Welch t-검정으로 판정
+	mean := newMetric("latency mean", "ms", b.LatencyMean, n.LatencyMean)
+	mean.PValue = welchTTest(b.LatencyMean, b.LatencyStdev, b.Samples, n.LatencyMean, n.LatencyStdev, n.Samples)
+	mean.Regressed = mean.ChangePct > th.MaxRegressionPct && significant(mean.PValue, th.Alpha)
+	rc.Metrics = append(rc.Metrics, mean)
+
+	// 백분위수: 분포 정보가 없으므로 변화율만으로 판정
+	for _, p := range []struct {
+		name      string
+		base, new float64
+	}{
+		{"latency p50", b.LatencyP50, n.LatencyP50},
+		{"latency p95", b.LatencyP95, n.LatencyP95},
+		{"latency p99", b.LatencyP99, n.LatencyP99},
+	} {
+		m := newMetric(p.name, "ms", p.base, p.new)
+		m.Regressed = m.ChangePct > th.MaxRegressionPct
+		rc.Metrics = append(rc.Metrics, m)
+	}
+
+	// 오류율: 증가폭(%p)과 two-proportion z-검정으로 판정
+	er := newMetric("error rate", "%", b.ErrorRate*100, n.ErrorRate*100)
+	er.PValue = twoProportionZTest(b.Attempted-b.Successful, b.Attempted, n.Attempted-n.Successful, n.Attempted)
+	er.Regressed = er.New-er.Base > th.MaxErrorRateIncrease && significant(er.PValue, th.Alpha)
+	rc.Metrics = append(rc.Metrics, er)
+
+	for _, m := range rc.Metrics {
+		if m.Regressed {
+			rc.Regressed = true
+		}
+	}
+	return rc
+}
+
+// newMetric은 변화율을 계산한 Metric을 생성합니다
+func newMetric(name, unit string, base, current float64) Metric {
+	m := Metric{Name: name, Unit: unit, Base: base, New: current, PValue: math.NaN()}
+	if base != 0 {
+		m.ChangePct = (current - base) / base * 100
+	}
+	return m
+}
+
+// significant는 p-value가 유의 수준보다 작은지 확인합니다
+// 검정이 불가능한 경우(NaN)에는 변화율만으로 판단하도록 true를 반환합니다
+func significant(pValue, alpha float64) bool {
+	if math.IsNaN(pValue) {
+		return true
+	}
+	return pValue < alpha
+}
+
+// Print는 비교 결과를 표 형태로 출력합니다
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "Thresholds: regression > %.1f%%, error rate increase > %.2f%%p, alpha = %.3f\n",
+		r.Thresholds.MaxRegressionPct, r.Thresholds.MaxErrorRateIncrease, r.Thresholds.Alpha)
+
+	for _, row := range r.Rows {
+		status := "OK"
+		if row.Regressed {
+			status = "REGRESSION"
+		}
+		fmt.Fprintf(w, "\n%s [%s]\n", row.Name, status)
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  metric\tbase\tnew\tchange\tp-value")
+		for _, m := range row.Metrics {
+			pv := "-"
+			if !math.IsNaN(m.PValue) {
+				pv = fmt.Sprintf("%.4f", m.PValue)
+			}
+			mark := ""
+			if m.Regressed {
+				mark = "  <- regressed"
+			}
+			fmt.Fprintf(tw, "  %s (%s)\t%.3f\t%.3f\t%+.1f%%\t%s%s\n", m.Name, m.Unit, m.Base, m.New, m.ChangePct, pv, mark)
+		}
+		tw.Flush()
+	}
+
+	for _, name := range r.OnlyBase {
+		fmt.Fprintf(w, "\n%s: only in base results (skipped)\n", name)
+	}
+	for _, name := range r.OnlyNew {
+		fmt.Fprintf(w, "\n%s: only in new results (skipped)\n", name)
+	}
+}
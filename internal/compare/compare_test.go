@@ -0,0 +1,110 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+)
+
+var testThresholds = Thresholds{MaxRegressionPct: 10, MaxErrorRateIncrease: 1, Alpha: 0.05}
+
+func row(name string, throughput, p99 float64) output.Row {
+	return output.Row{
+		Name:        name,
+		Attempted:   1000,
+		Successful:  1000,
+		Throughput:  throughput,
+		Samples:     1000,
+		LatencyMean: 10,
+		LatencyP50:  10,
+		LatencyP95:  20,
+		LatencyP99:  p99,
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		base, new []output.Row
+		regressed bool
+		rows      int
+		onlyBase  []string
+		onlyNew   []string
+	}{
+		{
+			name: "unchanged",
+			base: []output.Row{row("run", 100, 30)},
+			new:  []output.Row{row("run", 100, 30)},
+			rows: 1,
+		},
+		{
+			name:      "throughput drop",
+			base:      []output.Row{row("run", 100, 30)},
+			new:       []output.Row{row("run", 80, 30)},
+			regressed: true,
+			rows:      1,
+		},
+		{
+			name:      "p99 increase",
+			base:      []output.Row{row("run", 100, 30)},
+			new:       []output.Row{row("run", 100, 40)},
+			regressed: true,
+			rows:      1,
+		},
+		{
+			name: "within threshold",
+			base: []output.Row{row("run", 100, 30)},
+			new:  []output.Row{row("run", 95, 32)},
+			rows: 1,
+		},
+		{
+			name:     "disjoint rows",
+			base:     []output.Row{row("a", 100, 30)},
+			new:      []output.Row{row("b", 50, 90)},
+			onlyBase: []string{"a"},
+			onlyNew:  []string{"b"},
+		},
+		{
+			name:     "partial overlap",
+			base:     []output.Row{row("a", 100, 30), row("b", 100, 30)},
+			new:      []output.Row{row("b", 100, 30)},
+			rows:     1,
+			onlyBase: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Compare(&output.Results{Rows: tt.base}, &output.Results{Rows: tt.new}, testThresholds)
+			if r.Regressed() != tt.regressed {
+				t.Errorf("Regressed() = %v, want %v", r.Regressed(), tt.regressed)
+			}
+			if len(r.Rows) != tt.rows {
+				t.Errorf("compared %d rows, want %d", len(r.Rows), tt.rows)
+			}
+			if !reflect.DeepEqual(r.OnlyBase, tt.onlyBase) {
+				t.Errorf("OnlyBase = %v, want %v", r.OnlyBase, tt.onlyBase)
+			}
+			if !reflect.DeepEqual(r.OnlyNew, tt.onlyNew) {
+				t.Errorf("OnlyNew = %v, want %v", r.OnlyNew, tt.onlyNew)
+			}
+		})
+	}
+}
+
+func TestCompareErrorRate(t *testing.T) {
+	base := row("run", 100, 30)
+	current := row("run", 100, 30)
+	current.Successful = 900
+	current.ErrorRate = 0.1
+
+	r := Compare(&output.Results{Rows: []output.Row{base}}, &output.Results{Rows: []output.Row{current}}, testThresholds)
+	if !r.Regressed() {
+		t.Fatal("error rate increase from 0% to 10% was not flagged as a regression")
+	}
+	for _, m := range r.Rows[0].Metrics {
+		if m.Name == "error rate" && !m.Regressed {
+			t.Errorf("error rate metric not marked as regressed: %+v", m)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package compare
+
+import "math"
+
+// welchTTest는 두 표본의 평균 차이에 대한 Welch t-검정의 양측 p-value를 반환합니다
+// 표본이 부족하거나 분산이 0이면 NaN을 반환합니다
+func welchTTest(mean1, sd1 float64, n1 int, mean2, sd2 float64, n2 int) float64 {
+	if n1 < 2 || n2 < 2 {
+		return math.NaN()
+	}
+	v1 := sd1 * sd1 / float64(n1)
+	v2 := sd2 * sd2 / float64(n2)
+	if v1+v2 == 0 {
+		return math.NaN()
+	}
+
+	t := (mean2 - mean1) / math.Sqrt(v1+v2)
+	// Welch–Satterthwaite 자유도
+	df := (v1 + v2) * (v1 + v2) / (v1*v1/float64(n1-1) + v2*v2/float64(n2-1))
+
+	// 양측 p-value = I_{df/(df+t^2)}(df/2, 1/2)
+	return regIncBeta(df/2, 0.5, df/(df+t*t))
+}
+
+// twoProportionZTest는 두 비율의 차이에 대한 z-검정의 양측 p-value를 반환합니다
+func twoProportionZTest(x1, n1, x2, n2 int) float64 {
+	if n1 == 0 || n2 == 0 {
+		return math.NaN()
+	}
+	p1 := float64(x1) / float64(n1)
+	p2 := float64(x2) / float64(n2)
+	pooled := float64(x1+x2) / float64(n1+n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2)))
+	if se == 0 {
+		if p1 == p2 {
+			return 1
+		}
+		return 0
+	}
+	z := (p2 - p1) / se
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// regIncBeta는 정규화된 불완전 베타 함수 I_x(a, b)를 계산합니다
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	// 연분수가 빠르게 수렴하는 쪽을 선택
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction은 불완전 베타 함수의 연분수를 Lentz 방법으로 계산합니다
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIter = 200
+		eps     = 3e-14
+		tiny    = 1e-300
+	)
+
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
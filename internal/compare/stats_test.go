@@ -0,0 +1,98 @@
+package compare
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRegIncBeta(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, x float64
+		want    float64
+	}{
+		{"lower bound", 2, 3, 0, 0},
+		{"upper bound", 2, 3, 1, 1},
+		{"uniform", 1, 1, 0.3, 0.3},
+		{"symmetric midpoint", 4.5, 4.5, 0.5, 0.5},
+		{"power", 3, 1, 0.5, 0.125},
+		{"reflected power", 1, 2, 0.5, 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := regIncBeta(tt.a, tt.b, tt.x); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("regIncBeta(%v, %v, %v) = %v, want %v", tt.a, tt.b, tt.x, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWelchTTest(t *testing.T) {
+	tests := []struct {
+		name         string
+		mean1, sd1   float64
+		n1           int
+		mean2, sd2   float64
+		n2           int
+		want, within float64
+	}{
+		{"same mean", 10, 2, 30, 10, 2, 30, 1, 1e-9},
+		// 표본이 매우 크면 t 분포가 정규 분포에 가까워져 t=2의 양측 p-value는 약 0.0455
+		{"large samples t=2", 0, 1, 1000000, 2 * math.Sqrt(2e-6), 1, 1000000, 0.0455, 1e-3},
+		// t=2.262, df=18 (등분산, n=10): 양측 p-value 약 0.036
+		{"small samples", 0, 1, 10, 2.262 * math.Sqrt(0.2), 1, 10, 0.0363, 1e-3},
+		{"very different", 10, 1, 50, 20, 1, 50, 0, 1e-9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := welchTTest(tt.mean1, tt.sd1, tt.n1, tt.mean2, tt.sd2, tt.n2)
+			if math.Abs(got-tt.want) > tt.within {
+				t.Errorf("welchTTest() = %v, want %v ± %v", got, tt.want, tt.within)
+			}
+		})
+	}
+}
+
+func TestWelchTTestUndefined(t *testing.T) {
+	tests := []struct {
+		name       string
+		mean1, sd1 float64
+		n1         int
+		mean2, sd2 float64
+		n2         int
+	}{
+		{"single sample", 1, 1, 1, 2, 1, 10},
+		{"zero variance", 1, 0, 10, 2, 0, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := welchTTest(tt.mean1, tt.sd1, tt.n1, tt.mean2, tt.sd2, tt.n2); !math.IsNaN(got) {
+				t.Errorf("welchTTest() = %v, want NaN", got)
+			}
+		})
+	}
+}
+
+func TestTwoProportionZTest(t *testing.T) {
+	tests := []struct {
+		name           string
+		x1, n1, x2, n2 int
+		want           float64
+	}{
+		{"same rate", 10, 100, 10, 100, 1},
+		{"no errors on either side", 0, 100, 0, 100, 1},
+		{"all errors vs none", 100, 100, 0, 100, 0},
+		// p1=0.5, p2=0.7, pooled=0.6 → z≈2.887, 양측 p-value≈0.00389
+		{"significant difference", 50, 100, 70, 100, 0.00389},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := twoProportionZTest(tt.x1, tt.n1, tt.x2, tt.n2); math.Abs(got-tt.want) > 1e-4 {
+				t.Errorf("twoProportionZTest(%d, %d, %d, %d) = %v, want %v", tt.x1, tt.n1, tt.x2, tt.n2, got, tt.want)
+			}
+		})
+	}
+	if got := twoProportionZTest(1, 0, 1, 10); !math.IsNaN(got) {
+		t.Errorf("twoProportionZTest with empty sample = %v, want NaN", got)
+	}
+}
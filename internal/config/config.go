@@ -2,8 +2,12 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -15,15 +19,53 @@ type Config struct {
 		Port    int    `yaml:"port"`
 		Timeout int    `yaml:"timeout"`
 		TLS     bool   `yaml:"tls"`
+		// 전송 오류 및 429/502/503/504 응답 재시도
+		Retries        int `yaml:"retries"`
+		RetryBackoffMs int `yaml:"retry_backoff_ms"`
+		// 여러 호스트("host" 또는 "host:port", 포트 생략 시 port 사용)에 분산 (지정하면 host 대신 사용)
+		Hosts      []string `yaml:"hosts"`
+		Balance    string   `yaml:"balance"`     // round-robin, least-inflight, random
+		EjectAfter int      `yaml:"eject_after"` // 연속 실패 시 호스트 제외 기준 (0이면 제외하지 않음)
+		EjectMs    int      `yaml:"eject_ms"`    // 호스트 제외 유지 시간 (밀리초)
+		// 모든 요청에 추가할 HTTP 헤더 (게이트웨이 API 키, 테넌트 ID 등)
+		Headers map[string]string `yaml:"headers"`
 	} `yaml:"api"`
 
+	// 클라이언트 측 속도/동시 요청 제한 (공유 CRDP 인스턴스의 합의된 처리량 준수)
+	// 전체 제한과 엔드포인트별 제한이 모두 적용됩니다
+	RateLimit struct {
+		Limit     `yaml:",inline"`
+		Endpoints map[string]Limit `yaml:"endpoints"` // 키: protect, reveal, protectbulk, revealbulk
+	} `yaml:"rate_limit"`
+
+	// 서킷 브레이커 설정
+	// 최근 window개 요청의 실패(전송 오류, 429, 5xx) 비율이 error_ratio 이상이면 open_ms 동안 요청을 즉시 실패시킵니다
+	CircuitBreaker struct {
+		Enabled        bool    `yaml:"enabled"`
+		ErrorRatio     float64 `yaml:"error_ratio"`
+		Window         int     `yaml:"window"`
+		OpenMs         int     `yaml:"open_ms"`
+		HalfOpenProbes int     `yaml:"half_open_probes"` // 닫히기 전 성공해야 하는 시험 요청 수
+	} `yaml:"circuit_breaker"`
+
 	Protection struct {
 		Policy string `yaml:"policy"`
+		// 모든 protect/reveal 요청에 보낼 username (정책의 사용자별 접근 권한/마스킹 판단)
+		Username string `yaml:"username"`
+		// reveal 요청에 보낼 기본 external_version (protect 응답에 external_version이 없을 때 사용)
+		ExternalVersion string `yaml:"external_version"`
+		// 한 번의 실행에서 함께 사용할 여러 정책 (비어 있으면 policy 하나만 사용)
+		Policies []PolicyWeight `yaml:"policies"`
+		// 여러 정책 실행 방식: mix (가중치 비율로 섞음, 기본), iterate (정책마다 모든 항목 실행)
+		PolicyMode string `yaml:"policy_mode"`
 	} `yaml:"protection"`
 
 	Execution struct {
-		Iterations int    `yaml:"iterations"`
-		StartData  string `yaml:"start_data"`
+		Iterations    int    `yaml:"iterations"`
+		StartData     string `yaml:"start_data"`
+		PayloadLength int    `yaml:"payload_length"`
+		// 입력 파일 (.csv, .jsonl 또는 줄마다 데이터 하나인 텍스트, 지정하면 start_data/iterations 대신 사용)
+		InputFile string `yaml:"input_file"`
 	} `yaml:"execution"`
 
 	Batch struct {
@@ -35,7 +77,20 @@ type Config struct {
 		ShowProgress bool   `yaml:"show_progress"`
 		ShowBody     bool   `yaml:"show_body"`
 		Verbose      bool   `yaml:"verbose"`
+		Redact       string `yaml:"redact"` // 디버그 출력의 민감한 값 가림 방식 (none, mask, hash, length)
 		File         string `yaml:"file"`
+		Report       string `yaml:"report"`       // 단일 파일 HTML 리포트 경로
+		PhaseTiming  bool   `yaml:"phase_timing"` // 요청별 DNS/연결/TLS/TTFB/본문 읽기 시간 측정
+		// 실행 중 대시보드 (터미널이 아니면 live_interval초마다 한 줄 보고)
+		Live         bool `yaml:"live"`
+		LiveInterval int  `yaml:"live_interval"`
+		// 테스트 파이프라인용 리포트
+		JUnit          string `yaml:"junit"`
+		TAP            string `yaml:"tap"`
+		JUnitGroupSize int    `yaml:"junit_group_size"` // 단일 모드에서 한 테스트 케이스로 묶을 반복 수
+		// 모든 요청/응답 쌍을 기록할 카세트 파일과 기록 시 가림 방식
+		Record       string `yaml:"record"`
+		RecordRedact string `yaml:"record_redact"`
 	} `yaml:"output"`
 
 	// JWT 인증 설정
@@ -54,6 +109,90 @@ type Config struct {
 	Parallel struct {
 		Workers int `yaml:"workers"`
 	} `yaml:"parallel"`
+
+	// 파라미터 스윕(매트릭스) 설정
+	// 비어 있는 목록은 위의 단일 실행 설정값을 그대로 사용합니다
+	Matrix struct {
+		Enabled        bool     `yaml:"enabled"`
+		BatchSizes     []int    `yaml:"batch_sizes"` // 0은 단일(non-bulk) API를 의미
+		Workers        []int    `yaml:"workers"`
+		Policies       []string `yaml:"policies"`
+		TLS            []bool   `yaml:"tls"`
+		PayloadLengths []int    `yaml:"payload_lengths"`
+	} `yaml:"matrix"`
+
+	// Prometheus 메트릭 리스너 설정
+	Metrics struct {
+		Addr   string `yaml:"addr"`   // 예: ":9090" (비어 있으면 비활성화)
+		Linger int    `yaml:"linger"` // 실행 종료 후 리스너를 유지할 시간 (초)
+	} `yaml:"metrics"`
+
+	// 구조화 로그 설정
+	Logging struct {
+		Level  string `yaml:"level"`  // debug, info, warn, error
+		Format string `yaml:"format"` // text, json
+		File   string `yaml:"file"`   // 비어 있으면 표준 에러로 출력
+	} `yaml:"logging"`
+
+	// OpenTelemetry 트레이스 내보내기 설정 (file 또는 endpoint 중 하나 이상 지정 시 활성화)
+	Tracing struct {
+		File        string  `yaml:"file"`         // OTLP/JSON 파일 경로
+		Endpoint    string  `yaml:"endpoint"`     // OTLP/HTTP collector 주소 (예: http://localhost:4318/v1/traces)
+		ServiceName string  `yaml:"service_name"` // service.name 리소스 속성
+		SampleRatio float64 `yaml:"sample_ratio"` // 반복 스팬 샘플링 비율 (0~1)
+	} `yaml:"tracing"`
+
+	// 결과 비교(compare) 회귀 판정 기준
+	Compare struct {
+		MaxRegressionPct     float64 `yaml:"max_regression_pct"`
+		MaxErrorRateIncrease float64 `yaml:"max_error_rate_increase"`
+		Alpha                float64 `yaml:"alpha"`
+	} `yaml:"compare"`
+
+	// 실행 후 평가할 SLO 조건 (예: "p99 < 50ms", "error_rate < 0.1%")
+	Assertions []string `yaml:"assertions"`
+
+	// protect 응답 토큰 검증 항목 (예: "same_length", "same_charset", "prefix=6", "suffix=4", "not_input", "deterministic")
+	TokenChecks []string `yaml:"token_checks"`
+
+	// 접근 정책 검증(verify-access) 설정
+	// 표본 데이터를 한 번 protect한 뒤 각 신원으로 reveal하여 기대한 결과(plain, masked, denied)와 비교합니다
+	Access struct {
+		SampleSize int              `yaml:"sample_size"` // execution.start_data부터 생성할 표본 수 (입력 파일이 있으면 파일 항목 사용)
+		Identities []AccessIdentity `yaml:"identities"`
+	} `yaml:"access"`
+}
+
+// PolicyWeight는 여러 정책 실행에서 사용할 정책과 mix 모드의 선택 가중치입니다
+type PolicyWeight struct {
+	Name   string `yaml:"name"`
+	Weight int    `yaml:"weight"` // 0이면 1
+}
+
+// AccessIdentity는 접근 정책 검증에서 reveal을 요청할 신원과 기대 결과입니다
+type AccessIdentity struct {
+	Name     string `yaml:"name"`      // 보고서 표시 이름 (비어 있으면 username)
+	Username string `yaml:"username"`  // reveal 요청의 username
+	JWTToken string `yaml:"jwt_token"` // 이 신원의 JWT (비어 있으면 auth.jwt_token)
+	Expect   string `yaml:"expect"`    // plain, masked, denied
+	// masked일 때 reveal 결과가 일치해야 할 정규식 (예: "^\\*+[0-9]{4}$", 비어 있으면 원본과 다르기만 하면 됨)
+	MaskPattern string `yaml:"mask_pattern"`
+}
+
+// Limit는 속도/동시 요청 제한 설정입니다 (0이면 해당 항목 제한 없음)
+type Limit struct {
+	RequestsPerSec float64 `yaml:"requests_per_sec"`
+	ItemsPerSec    float64 `yaml:"items_per_sec"` // bulk 요청은 배치 크기만큼 차감
+	Burst          int     `yaml:"burst"`         // 순간 허용량 (0이면 1)
+	MaxInFlight    int     `yaml:"max_in_flight"`
+}
+
+// validate는 제한 값이 음수가 아닌지 확인합니다
+func (l Limit) validate() error {
+	if l.RequestsPerSec < 0 || l.ItemsPerSec < 0 || l.Burst < 0 || l.MaxInFlight < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	return nil
 }
 
 // LoadConfig는 config.yaml 파일을 읽어 설정을 로드합니다
@@ -69,8 +208,8 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// YAML 파싱
-	cfg := &Config{}
+	// YAML 파싱 (파일에 없는 항목은 기본값 유지)
+	cfg := DefaultConfig()
 	if err := yaml.Unmarshal(data, cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
@@ -78,6 +217,191 @@ func LoadConfig(filename string) (*Config, error) {
 	return cfg, nil
 }
 
+// Validate는 실행에 필요한 설정값이 올바른지 검사합니다
+func (c *Config) Validate() error {
+	if c.API.Host == "" {
+		return fmt.Errorf("api.host must not be empty")
+	}
+	if c.API.Port <= 0 || c.API.Port > 65535 {
+		return fmt.Errorf("api.port must be between 1 and 65535 (got %d)", c.API.Port)
+	}
+	if c.API.Timeout <= 0 {
+		return fmt.Errorf("api.timeout must be positive (got %d)", c.API.Timeout)
+	}
+	if c.Execution.Iterations <= 0 {
+		return fmt.Errorf("execution.iterations must be positive (got %d)", c.Execution.Iterations)
+	}
+	seen := make(map[string]bool, len(c.Protection.Policies))
+	for i, p := range c.Protection.Policies {
+		if p.Name == "" {
+			return fmt.Errorf("protection.policies[%d]: name must not be empty", i)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("protection.policies: duplicate policy %q", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Weight < 0 {
+			return fmt.Errorf("protection.policies[%d]: weight must not be negative (got %d)", i, p.Weight)
+		}
+	}
+	switch c.Protection.PolicyMode {
+	case "", "mix", "iterate":
+	default:
+		return fmt.Errorf("protection.policy_mode must be mix or iterate (got %q)", c.Protection.PolicyMode)
+	}
+	if len(c.Protection.Policies) > 0 && c.Matrix.Enabled && len(c.Matrix.Policies) > 0 {
+		return fmt.Errorf("protection.policies and matrix.policies cannot be combined")
+	}
+	if c.Batch.Enabled && c.Batch.Size <= 0 {
+		return fmt.Errorf("batch.size must be positive when batch is enabled (got %d)", c.Batch.Size)
+	}
+	for _, h := range c.API.Hosts {
+		if strings.TrimSpace(h) == "" {
+			return fmt.Errorf("api.hosts must not contain empty entries")
+		}
+	}
+	if !oneOf(c.API.Balance, "", "round-robin", "least-inflight", "random") {
+		return fmt.Errorf("api.balance must be round-robin, least-inflight or random (got %q)", c.API.Balance)
+	}
+	if c.API.EjectAfter < 0 || c.API.EjectMs < 0 {
+		return fmt.Errorf("api.eject_after and api.eject_ms must not be negative")
+	}
+	if c.API.Retries < 0 {
+		return fmt.Errorf("api.retries must not be negative (got %d)", c.API.Retries)
+	}
+	if c.API.RetryBackoffMs < 0 {
+		return fmt.Errorf("api.retry_backoff_ms must not be negative (got %d)", c.API.RetryBackoffMs)
+	}
+	if err := c.RateLimit.validate(); err != nil {
+		return fmt.Errorf("rate_limit: %w", err)
+	}
+	for name, l := range c.RateLimit.Endpoints {
+		switch name {
+		case "protect", "reveal", "protectbulk", "revealbulk":
+		default:
+			return fmt.Errorf("rate_limit.endpoints: unknown endpoint %q (expected protect, reveal, protectbulk or revealbulk)", name)
+		}
+		if err := l.validate(); err != nil {
+			return fmt.Errorf("rate_limit.endpoints.%s: %w", name, err)
+		}
+	}
+	if cb := c.CircuitBreaker; cb.Enabled {
+		if cb.ErrorRatio <= 0 || cb.ErrorRatio > 1 {
+			return fmt.Errorf("circuit_breaker.error_ratio must be greater than 0 and at most 1 (got %g)", cb.ErrorRatio)
+		}
+		if cb.Window <= 0 || cb.OpenMs <= 0 || cb.HalfOpenProbes <= 0 {
+			return fmt.Errorf("circuit_breaker.window, open_ms and half_open_probes must be positive")
+		}
+	}
+	if c.Parallel.Workers < 0 {
+		return fmt.Errorf("parallel.workers must not be negative (got %d)", c.Parallel.Workers)
+	}
+	redactModes := []string{"none", "mask", "hash", "length", "length-only"}
+	if !oneOf(c.Output.Redact, redactModes...) {
+		return fmt.Errorf("output.redact must be none, mask, hash or length (got %q)", c.Output.Redact)
+	}
+	if !oneOf(c.Output.RecordRedact, redactModes...) {
+		return fmt.Errorf("output.record_redact must be none, mask, hash or length (got %q)", c.Output.RecordRedact)
+	}
+	if !oneOf(c.Logging.Level, "", "debug", "info", "warn", "warning", "error") {
+		return fmt.Errorf("logging.level must be debug, info, warn or error (got %q)", c.Logging.Level)
+	}
+	if !oneOf(c.Logging.Format, "", "text", "json") {
+		return fmt.Errorf("logging.format must be text or json (got %q)", c.Logging.Format)
+	}
+	if c.Output.Live && c.Output.LiveInterval <= 0 {
+		return fmt.Errorf("output.live_interval must be positive (got %d)", c.Output.LiveInterval)
+	}
+	if c.Access.SampleSize < 0 {
+		return fmt.Errorf("access.sample_size must not be negative (got %d)", c.Access.SampleSize)
+	}
+	for i, id := range c.Access.Identities {
+		if id.Name == "" && id.Username == "" {
+			return fmt.Errorf("access.identities[%d]: name or username is required", i)
+		}
+		switch id.Expect {
+		case "plain", "masked", "denied":
+		default:
+			return fmt.Errorf("access.identities[%d]: expect must be plain, masked or denied (got %q)", i, id.Expect)
+		}
+		if _, err := regexp.Compile(id.MaskPattern); err != nil {
+			return fmt.Errorf("access.identities[%d].mask_pattern: %w", i, err)
+		}
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sample_ratio must be between 0 and 1 (got %g)", c.Tracing.SampleRatio)
+	}
+	return nil
+}
+
+// oneOf는 값이 허용 목록 중 하나인지 확인합니다 (대소문자와 앞뒤 공백 무시)
+func oneOf(value string, allowed ...string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+// LogValue는 slog.LogValuer 구현으로, 실행에 영향을 주는 주요 설정을 로그 속성으로 반환합니다
+// JWT 토큰과 커스텀 헤더 값은 기록하지 않습니다
+func (c *Config) LogValue() slog.Value {
+	headers := make([]string, 0, len(c.API.Headers))
+	for name := range c.API.Headers {
+		headers = append(headers, name)
+	}
+	sort.Strings(headers)
+	return slog.GroupValue(
+		slog.String("host", c.API.Host),
+		slog.Any("hosts", c.API.Hosts),
+		slog.Int("port", c.API.Port),
+		slog.Bool("tls", c.API.TLS),
+		slog.Int("timeout", c.API.Timeout),
+		slog.Int("retries", c.API.Retries),
+		slog.Any("headers", headers),
+		slog.String("policy", c.Protection.Policy),
+		slog.Any("policies", c.Protection.Policies),
+		slog.String("username", c.Protection.Username),
+		slog.String("input_file", c.Execution.InputFile),
+		slog.Int("iterations", c.Execution.Iterations),
+		slog.Bool("bulk", c.Batch.Enabled),
+		slog.Int("batch_size", c.Batch.Size),
+		slog.Int("workers", c.Parallel.Workers),
+		slog.Bool("jwt", c.Auth.JWT),
+		slog.Bool("matrix", c.Matrix.Enabled),
+	)
+}
+
+// MaskedYAML은 비밀 값(JWT 토큰, 커스텀 헤더 값)을 가린 설정을 YAML 문자열로 반환합니다 (리포트 출력용)
+func (c *Config) MaskedYAML() (string, error) {
+	masked := *c
+	if masked.Auth.JWTToken != "" {
+		masked.Auth.JWTToken = "********"
+	}
+	if len(c.Access.Identities) > 0 {
+		masked.Access.Identities = make([]AccessIdentity, len(c.Access.Identities))
+		for i, id := range c.Access.Identities {
+			if id.JWTToken != "" {
+				id.JWTToken = "********"
+			}
+			masked.Access.Identities[i] = id
+		}
+	}
+	if len(c.API.Headers) > 0 {
+		masked.API.Headers = make(map[string]string, len(c.API.Headers))
+		for name := range c.API.Headers {
+			masked.API.Headers[name] = "********"
+		}
+	}
+	data, err := yaml.Marshal(&masked)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+	return string(data), nil
+}
+
 // DefaultConfig는 기본 설정을 반환합니다
 func DefaultConfig() *Config {
 	cfg := &Config{}
@@ -85,15 +409,33 @@ func DefaultConfig() *Config {
 	cfg.API.Port = 32082
 	cfg.API.Timeout = 10
 	cfg.API.TLS = false
+	cfg.API.Retries = 0
+	cfg.API.RetryBackoffMs = 100
+	cfg.API.Balance = "round-robin"
+	cfg.API.EjectAfter = 5
+	cfg.API.EjectMs = 10000
+	// 서킷 브레이커 설정
+	cfg.CircuitBreaker.Enabled = false
+	cfg.CircuitBreaker.ErrorRatio = 0.5
+	cfg.CircuitBreaker.Window = 20
+	cfg.CircuitBreaker.OpenMs = 5000
+	cfg.CircuitBreaker.HalfOpenProbes = 1
 	cfg.Protection.Policy = "P03"
 	cfg.Execution.Iterations = 100
 	cfg.Execution.StartData = "1234567890123"
+	cfg.Execution.PayloadLength = 0
 	cfg.Batch.Enabled = false
 	cfg.Batch.Size = 50
 	cfg.Output.ShowProgress = false
 	cfg.Output.ShowBody = false
 	cfg.Output.Verbose = false
 	cfg.Output.File = ""
+	cfg.Output.JUnit = ""
+	cfg.Output.TAP = ""
+	cfg.Output.JUnitGroupSize = 100
+	cfg.Output.LiveInterval = 5
+	cfg.Output.Redact = "mask"
+	cfg.Output.RecordRedact = "mask"
 	// JWT 인증 설정
 	cfg.Auth.JWT = false
 	cfg.Auth.JWTToken = ""
@@ -103,6 +445,23 @@ func DefaultConfig() *Config {
 	cfg.File.SkipHeader = false
 	// Parallel 설정 (crdp_file_converter 호환)
 	cfg.Parallel.Workers = 1
+	// Matrix 설정
+	cfg.Matrix.Enabled = false
+	// Metrics 설정
+	cfg.Metrics.Addr = ""
+	cfg.Metrics.Linger = 0
+	// Logging 설정
+	cfg.Logging.Level = "info"
+	cfg.Logging.Format = "text"
+	// Tracing 설정
+	cfg.Tracing.ServiceName = "crdp-cli"
+	cfg.Tracing.SampleRatio = 1
+	// Compare 설정
+	cfg.Compare.MaxRegressionPct = 10
+	cfg.Compare.MaxErrorRateIncrease = 1
+	cfg.Compare.Alpha = 0.05
+	// Access 설정
+	cfg.Access.SampleSize = 5
 	return cfg
 }
 
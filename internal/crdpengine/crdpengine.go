@@ -0,0 +1,77 @@
+// Package crdpengine은 공개 SDK(pkg/crdp)와 CLI가 함께 쓰는 전송 엔진(internal/client) 생성 경로입니다
+// SDK는 Option을, CLI는 설정 파일을 Settings로 모아 같은 New로 엔진을 만들고,
+// CLI는 반환된 엔진에 속도 제한, 서킷 브레이커 같은 CLI 전용 기능을 붙입니다
+package crdpengine
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+)
+
+// Settings는 전송 엔진 구성 값입니다
+type Settings struct {
+	Host   string
+	Port   int
+	Policy string
+
+	TLS                bool
+	TLSConfig          *tls.Config // nil이면 InsecureSkipVerify만 반영한 기본 설정
+	InsecureSkipVerify bool
+
+	JWTToken    string                                    // 고정 JWT 토큰 (비어 있으면 보내지 않음)
+	TokenSource func(ctx context.Context) (string, error) // 요청마다 토큰을 가져올 함수 (JWTToken보다 우선)
+
+	Timeout    time.Duration // 요청별 타임아웃 (연결 타임아웃에도 초 단위로 올림해 사용)
+	MaxRetries int
+	Backoff    time.Duration
+
+	Username        string // 요청 기본 username
+	ExternalVersion string // reveal 요청 기본 external_version
+
+	Logger      *slog.Logger        // nil이면 기록하지 않음
+	Headers     map[string]string   // 모든 요청에 추가할 고정 헤더 (가장 바깥 미들웨어)
+	Middlewares []client.Middleware // 헤더 미들웨어 안쪽에 순서대로 추가
+}
+
+// New는 Settings로 전송 엔진을 생성합니다 (헤더가 HTTP 헤더로 보낼 수 없는 값이면 오류)
+func New(s Settings) (*client.Client, error) {
+	for name, value := range s.Headers {
+		if err := client.ValidateHeader(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	timeoutSec := int(math.Ceil(s.Timeout.Seconds()))
+	engine := client.NewClient(s.Host, s.Port, s.Policy, timeoutSec, s.TLS)
+	engine.SetTimeout(s.Timeout)
+	if s.TLS {
+		tlsConfig := s.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{InsecureSkipVerify: s.InsecureSkipVerify}
+		}
+		engine.SetTLSConfig(tlsConfig)
+	}
+	if s.TokenSource != nil {
+		engine.SetTokenSource(s.TokenSource)
+	} else if s.JWTToken != "" {
+		engine.SetJWT(true, s.JWTToken)
+	}
+	engine.SetRetry(s.MaxRetries, s.Backoff)
+	engine.SetRequestDefaults(client.RequestOptions{Username: s.Username, ExternalVersion: s.ExternalVersion})
+	if s.Logger != nil {
+		engine.SetLogger(s.Logger)
+	}
+	// 헤더를 가장 바깥에서 추가해 안쪽 미들웨어(요청 서명 등)가 최종 헤더를 보도록 함
+	if len(s.Headers) > 0 {
+		engine.Use(client.HeaderMiddleware(s.Headers))
+	}
+	if len(s.Middlewares) > 0 {
+		engine.Use(s.Middlewares...)
+	}
+	return engine, nil
+}
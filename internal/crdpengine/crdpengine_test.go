@@ -0,0 +1,112 @@
+package crdpengine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+// testSettings는 가짜 CRDP 서버를 시작하고 그 주소를 담은 Settings를 반환합니다
+func testSettings(t *testing.T, cfg fakecrdp.Config) Settings {
+	t.Helper()
+	srv := fakecrdp.NewTestServer(cfg)
+	t.Cleanup(srv.Close)
+	u, _ := url.Parse(srv.URL)
+	port, _ := strconv.Atoi(u.Port())
+	return Settings{Host: u.Hostname(), Port: port, Policy: "P03", Timeout: 5 * time.Second}
+}
+
+func TestNewInvalidHeader(t *testing.T) {
+	if _, err := New(Settings{Host: "127.0.0.1", Port: 1, Policy: "P03", Timeout: time.Second,
+		Headers: map[string]string{"X-Bad": "a\r\nb"}}); err == nil {
+		t.Error("New accepted a header value with a line break")
+	}
+}
+
+func TestNewAuthentication(t *testing.T) {
+	token := fakecrdp.SignToken("secret", "dev-user01", time.Hour)
+	tests := []struct {
+		name   string
+		set    func(s *Settings)
+		status int
+	}{
+		{"no token", func(s *Settings) {}, http.StatusUnauthorized},
+		{"static token", func(s *Settings) { s.JWTToken = token }, http.StatusOK},
+		{"token source", func(s *Settings) {
+			s.TokenSource = func(context.Context) (string, error) { return token, nil }
+		}, http.StatusOK},
+		{"token source wins", func(s *Settings) {
+			s.JWTToken = token
+			s.TokenSource = func(context.Context) (string, error) { return "not-a-jwt", nil }
+		}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testSettings(t, fakecrdp.Config{RequireJWT: true, JWTSecret: "secret"})
+			tt.set(&s)
+			c, err := New(s)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			resp, err := c.Protect("1234567890123")
+			if err != nil {
+				t.Fatalf("Protect: %v", err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestNewMiddlewareOrder(t *testing.T) {
+	s := testSettings(t, fakecrdp.Config{})
+	s.Headers = map[string]string{"X-Tenant": "a"}
+	var seen string
+	s.Middlewares = []client.Middleware{func(next http.RoundTripper) http.RoundTripper {
+		return client.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			seen = req.Header.Get("X-Tenant")
+			return next.RoundTrip(req)
+		})
+	}}
+	c, err := New(s)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := c.Protect("1234567890123"); err != nil {
+		t.Fatalf("Protect: %v", err)
+	}
+	if seen != "a" {
+		t.Errorf("middleware saw X-Tenant %q, want %q (headers are added outside middlewares)", seen, "a")
+	}
+}
+
+func TestNewRequestDefaults(t *testing.T) {
+	s := testSettings(t, fakecrdp.Config{Policies: []fakecrdp.Policy{
+		{Name: "P03", DefaultAccess: fakecrdp.AccessDenied, Users: map[string]fakecrdp.Access{"alice": fakecrdp.AccessPlain}},
+	}})
+	s.Username = "alice"
+	c, err := New(s)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	resp, err := c.Protect("1234567890123")
+	if err != nil {
+		t.Fatalf("Protect: %v", err)
+	}
+	token, _ := resp.Body["protected_data"].(string)
+	resp, err = c.Reveal(token)
+	if err != nil {
+		t.Fatalf("Reveal: %v", err)
+	}
+	if err := resp.Err(); errors.Is(err, client.ErrForbidden) || resp.StatusCode != http.StatusOK {
+		t.Errorf("reveal as default username: status %d, %v", resp.StatusCode, err)
+	}
+}
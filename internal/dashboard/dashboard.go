@@ -0,0 +1,315 @@
+package dashboard
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/runner"
+)
+
+const (
+	// refreshInterval은 터미널 화면 갱신 주기입니다
+	refreshInterval = 250 * time.Millisecond
+	// rateWindow는 현재 처리량(rps) 계산 구간입니다
+	rateWindow = 5 * time.Second
+	// latencyWindow는 이동 p50/p99 계산 구간입니다
+	latencyWindow = 10 * time.Second
+	// sparkWidth는 스파크라인에 표시할 초 단위 구간 수입니다
+	sparkWidth = 40
+	// barWidth는 진행 막대 폭입니다
+	barWidth = 30
+)
+
+// sparkChars는 스파크라인 막대 문자입니다 (낮음 -> 높음)
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+// sample은 한 번의 반복(배치) 완료 기록입니다
+type sample struct {
+	at      time.Time
+	latency float64 // 초
+	items   int
+}
+
+// Dashboard는 실행 중 진행 상황을 주기적으로 표시합니다
+// 터미널이면 같은 자리에서 화면을 갱신하고, 아니면 일정 간격으로 한 줄 보고를 출력합니다
+type Dashboard struct {
+	w        io.Writer
+	tty      bool
+	interval time.Duration // 터미널이 아닐 때 보고 간격
+	title    string
+	total    int // 전체 데이터 개수
+
+	mu      sync.Mutex
+	start   time.Time
+	done    int
+	errors  map[string]int // 상태 코드(또는 runner.ErrorKind)별 오류 수
+	recent  []sample       // latencyWindow 이내의 완료 기록
+	perSec  []int          // 시작 후 초 단위 처리 데이터 개수
+	lines   int            // 마지막으로 그린 화면 줄 수
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// New는 새로운 Dashboard를 생성합니다
+// tty가 false이면 interval마다 한 줄 보고를 출력합니다
+func New(w io.Writer, tty bool, title string, total int, interval time.Duration) *Dashboard {
+	return &Dashboard{
+		w:        w,
+		tty:      tty,
+		interval: interval,
+		title:    title,
+		total:    total,
+		errors:   make(map[string]int),
+	}
+}
+
+// IsTerminal은 f가 터미널(문자 장치)인지 확인합니다
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Start는 화면 갱신(또는 주기적 보고)을 시작합니다
+func (d *Dashboard) Start() {
+	d.mu.Lock()
+	d.start = time.Now()
+	d.mu.Unlock()
+
+	d.stop = make(chan struct{})
+	d.stopped = make(chan struct{})
+
+	every := refreshInterval
+	if !d.tty {
+		every = d.interval
+	}
+	go func() {
+		defer close(d.stopped)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				d.render()
+			case <-d.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop은 갱신을 멈추고 마지막 상태를 한 번 더 출력합니다
+func (d *Dashboard) Stop() {
+	close(d.stop)
+	<-d.stopped
+	d.render()
+}
+
+// Record는 반복(배치) 결과를 반영합니다 (runner.Options.Progress에 연결)
+func (d *Dashboard) Record(p runner.Progress) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.done += len(p.Inputs)
+
+	sec := int(now.Sub(d.start) / time.Second)
+	for len(d.perSec) <= sec {
+		d.perSec = append(d.perSec, 0)
+	}
+	d.perSec[sec] += len(p.Inputs)
+
+	if p.Err != nil {
+		d.errors[runner.ErrorKind(p.Err)]++
+		return
+	}
+	for _, resp := range []*client.APIResponse{p.Result.ProtectResponse, p.Result.RevealResponse} {
+		if resp != nil && !runner.IsSuccess(resp.StatusCode) {
+			d.errors[strconv.Itoa(resp.StatusCode)]++
+		}
+	}
+	d.recent = append(d.recent, sample{at: now, latency: p.Result.TimeS, items: len(p.Inputs)})
+}
+
+// render는 현재 상태를 출력합니다
+func (d *Dashboard) render() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.prune(now)
+	if d.tty {
+		d.renderFrame(now)
+	} else {
+		d.renderLine(now)
+	}
+}
+
+// prune은 latencyWindow를 벗어난 완료 기록을 제거합니다
+func (d *Dashboard) prune(now time.Time) {
+	i := 0
+	for i < len(d.recent) && now.Sub(d.recent[i].at) > latencyWindow {
+		i++
+	}
+	d.recent = d.recent[i:]
+}
+
+// rate는 최근 rateWindow 구간의 초당 처리 데이터 개수를 반환합니다
+func (d *Dashboard) rate(now time.Time) float64 {
+	window := rateWindow
+	if elapsed := now.Sub(d.start); elapsed < window {
+		window = elapsed
+	}
+	if window <= 0 {
+		return 0
+	}
+	items := 0
+	for _, s := range d.recent {
+		if now.Sub(s.at) <= window {
+			items += s.items
+		}
+	}
+	return float64(items) / window.Seconds()
+}
+
+// percentiles는 최근 latencyWindow 구간의 p50/p99(초)를 반환합니다
+func (d *Dashboard) percentiles() (p50, p99 float64) {
+	latencies := make([]float64, len(d.recent))
+	for i, s := range d.recent {
+		latencies[i] = s.latency
+	}
+	stats := runner.ComputeLatencyStats(latencies)
+	return stats.P50, stats.P99
+}
+
+// remaining은 최근 처리량 기준 남은 예상 시간을 반환합니다 (계산할 수 없으면 음수)
+func (d *Dashboard) remaining(rps float64) time.Duration {
+	left := d.total - d.done
+	if left <= 0 {
+		return 0
+	}
+	if rps <= 0 {
+		return -1
+	}
+	return time.Duration(float64(left) / rps * float64(time.Second))
+}
+
+// errorSummary는 상태 코드별 오류 수를 "503=4 transport=2" 형식으로 반환합니다
+func (d *Dashboard) errorSummary(sep string) string {
+	if len(d.errors) == 0 {
+		return "none"
+	}
+	keys := make([]string, 0, len(d.errors))
+	for k := range d.errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%d", k, d.errors[k])
+	}
+	return strings.Join(parts, sep)
+}
+
+// sparkline은 최근 sparkWidth초의 초당 처리량을 막대 문자로 반환합니다
+// 아직 끝나지 않은 현재 초는 제외합니다
+func (d *Dashboard) sparkline(now time.Time) string {
+	current := int(now.Sub(d.start) / time.Second)
+	end := current
+	if end > len(d.perSec) {
+		end = len(d.perSec)
+	}
+	begin := end - sparkWidth
+	if begin < 0 {
+		begin = 0
+	}
+	values := d.perSec[begin:end]
+
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if max > 0 {
+			idx = v * (len(sparkChars) - 1) / max
+		}
+		b.WriteRune(sparkChars[idx])
+	}
+	return b.String()
+}
+
+// renderFrame은 이전 화면을 지우고 여러 줄 화면을 다시 그립니다
+func (d *Dashboard) renderFrame(now time.Time) {
+	rps := d.rate(now)
+	p50, p99 := d.percentiles()
+
+	pct := 0.0
+	if d.total > 0 {
+		pct = float64(d.done) / float64(d.total)
+	}
+	filled := int(pct * barWidth)
+	if filled > barWidth {
+		filled = barWidth
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s  [%s%s] %5.1f%%  %d/%d items",
+			d.title, strings.Repeat("#", filled), strings.Repeat("-", barWidth-filled), pct*100, d.done, d.total),
+		fmt.Sprintf("elapsed %s  remaining %s  rps %.1f  p50 %.2fms  p99 %.2fms",
+			formatDuration(now.Sub(d.start)), formatDuration(d.remaining(rps)), rps, p50*1000, p99*1000),
+		"errors " + d.errorSummary("  "),
+		"rps/s  " + d.sparkline(now),
+	}
+
+	var b strings.Builder
+	if d.lines > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", d.lines)
+	}
+	for _, line := range lines {
+		b.WriteString("\r\x1b[2K")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	io.WriteString(d.w, b.String())
+	d.lines = len(lines)
+}
+
+// renderLine은 한 줄 간격 보고를 출력합니다
+func (d *Dashboard) renderLine(now time.Time) {
+	rps := d.rate(now)
+	p50, p99 := d.percentiles()
+
+	pct := 0.0
+	if d.total > 0 {
+		pct = float64(d.done) / float64(d.total) * 100
+	}
+	fmt.Fprintf(d.w, "[%s] %s %d/%d items (%.1f%%) rps=%.1f p50=%.2fms p99=%.2fms remaining=%s errors=%s\n",
+		formatDuration(now.Sub(d.start)), d.title, d.done, d.total, pct, rps, p50*1000, p99*1000,
+		formatDuration(d.remaining(rps)), d.errorSummary(","))
+}
+
+// formatDuration은 시간을 "mm:ss"(1시간 이상이면 "h:mm:ss") 형식으로 반환합니다
+func formatDuration(dur time.Duration) string {
+	if dur < 0 {
+		return "--:--"
+	}
+	total := int(dur.Round(time.Second) / time.Second)
+	h, m, s := total/3600, total/60%60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
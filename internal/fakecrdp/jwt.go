@@ -0,0 +1,75 @@
+package fakecrdp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// jwtHeader는 HS256 JWT 헤더입니다
+const jwtHeader = `{"alg":"HS256","typ":"JWT"}`
+
+// jwtClaims는 가짜 서버가 확인하는 JWT 클레임입니다
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+}
+
+// SignToken은 secret으로 서명한 HS256 JWT를 발급합니다 (테스트/데모용)
+// ttl이 0이면 만료 시간(exp)을 넣지 않습니다
+func SignToken(secret, subject string, ttl time.Duration) string {
+	now := time.Now()
+	c := jwtClaims{Subject: subject, IssuedAt: now.Unix()}
+	if ttl > 0 {
+		c.ExpiresAt = now.Add(ttl).Unix()
+	}
+	payload, _ := json.Marshal(c)
+	unsigned := b64(jwtHeader) + "." + b64(string(payload))
+	return unsigned + "." + sign(secret, unsigned)
+}
+
+// verifyToken은 서명과 유효 기간을 확인하고 subject를 반환합니다
+// secret이 비어 있으면 서명은 확인하지 않습니다
+func verifyToken(token, secret string, now time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed token")
+	}
+	if secret != "" && !hmac.Equal([]byte(parts[2]), []byte(sign(secret, parts[0]+"."+parts[1]))) {
+		return "", errors.New("invalid token signature")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed token payload: %w", err)
+	}
+	var c jwtClaims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return "", fmt.Errorf("malformed token claims: %w", err)
+	}
+	if c.ExpiresAt > 0 && now.Unix() >= c.ExpiresAt {
+		return "", errors.New("token expired")
+	}
+	if c.NotBefore > 0 && now.Unix() < c.NotBefore {
+		return "", errors.New("token not valid yet")
+	}
+	return c.Subject, nil
+}
+
+// sign은 HMAC-SHA256 서명을 base64url로 반환합니다
+func sign(secret, unsigned string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// b64는 base64url(패딩 없음) 인코딩입니다
+func b64(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
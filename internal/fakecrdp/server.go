@@ -0,0 +1,339 @@
+package fakecrdp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/big"
+	mrand "math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+)
+
+// Config는 가짜 CRDP 서버 설정입니다
+type Config struct {
+	// Policies가 비어 있으면 어떤 정책 이름이든 기본 설정으로 허용하고,
+	// 지정되어 있으면 목록에 없는 정책은 400으로 거부합니다
+	Policies []Policy
+
+	RequireJWT bool   // Authorization: Bearer 토큰이 없으면 401
+	JWTSecret  string // 설정하면 HS256 서명을 확인 (비어 있으면 형식과 유효 기간만 확인)
+
+	Latency     time.Duration // 응답 전 고정 지연
+	Jitter      time.Duration // 추가 무작위 지연 (0~Jitter)
+	ErrorRate   float64       // 무작위 오류 응답 비율 (0~1)
+	ErrorStatus int           // 주입할 오류 상태 코드 (0이면 503)
+
+	Seed int64 // 토큰 생성/오류 주입 난수 시드 (0이면 현재 시각)
+
+	// 정책별로 보관할 최대 토큰 수 (0이면 DefaultMaxTokens)
+	// 넘으면 가장 오래 전에 발급한 토큰부터 삭제하므로 그 토큰의 reveal은 400으로 실패하고,
+	// 결정적 정책이라도 같은 입력에 새 토큰이 발급됩니다
+	MaxTokens int
+}
+
+// DefaultMaxTokens는 Config.MaxTokens를 지정하지 않았을 때 정책별로 보관하는 최대 토큰 수입니다
+const DefaultMaxTokens = 100000
+
+// Server는 /v1/protect, /v1/reveal, /v1/protectbulk, /v1/revealbulk를 구현하는 메모리 기반 가짜 CRDP 서버입니다
+// http.Handler이므로 httptest.NewServer나 http.Server에 그대로 사용할 수 있습니다
+type Server struct {
+	cfg    Config
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	rnd      *mrand.Rand
+	vaults   map[string]*vault
+	requests map[string]int
+}
+
+// New는 새로운 가짜 CRDP 서버를 생성합니다
+func New(cfg Config) *Server {
+	if cfg.ErrorStatus == 0 {
+		cfg.ErrorStatus = http.StatusServiceUnavailable
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	s := &Server{
+		cfg:      cfg,
+		logger:   logging.Discard(),
+		rnd:      mrand.New(mrand.NewSource(seed)),
+		vaults:   make(map[string]*vault),
+		requests: make(map[string]int),
+	}
+	for _, p := range cfg.Policies {
+		s.vaults[p.Name] = newVault(p, s.rnd.Int63(), cfg.MaxTokens)
+	}
+	return s
+}
+
+// NewTestServer는 가짜 CRDP 서버를 httptest 서버로 시작합니다 (사용 후 Close 필요)
+func NewTestServer(cfg Config) *httptest.Server {
+	return httptest.NewServer(New(cfg))
+}
+
+// NewTestTLSServer는 가짜 CRDP 서버를 자체 서명 인증서의 HTTPS httptest 서버로 시작합니다
+func NewTestTLSServer(cfg Config) *httptest.Server {
+	return httptest.NewTLSServer(New(cfg))
+}
+
+// SetLogger는 요청 로그를 기록할 로거를 설정합니다
+func (s *Server) SetLogger(l *slog.Logger) {
+	s.logger = l
+}
+
+// Requests는 엔드포인트별 수신 요청 수를 반환합니다
+func (s *Server) Requests() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]int, len(s.requests))
+	for k, v := range s.requests {
+		out[k] = v
+	}
+	return out
+}
+
+// request는 네 엔드포인트가 공통으로 받는 요청 본문입니다
+type request struct {
+	Policy             string   `json:"protection_policy_name"`
+	Data               string   `json:"data"`
+	ProtectedData      string   `json:"protected_data"`
+	DataArray          []string `json:"data_array"`
+	ProtectedDataArray []struct {
+		ProtectedData   string `json:"protected_data"`
+		ExternalVersion string `json:"external_version"`
+	} `json:"protected_data_array"`
+	Username        string `json:"username"`
+	ExternalVersion string `json:"external_version"`
+}
+
+// ServeHTTP는 http.Handler 구현입니다
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	status := s.serve(w, r)
+	s.logger.Debug("request handled", "method", r.Method, "path", r.URL.Path, "status", status,
+		"request_id", r.Header.Get("X-Request-ID"), "elapsed", time.Since(start))
+}
+
+// serve는 요청을 처리하고 응답 상태 코드를 반환합니다
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) int {
+	switch r.URL.Path {
+	case "/v1/protect", "/v1/reveal", "/v1/protectbulk", "/v1/revealbulk":
+	default:
+		return writeError(w, http.StatusNotFound, "not found")
+	}
+	if r.Method != http.MethodPost {
+		return writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+
+	s.mu.Lock()
+	s.requests[r.URL.Path]++
+	delay := s.cfg.Latency
+	if s.cfg.Jitter > 0 {
+		delay += time.Duration(s.rnd.Int63n(int64(s.cfg.Jitter)))
+	}
+	inject := s.cfg.ErrorRate > 0 && s.rnd.Float64() < s.cfg.ErrorRate
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return 0
+		}
+	}
+	if inject {
+		return writeError(w, s.cfg.ErrorStatus, "injected failure")
+	}
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+	}
+
+	user, err := s.authenticate(r)
+	if err != nil {
+		return writeError(w, http.StatusUnauthorized, err.Error())
+	}
+	if user == "" {
+		user = req.Username
+	}
+
+	v, ok := s.vault(req.Policy)
+	if !ok {
+		return writeError(w, http.StatusBadRequest, fmt.Sprintf("protection policy %q not found", req.Policy))
+	}
+
+	switch r.URL.Path {
+	case "/v1/protect":
+		return s.protect(w, v, req)
+	case "/v1/reveal":
+		return s.reveal(w, v, req, user)
+	case "/v1/protectbulk":
+		return s.protectBulk(w, v, req)
+	default:
+		return s.revealBulk(w, v, req, user)
+	}
+}
+
+// authenticate는 Authorization 헤더의 JWT를 확인하고 subject를 반환합니다
+func (s *Server) authenticate(r *http.Request) (string, error) {
+	auth := r.Header.Get("Authorization")
+	if auth == "" {
+		if s.cfg.RequireJWT {
+			return "", fmt.Errorf("missing bearer token")
+		}
+		return "", nil
+	}
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	if !ok {
+		return "", fmt.Errorf("unsupported authorization scheme")
+	}
+	return verifyToken(token, s.cfg.JWTSecret, time.Now())
+}
+
+// vault는 정책 이름에 해당하는 저장소를 반환합니다 (정책 목록이 비어 있으면 새로 생성)
+func (s *Server) vault(name string) (*vault, bool) {
+	if name == "" {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.vaults[name]; ok {
+		return v, true
+	}
+	if len(s.cfg.Policies) > 0 {
+		return nil, false
+	}
+	v := newVault(Policy{Name: name}, s.rnd.Int63(), s.cfg.MaxTokens)
+	s.vaults[name] = v
+	return v, true
+}
+
+func (s *Server) protect(w http.ResponseWriter, v *vault, req request) int {
+	token, err := v.protect(req.Data)
+	if err != nil {
+		return writeError(w, http.StatusBadRequest, err.Error())
+	}
+	resp := map[string]interface{}{"protected_data": token}
+	if ev := v.policy.ExternalVersion; ev != "" {
+		resp["external_version"] = ev
+	}
+	return writeJSON(w, http.StatusOK, resp)
+}
+
+// checkVersion은 외부 버전을 쓰는 정책에서 reveal 요청의 external_version을 확인합니다
+func checkVersion(v *vault, version string) error {
+	switch want := v.policy.ExternalVersion; {
+	case want == "":
+		return nil
+	case version == "":
+		return fmt.Errorf("external_version is required for policy %q", v.policy.Name)
+	case version != want:
+		return fmt.Errorf("external_version %q does not match policy %q", version, v.policy.Name)
+	}
+	return nil
+}
+
+func (s *Server) reveal(w http.ResponseWriter, v *vault, req request, user string) int {
+	if err := checkVersion(v, req.ExternalVersion); err != nil {
+		return writeError(w, http.StatusBadRequest, err.Error())
+	}
+	data, access, err := v.reveal(req.ProtectedData, user)
+	if err != nil {
+		return writeError(w, http.StatusBadRequest, err.Error())
+	}
+	if access == AccessDenied {
+		return writeError(w, http.StatusForbidden, fmt.Sprintf("user %q is not allowed to reveal with policy %q", user, v.policy.Name))
+	}
+	return writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func (s *Server) protectBulk(w http.ResponseWriter, v *vault, req request) int {
+	items := make([]map[string]interface{}, 0, len(req.DataArray))
+	for _, data := range req.DataArray {
+		token, err := v.protect(data)
+		if err != nil {
+			return writeError(w, http.StatusBadRequest, err.Error())
+		}
+		item := map[string]interface{}{"protected_data": token}
+		if ev := v.policy.ExternalVersion; ev != "" {
+			item["external_version"] = ev
+		}
+		items = append(items, item)
+	}
+	return writeJSON(w, http.StatusOK, map[string]interface{}{"status": "Success", "protected_data_array": items})
+}
+
+func (s *Server) revealBulk(w http.ResponseWriter, v *vault, req request, user string) int {
+	items := make([]map[string]interface{}, 0, len(req.ProtectedDataArray))
+	for _, pd := range req.ProtectedDataArray {
+		if err := checkVersion(v, pd.ExternalVersion); err != nil {
+			return writeError(w, http.StatusBadRequest, err.Error())
+		}
+		data, access, err := v.reveal(pd.ProtectedData, user)
+		if err != nil {
+			return writeError(w, http.StatusBadRequest, err.Error())
+		}
+		if access == AccessDenied {
+			return writeError(w, http.StatusForbidden, fmt.Sprintf("user %q is not allowed to reveal with policy %q", user, v.policy.Name))
+		}
+		items = append(items, map[string]interface{}{"data": data})
+	}
+	return writeJSON(w, http.StatusOK, map[string]interface{}{"status": "Success", "data_array": items})
+}
+
+// writeJSON은 JSON 응답을 쓰고 상태 코드를 반환합니다
+func writeJSON(w http.ResponseWriter, status int, v interface{}) int {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+	return status
+}
+
+// writeError는 {"status": "Error", "error": msg} 응답을 씁니다
+func writeError(w http.ResponseWriter, status int, msg string) int {
+	return writeJSON(w, status, map[string]interface{}{"status": "Error", "error": msg})
+}
+
+// SelfSignedCertificate는 hosts(IP 또는 DNS 이름)에 대한 임시 자체 서명 인증서를 생성합니다
+func SelfSignedCertificate(hosts ...string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate key: %w", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "fake-crdp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else if h != "" {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
@@ -0,0 +1,190 @@
+package fakecrdp
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+)
+
+// Access는 reveal 시 사용자에게 허용되는 결과입니다
+type Access string
+
+const (
+	AccessPlain  Access = "plain"  // 원본 데이터
+	AccessMasked Access = "masked" // 마지막 MaskKeep자만 남기고 MaskChar로 가린 데이터
+	AccessDenied Access = "denied" // 403 응답
+)
+
+// Policy는 보호 정책 설정입니다
+type Policy struct {
+	Name string `yaml:"name"`
+	// 토큰 앞/뒤에 원본을 그대로 남길 글자 수
+	PreservePrefix int `yaml:"preserve_prefix"`
+	PreserveSuffix int `yaml:"preserve_suffix"`
+	// 같은 입력에 항상 같은 토큰을 반환할지 여부 (false이면 protect마다 새 토큰)
+	Randomized bool `yaml:"randomized"`
+	// 사용자별 reveal 권한 (없는 사용자는 DefaultAccess, 비어 있으면 plain)
+	Users         map[string]Access `yaml:"users"`
+	DefaultAccess Access            `yaml:"default_access"`
+	MaskChar      string            `yaml:"mask_char"` // 기본 "*"
+	MaskKeep      int               `yaml:"mask_keep"` // masked reveal에서 남길 끝 글자 수 (기본 4)
+	// 토큰 밖에 보관하는 키 버전 (설정하면 protect 응답에 포함하고 reveal 요청에 같은 값을 요구)
+	ExternalVersion string `yaml:"external_version"`
+}
+
+// errUnknownToken은 reveal 대상 토큰이 이 정책으로 발급되지 않았을 때 반환됩니다
+var errUnknownToken = errors.New("protected data was not issued by this policy")
+
+// vault는 한 정책의 가역 토큰화 저장소입니다 (메모리)
+type vault struct {
+	policy Policy
+
+	mu      sync.Mutex
+	rnd     *rand.Rand
+	tokens  map[string]string // 토큰 -> 원본
+	byValue map[string]string // 원본 -> 토큰 (결정적 정책)
+
+	// 발급 순서 (maxTokens개를 넘으면 가장 오래된 토큰부터 삭제하는 링 버퍼)
+	maxTokens int
+	order     []string
+	next      int
+}
+
+// newVault는 정책의 기본값을 채워 저장소를 생성합니다 (maxTokens가 0 이하이면 DefaultMaxTokens)
+func newVault(p Policy, seed int64, maxTokens int) *vault {
+	if p.DefaultAccess == "" {
+		p.DefaultAccess = AccessPlain
+	}
+	if p.MaskChar == "" {
+		p.MaskChar = "*"
+	}
+	if p.MaskKeep == 0 {
+		p.MaskKeep = 4
+	}
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+	return &vault{
+		policy:    p,
+		rnd:       rand.New(rand.NewSource(seed)),
+		tokens:    make(map[string]string),
+		byValue:   make(map[string]string),
+		maxTokens: maxTokens,
+	}
+}
+
+// protect는 데이터를 형식을 유지한 토큰으로 바꿉니다
+// 숫자는 숫자로, 영문자는 같은 대소문자의 영문자로 바꾸고 그 외 문자와 보존 구간은 그대로 둡니다
+func (v *vault) protect(data string) (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.policy.Randomized {
+		if token, ok := v.byValue[data]; ok {
+			return token, nil
+		}
+	}
+	if !hasReplaceable(data, v.policy.PreservePrefix, v.policy.PreserveSuffix) {
+		return "", fmt.Errorf("data has no characters to tokenize outside the preserved prefix/suffix")
+	}
+
+	// 원본과 같거나 이미 발급된 토큰이면 다시 생성
+	for attempt := 0; attempt < 100; attempt++ {
+		token := v.tokenize(data)
+		if token == data {
+			continue
+		}
+		if _, taken := v.tokens[token]; taken {
+			continue
+		}
+		v.store(token, data)
+		return token, nil
+	}
+	return "", fmt.Errorf("token space exhausted for data of length %d", len(data))
+}
+
+// store는 토큰을 저장하고, 저장 개수가 maxTokens를 넘으면 가장 오래된 토큰을 삭제합니다
+func (v *vault) store(token, data string) {
+	if len(v.order) < v.maxTokens {
+		v.order = append(v.order, token)
+	} else {
+		old := v.order[v.next]
+		if d, ok := v.tokens[old]; ok && v.byValue[d] == old {
+			delete(v.byValue, d)
+		}
+		delete(v.tokens, old)
+		v.order[v.next] = token
+		v.next = (v.next + 1) % v.maxTokens
+	}
+	v.tokens[token] = data
+	if !v.policy.Randomized {
+		v.byValue[data] = token
+	}
+}
+
+// tokenize는 보존 구간을 제외한 문자를 같은 종류의 무작위 문자로 바꿉니다
+func (v *vault) tokenize(data string) string {
+	runes := []rune(data)
+	for i, r := range runes {
+		if i < v.policy.PreservePrefix || i >= len(runes)-v.policy.PreserveSuffix {
+			continue
+		}
+		switch {
+		case r >= '0' && r <= '9':
+			runes[i] = rune('0' + v.rnd.Intn(10))
+		case r >= 'a' && r <= 'z':
+			runes[i] = rune('a' + v.rnd.Intn(26))
+		case r >= 'A' && r <= 'Z':
+			runes[i] = rune('A' + v.rnd.Intn(26))
+		}
+	}
+	return string(runes)
+}
+
+// hasReplaceable은 보존 구간 밖에 바꿀 수 있는 문자가 있는지 확인합니다
+func hasReplaceable(data string, prefix, suffix int) bool {
+	runes := []rune(data)
+	for i, r := range runes {
+		if i < prefix || i >= len(runes)-suffix {
+			continue
+		}
+		if (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return true
+		}
+	}
+	return false
+}
+
+// reveal은 토큰을 원본으로 되돌립니다 (사용자 권한에 따라 가리거나 거부)
+func (v *vault) reveal(token, user string) (string, Access, error) {
+	v.mu.Lock()
+	data, ok := v.tokens[token]
+	v.mu.Unlock()
+	if !ok {
+		return "", "", errUnknownToken
+	}
+
+	access := v.policy.DefaultAccess
+	if a, ok := v.policy.Users[user]; ok {
+		access = a
+	}
+	switch access {
+	case AccessDenied:
+		return "", access, nil
+	case AccessMasked:
+		return v.mask(data), access, nil
+	}
+	return data, AccessPlain, nil
+}
+
+// mask는 마지막 MaskKeep자만 남기고 가립니다
+func (v *vault) mask(data string) string {
+	runes := []rune(data)
+	keep := v.policy.MaskKeep
+	if keep > len(runes) {
+		keep = len(runes)
+	}
+	return strings.Repeat(v.policy.MaskChar, len(runes)-keep) + string(runes[len(runes)-keep:])
+}
@@ -0,0 +1,58 @@
+package fakecrdp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestVaultEvictsOldestTokens(t *testing.T) {
+	v := newVault(Policy{Name: "P"}, 1, 3)
+	tokens := make([]string, 5)
+	for i := range tokens {
+		token, err := v.protect(fmt.Sprintf("10000%d", i))
+		if err != nil {
+			t.Fatalf("protect %d: %v", i, err)
+		}
+		tokens[i] = token
+	}
+	if len(v.tokens) != 3 || len(v.byValue) != 3 {
+		t.Fatalf("stored %d tokens / %d values, want 3", len(v.tokens), len(v.byValue))
+	}
+	for i, token := range tokens {
+		_, _, err := v.reveal(token, "")
+		if evicted := i < 2; evicted != errors.Is(err, errUnknownToken) {
+			t.Errorf("token %d: reveal error %v, evicted %v", i, err, evicted)
+		}
+	}
+
+	// 삭제된 값은 새 토큰으로, 남아 있는 값은 같은 토큰으로 발급
+	again, err := v.protect("100004")
+	if err != nil || again != tokens[4] {
+		t.Errorf("protect kept value = %q, %v; want %q", again, err, tokens[4])
+	}
+	if _, err := v.protect("100000"); err != nil {
+		t.Errorf("protect evicted value: %v", err)
+	}
+	if len(v.tokens) != 3 {
+		t.Errorf("stored %d tokens after re-protect, want 3", len(v.tokens))
+	}
+}
+
+func TestVaultRandomizedKeepsNoValueIndex(t *testing.T) {
+	v := newVault(Policy{Name: "P", Randomized: true}, 1, 2)
+	for i := 0; i < 4; i++ {
+		if _, err := v.protect("123456"); err != nil {
+			t.Fatalf("protect: %v", err)
+		}
+	}
+	if len(v.tokens) != 2 || len(v.byValue) != 0 {
+		t.Errorf("stored %d tokens / %d values, want 2 / 0", len(v.tokens), len(v.byValue))
+	}
+}
+
+func TestVaultDefaultMaxTokens(t *testing.T) {
+	if v := newVault(Policy{Name: "P"}, 1, 0); v.maxTokens != DefaultMaxTokens {
+		t.Errorf("maxTokens = %d, want %d", v.maxTokens, DefaultMaxTokens)
+	}
+}
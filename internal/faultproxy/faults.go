@@ -0,0 +1,215 @@
+package faultproxy
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// 주입하는 장애 종류
+const (
+	FaultLatency  = "latency"   // 응답 전 지연
+	FaultReset    = "reset"     // 응답 없이 연결 끊기 (TCP RST)
+	FaultStatus   = "status"    // 서버로 전달하지 않고 오류 상태 코드 응답
+	FaultTruncate = "truncate"  // Content-Length보다 짧은 본문을 보내고 연결 끊기
+	FaultSlowRead = "slow_read" // 응답 본문을 느린 속도로 전송
+)
+
+// Latency는 지연 시간 분포입니다
+// fixed: base, uniform: base + U(0, spread), normal: N(base, spread) (0 미만은 0),
+// exponential: base + 평균 spread인 지수 분포
+type Latency struct {
+	Distribution string `yaml:"distribution"`
+	BaseMs       int    `yaml:"base_ms"`
+	SpreadMs     int    `yaml:"spread_ms"`
+}
+
+// Faults는 요청마다 적용할 장애 주입 설정입니다 (비율은 0~1)
+type Faults struct {
+	Latency       Latency `yaml:"latency"`
+	ResetRate     float64 `yaml:"reset_rate"`
+	ErrorRate     float64 `yaml:"error_rate"`
+	ErrorStatuses []int   `yaml:"error_statuses"` // 무작위로 하나를 골라 응답 (기본 [503])
+	TruncateRate  float64 `yaml:"truncate_rate"`
+	SlowReadRate  float64 `yaml:"slow_read_rate"`
+	SlowReadBps   int     `yaml:"slow_read_bps"` // 느린 전송 속도 (바이트/초, 기본 1024)
+}
+
+// Stage는 프록시 시작 후 [FromS, ToS) 초 동안 기본 설정 대신 적용할 장애 설정입니다
+type Stage struct {
+	Name   string `yaml:"name"`
+	FromS  int    `yaml:"from_s"`
+	ToS    int    `yaml:"to_s"` // 0이면 끝없이
+	Faults Faults `yaml:"faults"`
+}
+
+// Config는 장애 주입 프록시 설정입니다
+type Config struct {
+	Target   string  `yaml:"target"`   // 전달 대상 CRDP 주소 (예: https://192.168.0.231:32082)
+	Faults   Faults  `yaml:"faults"`   // 일정에 해당하지 않을 때의 기본 장애 설정
+	Schedule []Stage `yaml:"schedule"` // 시간대별 장애 설정
+	CycleS   int     `yaml:"cycle_s"`  // 0보다 크면 일정을 이 주기(초)로 반복
+	Seed     int64   `yaml:"seed"`     // 난수 시드 (0이면 현재 시각)
+}
+
+// Validate는 설정 값을 확인합니다
+func (c *Config) Validate() error {
+	if c.Target == "" {
+		return fmt.Errorf("target: required")
+	}
+	if !strings.HasPrefix(c.Target, "http://") && !strings.HasPrefix(c.Target, "https://") {
+		return fmt.Errorf("target: must start with http:// or https:// (got %q)", c.Target)
+	}
+	if err := c.Faults.validate("faults"); err != nil {
+		return err
+	}
+	for i, s := range c.Schedule {
+		prefix := fmt.Sprintf("schedule[%d]", i)
+		if s.FromS < 0 || s.ToS < 0 || (s.ToS > 0 && s.ToS <= s.FromS) {
+			return fmt.Errorf("%s: to_s must be greater than from_s (got %d..%d)", prefix, s.FromS, s.ToS)
+		}
+		if err := s.Faults.validate(prefix + ".faults"); err != nil {
+			return err
+		}
+	}
+	if c.CycleS < 0 {
+		return fmt.Errorf("cycle_s: must be >= 0")
+	}
+	return nil
+}
+
+func (f *Faults) validate(prefix string) error {
+	switch f.Latency.Distribution {
+	case "", "fixed", "uniform", "normal", "exponential":
+	default:
+		return fmt.Errorf("%s.latency.distribution: invalid value %q (expected fixed, uniform, normal or exponential)", prefix, f.Latency.Distribution)
+	}
+	if f.Latency.BaseMs < 0 || f.Latency.SpreadMs < 0 {
+		return fmt.Errorf("%s.latency: base_ms and spread_ms must be >= 0", prefix)
+	}
+	rates := []struct {
+		name string
+		v    float64
+	}{{"reset_rate", f.ResetRate}, {"error_rate", f.ErrorRate}, {"truncate_rate", f.TruncateRate}, {"slow_read_rate", f.SlowReadRate}}
+	for _, r := range rates {
+		if r.v < 0 || r.v > 1 {
+			return fmt.Errorf("%s.%s: must be between 0 and 1", prefix, r.name)
+		}
+	}
+	if f.ResetRate+f.ErrorRate > 1 {
+		return fmt.Errorf("%s: reset_rate + error_rate must be <= 1", prefix)
+	}
+	if f.TruncateRate+f.SlowReadRate > 1 {
+		return fmt.Errorf("%s: truncate_rate + slow_read_rate must be <= 1", prefix)
+	}
+	for _, s := range f.ErrorStatuses {
+		if s < 400 || s > 599 {
+			return fmt.Errorf("%s.error_statuses: %d is not a 4xx or 5xx status code", prefix, s)
+		}
+	}
+	if f.SlowReadBps < 0 {
+		return fmt.Errorf("%s.slow_read_bps: must be >= 0", prefix)
+	}
+	return nil
+}
+
+// stageAt는 시작 후 elapsed 시점에 적용할 장애 설정과 단계 이름을 반환합니다
+func (c *Config) stageAt(elapsed time.Duration) (Faults, string) {
+	if c.CycleS > 0 {
+		elapsed %= time.Duration(c.CycleS) * time.Second
+	}
+	for i, s := range c.Schedule {
+		from := time.Duration(s.FromS) * time.Second
+		to := time.Duration(s.ToS) * time.Second
+		if elapsed >= from && (s.ToS == 0 || elapsed < to) {
+			name := s.Name
+			if name == "" {
+				name = fmt.Sprintf("stage%d", i+1)
+			}
+			return s.Faults, name
+		}
+	}
+	return c.Faults, "default"
+}
+
+// plan은 한 요청에 주입할 장애 결정입니다
+type plan struct {
+	stage    string
+	delay    time.Duration
+	reset    bool
+	status   int // 0이 아니면 이 상태 코드로 응답
+	truncate bool
+	slowBps  int // 0이 아니면 느린 전송
+}
+
+// faults는 plan에 포함된 장애 종류 목록입니다 (로그용)
+func (p plan) faults() []string {
+	var out []string
+	if p.delay > 0 {
+		out = append(out, FaultLatency)
+	}
+	switch {
+	case p.reset:
+		out = append(out, FaultReset)
+	case p.status != 0:
+		out = append(out, FaultStatus)
+	case p.truncate:
+		out = append(out, FaultTruncate)
+	case p.slowBps != 0:
+		out = append(out, FaultSlowRead)
+	}
+	return out
+}
+
+// decide는 장애 설정에 따라 한 요청의 주입 내용을 무작위로 정합니다
+// 연결 끊기와 오류 응답은 서로 배타적이며, 본문 자르기와 느린 전송은 서버로 전달한 경우에만 적용됩니다
+func (f Faults) decide(rnd *rand.Rand) plan {
+	var p plan
+	p.delay = f.Latency.sample(rnd)
+
+	roll := rnd.Float64()
+	switch {
+	case roll < f.ResetRate:
+		p.reset = true
+		return p
+	case roll < f.ResetRate+f.ErrorRate:
+		statuses := f.ErrorStatuses
+		if len(statuses) == 0 {
+			statuses = []int{503}
+		}
+		p.status = statuses[rnd.Intn(len(statuses))]
+		return p
+	}
+
+	roll = rnd.Float64()
+	switch {
+	case roll < f.TruncateRate:
+		p.truncate = true
+	case roll < f.TruncateRate+f.SlowReadRate:
+		p.slowBps = f.SlowReadBps
+		if p.slowBps == 0 {
+			p.slowBps = 1024
+		}
+	}
+	return p
+}
+
+// sample은 분포에서 지연 시간 하나를 뽑습니다
+func (l Latency) sample(rnd *rand.Rand) time.Duration {
+	base := float64(l.BaseMs)
+	spread := float64(l.SpreadMs)
+	var ms float64
+	switch l.Distribution {
+	case "uniform":
+		ms = base + rnd.Float64()*spread
+	case "normal":
+		ms = math.Max(0, base+rnd.NormFloat64()*spread)
+	case "exponential":
+		ms = base + rnd.ExpFloat64()*spread
+	default:
+		ms = base
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
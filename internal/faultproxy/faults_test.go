@@ -0,0 +1,138 @@
+package faultproxy
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStageAt(t *testing.T) {
+	cfg := Config{
+		Faults: Faults{ErrorRate: 0.1},
+		Schedule: []Stage{
+			{Name: "warmup", FromS: 0, ToS: 10, Faults: Faults{ResetRate: 0.2}},
+			{FromS: 10, ToS: 20, Faults: Faults{ErrorRate: 1}},
+			{Name: "tail", FromS: 30, Faults: Faults{TruncateRate: 0.5}},
+		},
+	}
+	tests := []struct {
+		elapsed time.Duration
+		cycle   int
+		want    string
+	}{
+		{0, 0, "warmup"},
+		{9999 * time.Millisecond, 0, "warmup"},
+		{10 * time.Second, 0, "stage2"},
+		{25 * time.Second, 0, "default"},
+		{time.Hour, 0, "tail"},
+		{45 * time.Second, 40, "warmup"}, // 40초 주기로 5초 시점
+		{95 * time.Second, 40, "stage2"},
+	}
+	for _, tt := range tests {
+		cfg.CycleS = tt.cycle
+		if _, got := cfg.stageAt(tt.elapsed); got != tt.want {
+			t.Errorf("stageAt(%v) with cycle %d = %q, want %q", tt.elapsed, tt.cycle, got, tt.want)
+		}
+	}
+	if f, _ := cfg.stageAt(25 * time.Second); f.ErrorRate != 0.1 {
+		t.Errorf("default stage faults = %+v, want the top-level faults", f)
+	}
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name   string
+		faults Faults
+		want   plan
+	}{
+		{"none", Faults{}, plan{}},
+		{"reset", Faults{ResetRate: 1}, plan{reset: true}},
+		{"default status", Faults{ErrorRate: 1}, plan{status: 503}},
+		{"configured status", Faults{ErrorRate: 1, ErrorStatuses: []int{429}}, plan{status: 429}},
+		{"truncate", Faults{TruncateRate: 1}, plan{truncate: true}},
+		{"default slow read", Faults{SlowReadRate: 1}, plan{slowBps: 1024}},
+		{"slow read", Faults{SlowReadRate: 1, SlowReadBps: 64}, plan{slowBps: 64}},
+		{"reset wins over truncate", Faults{ResetRate: 1, TruncateRate: 1}, plan{reset: true}},
+		{"fixed latency", Faults{Latency: Latency{Distribution: "fixed", BaseMs: 20}}, plan{delay: 20 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.faults.decide(rand.New(rand.NewSource(1)))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decide = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecideRates(t *testing.T) {
+	f := Faults{ResetRate: 0.1, ErrorRate: 0.2, ErrorStatuses: []int{500, 503}, TruncateRate: 0.3}
+	rnd := rand.New(rand.NewSource(42))
+	const n = 20000
+	counts := make(map[string]int)
+	statuses := make(map[int]int)
+	for i := 0; i < n; i++ {
+		p := f.decide(rnd)
+		for _, name := range p.faults() {
+			counts[name]++
+		}
+		if p.status != 0 {
+			statuses[p.status]++
+		}
+	}
+	// 자르기는 서버로 전달한 요청(70%)의 30%
+	want := map[string]float64{FaultReset: 0.1, FaultStatus: 0.2, FaultTruncate: 0.21}
+	for name, rate := range want {
+		if got := float64(counts[name]) / n; got < rate-0.02 || got > rate+0.02 {
+			t.Errorf("%s rate = %.3f, want about %.2f", name, got, rate)
+		}
+	}
+	if statuses[500] == 0 || statuses[503] == 0 || len(statuses) != 2 {
+		t.Errorf("injected statuses = %v, want both 500 and 503", statuses)
+	}
+
+	// 같은 시드이면 같은 순서로 주입
+	a, b := rand.New(rand.NewSource(7)), rand.New(rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		if pa, pb := f.decide(a), f.decide(b); !reflect.DeepEqual(pa, pb) {
+			t.Fatalf("draw %d differs with the same seed: %+v vs %+v", i, pa, pb)
+		}
+	}
+}
+
+func TestLatencySample(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if d := (Latency{Distribution: "uniform", BaseMs: 10, SpreadMs: 5}).sample(rnd); d < 10*time.Millisecond || d > 15*time.Millisecond {
+			t.Fatalf("uniform sample = %v, want within [10ms, 15ms]", d)
+		}
+		if d := (Latency{Distribution: "normal", BaseMs: 0, SpreadMs: 50}).sample(rnd); d < 0 {
+			t.Fatalf("normal sample = %v, want >= 0", d)
+		}
+		if d := (Latency{Distribution: "exponential", BaseMs: 5, SpreadMs: 1}).sample(rnd); d < 5*time.Millisecond {
+			t.Fatalf("exponential sample = %v, want >= base", d)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		ok   bool
+	}{
+		{"minimal", Config{Target: "http://127.0.0.1:32082"}, true},
+		{"no scheme", Config{Target: "127.0.0.1:32082"}, false},
+		{"rates over 1", Config{Target: "http://x", Faults: Faults{ResetRate: 0.6, ErrorRate: 0.5}}, false},
+		{"bad status", Config{Target: "http://x", Faults: Faults{ErrorStatuses: []int{200}}}, false},
+		{"bad distribution", Config{Target: "http://x", Faults: Faults{Latency: Latency{Distribution: "pareto"}}}, false},
+		{"empty stage", Config{Target: "http://x", Schedule: []Stage{{FromS: 10, ToS: 10}}}, false},
+		{"open-ended stage", Config{Target: "http://x", Schedule: []Stage{{FromS: 10}}}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%s: Validate() = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Package faultproxy는 CRDP 서버 앞에서 요청을 전달하며 지연, 연결 끊기, 오류 응답,
+// 잘린 본문, 느린 전송 같은 장애를 일정에 따라 주입하는 프록시를 제공합니다
+package faultproxy
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+)
+
+// hopHeaders는 프록시가 전달하지 않는 hop-by-hop 헤더입니다
+var hopHeaders = []string{"Connection", "Keep-Alive", "Proxy-Connection", "Te", "Trailer", "Transfer-Encoding", "Upgrade"}
+
+// Injection은 주입 기록 한 건입니다 (JSON Lines로 기록)
+type Injection struct {
+	Time           time.Time `json:"time"`
+	RequestID      string    `json:"request_id,omitempty"`
+	Traceparent    string    `json:"traceparent,omitempty"`
+	Method         string    `json:"method"`
+	Path           string    `json:"path"`
+	Stage          string    `json:"stage"`
+	Faults         []string  `json:"faults"`
+	DelayMs        float64   `json:"delay_ms,omitempty"`
+	Status         int       `json:"status,omitempty"`          // 주입한 오류 상태 코드
+	UpstreamStatus int       `json:"upstream_status,omitempty"` // 서버로 전달한 경우 서버 응답 상태 코드
+	SlowReadBps    int       `json:"slow_read_bps,omitempty"`
+}
+
+// Proxy는 장애 주입 리버스 프록시입니다 (http.Handler)
+type Proxy struct {
+	cfg    Config
+	target *url.URL
+	client *http.Client
+	logger *slog.Logger
+	start  time.Time
+
+	mu       sync.Mutex
+	rnd      *rand.Rand
+	log      *json.Encoder
+	requests int
+	counts   map[string]int
+}
+
+// New는 설정을 확인하고 프록시를 생성합니다
+// 대상 서버의 인증서는 CRDP 클라이언트와 같이 검증하지 않습니다 (자체 서명 인증서 허용)
+func New(cfg Config) (*Proxy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	target, err := url.Parse(strings.TrimRight(cfg.Target, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("target: %w", err)
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Proxy{
+		cfg:    cfg,
+		target: target,
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig:     &tls.Config{InsecureSkipVerify: true},
+				MaxIdleConnsPerHost: 100,
+			},
+		},
+		logger: logging.Discard(),
+		start:  time.Now(),
+		rnd:    rand.New(rand.NewSource(seed)),
+		counts: make(map[string]int),
+	}, nil
+}
+
+// SetLogger는 주입 내용을 기록할 로거를 설정합니다
+func (p *Proxy) SetLogger(l *slog.Logger) {
+	p.logger = l
+}
+
+// SetInjectionLog는 주입 기록을 JSON Lines로 쓸 대상을 설정합니다
+func (p *Proxy) SetInjectionLog(w io.Writer) {
+	p.log = json.NewEncoder(w)
+}
+
+// Counts는 전달받은 전체 요청 수와 장애 종류별 주입 횟수를 반환합니다
+func (p *Proxy) Counts() (int, map[string]int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make(map[string]int, len(p.counts))
+	for k, v := range p.counts {
+		out[k] = v
+	}
+	return p.requests, out
+}
+
+// ServeHTTP는 http.Handler 구현입니다
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	faults, stage := p.cfg.stageAt(time.Since(p.start))
+	pl := faults.decide(p.rnd)
+	p.requests++
+	p.mu.Unlock()
+
+	inj := Injection{
+		Time:        time.Now(),
+		RequestID:   r.Header.Get("X-Request-ID"),
+		Traceparent: r.Header.Get("traceparent"),
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		Stage:       stage,
+		Faults:      pl.faults(),
+	}
+	defer func() { p.record(inj) }()
+
+	if pl.delay > 0 {
+		inj.DelayMs = float64(pl.delay) / float64(time.Millisecond)
+		select {
+		case <-time.After(pl.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	switch {
+	case pl.reset:
+		resetConnection(w)
+		return
+	case pl.status != 0:
+		inj.Status = pl.status
+		writeStatus(w, pl.status)
+		return
+	}
+
+	resp, body, err := p.forward(r)
+	if err != nil {
+		p.logger.Warn("upstream request failed", "request_id", inj.RequestID, "path", r.URL.Path, "error", err)
+		inj.Faults = nil // 주입한 장애가 아니므로 기록하지 않음
+		if pl.delay > 0 {
+			inj.Faults = []string{FaultLatency}
+		}
+		http.Error(w, "upstream request failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	inj.UpstreamStatus = resp.StatusCode
+
+	header := w.Header()
+	for k, vs := range resp.Header {
+		header[k] = vs
+	}
+	for _, h := range hopHeaders {
+		header.Del(h)
+	}
+	header.Set("Content-Length", fmt.Sprint(len(body)))
+
+	switch {
+	case pl.truncate:
+		// 선언한 Content-Length보다 적게 쓰면 net/http가 응답 후 연결을 닫음
+		w.WriteHeader(resp.StatusCode)
+		w.Write(body[:len(body)/2])
+	case pl.slowBps != 0:
+		inj.SlowReadBps = pl.slowBps
+		w.WriteHeader(resp.StatusCode)
+		slowWrite(w, r, body, pl.slowBps)
+	default:
+		w.WriteHeader(resp.StatusCode)
+		w.Write(body)
+	}
+}
+
+// forward는 요청을 대상 서버로 전달하고 응답 본문을 모두 읽어 반환합니다
+func (p *Proxy) forward(r *http.Request) (*http.Response, []byte, error) {
+	out, err := http.NewRequestWithContext(r.Context(), r.Method, p.target.String()+r.URL.RequestURI(), r.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	out.Header = r.Header.Clone()
+	for _, h := range hopHeaders {
+		out.Header.Del(h)
+	}
+	out.ContentLength = r.ContentLength
+
+	resp, err := p.client.Do(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read upstream response: %w", err)
+	}
+	return resp, body, nil
+}
+
+// record는 주입한 장애를 집계하고 로그와 주입 기록에 남깁니다
+func (p *Proxy) record(inj Injection) {
+	if len(inj.Faults) == 0 {
+		return
+	}
+	p.mu.Lock()
+	for _, f := range inj.Faults {
+		p.counts[f]++
+	}
+	if p.log != nil {
+		if err := p.log.Encode(inj); err != nil {
+			p.logger.Warn("failed to write injection log", "error", err)
+		}
+	}
+	p.mu.Unlock()
+
+	attrs := []interface{}{"request_id", inj.RequestID, "method", inj.Method, "path", inj.Path,
+		"stage", inj.Stage, "faults", strings.Join(inj.Faults, ",")}
+	if inj.DelayMs > 0 {
+		attrs = append(attrs, "delay_ms", fmt.Sprintf("%.1f", inj.DelayMs))
+	}
+	if inj.Status != 0 {
+		attrs = append(attrs, "status", inj.Status)
+	}
+	if inj.UpstreamStatus != 0 {
+		attrs = append(attrs, "upstream_status", inj.UpstreamStatus)
+	}
+	if inj.SlowReadBps != 0 {
+		attrs = append(attrs, "slow_read_bps", inj.SlowReadBps)
+	}
+	p.logger.Info("fault injected", attrs...)
+}
+
+// resetConnection은 응답 없이 연결을 끊습니다 (가능하면 TCP RST)
+func resetConnection(w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	if tc, ok := conn.(*tls.Conn); ok {
+		conn = tc.NetConn()
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.SetLinger(0)
+	}
+	conn.Close()
+}
+
+// writeStatus는 CRDP 오류 형식의 응답을 씁니다 (429이면 Retry-After 포함)
+func writeStatus(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	if status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "1")
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"status": "Error", "error": "injected by fault proxy"})
+}
+
+// slowWrite는 본문을 bps 속도로 나누어 보냅니다 (100ms마다 bps/10 바이트)
+func slowWrite(w http.ResponseWriter, r *http.Request, body []byte, bps int) {
+	chunk := bps / 10
+	if chunk < 1 {
+		chunk = 1
+	}
+	flusher, _ := w.(http.Flusher)
+	rd := bytes.NewReader(body)
+	buf := make([]byte, chunk)
+	for {
+		n, _ := rd.Read(buf)
+		if n == 0 {
+			return
+		}
+		if _, err := w.Write(buf[:n]); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if rd.Len() == 0 {
+			return
+		}
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
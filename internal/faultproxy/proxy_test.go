@@ -0,0 +1,184 @@
+package faultproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+// startProxy는 가짜 CRDP 서버 앞에 faults를 주입하는 프록시를 시작하고 주입 기록 버퍼를 반환합니다
+func startProxy(t *testing.T, cfg Config) (*Proxy, *httptest.Server, *bytes.Buffer) {
+	t.Helper()
+	upstream := fakecrdp.NewTestServer(fakecrdp.Config{Seed: 1})
+	t.Cleanup(upstream.Close)
+	cfg.Target = upstream.URL
+	cfg.Seed = 1
+	p, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	var log bytes.Buffer
+	p.SetInjectionLog(&log)
+	srv := httptest.NewServer(p)
+	t.Cleanup(srv.Close)
+	return p, srv, &log
+}
+
+// protect는 프록시로 protect 요청을 보냅니다
+func protect(t *testing.T, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url+"/v1/protect",
+		strings.NewReader(`{"protection_policy_name":"P03","data":"1234567890123"}`))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "req-1")
+	c := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}, Timeout: 5 * time.Second}
+	return c.Do(req)
+}
+
+// injections는 진행 중인 요청이 끝나도록 프록시 서버를 닫은 뒤 주입 기록을 읽습니다
+func injections(t *testing.T, srv *httptest.Server, log *bytes.Buffer) []Injection {
+	t.Helper()
+	srv.Close()
+	var out []Injection
+	dec := json.NewDecoder(log)
+	for dec.More() {
+		var inj Injection
+		if err := dec.Decode(&inj); err != nil {
+			t.Fatalf("decode injection log: %v", err)
+		}
+		out = append(out, inj)
+	}
+	return out
+}
+
+func TestProxyPassThrough(t *testing.T) {
+	p, srv, log := startProxy(t, Config{})
+	resp, err := protect(t, srv.URL)
+	if err != nil {
+		t.Fatalf("protect: %v", err)
+	}
+	defer resp.Body.Close()
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || resp.StatusCode != 200 || body["protected_data"] == nil {
+		t.Fatalf("status %d, body %v, err %v, want a protect response", resp.StatusCode, body, err)
+	}
+	if inj := injections(t, srv, log); len(inj) != 0 {
+		t.Errorf("injection log = %+v, want empty", inj)
+	}
+	if n, counts := p.Counts(); n != 1 || len(counts) != 0 {
+		t.Errorf("Counts = %d, %v, want 1 request and no injections", n, counts)
+	}
+}
+
+func TestProxyStatus(t *testing.T) {
+	p, srv, log := startProxy(t, Config{
+		Schedule: []Stage{{Name: "throttle", FromS: 0, Faults: Faults{ErrorRate: 1, ErrorStatuses: []int{429}}}},
+	})
+	resp, err := protect(t, srv.URL)
+	if err != nil {
+		t.Fatalf("protect: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 429 || resp.Header.Get("Retry-After") != "1" {
+		t.Errorf("status %d, Retry-After %q, want 429 with Retry-After 1", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+	inj := injections(t, srv, log)
+	if _, counts := p.Counts(); counts[FaultStatus] != 1 {
+		t.Errorf("counts = %v, want one status injection", counts)
+	}
+	if len(inj) != 1 {
+		t.Fatalf("injection log has %d entries, want 1", len(inj))
+	}
+	got := inj[0]
+	if got.Stage != "throttle" || got.Status != 429 || got.UpstreamStatus != 0 || got.RequestID != "req-1" ||
+		got.Path != "/v1/protect" || strings.Join(got.Faults, ",") != FaultStatus {
+		t.Errorf("injection = %+v", got)
+	}
+}
+
+func TestProxyReset(t *testing.T) {
+	p, srv, log := startProxy(t, Config{Faults: Faults{ResetRate: 1}})
+	resp, err := protect(t, srv.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("protect succeeded with status %d, want a connection error", resp.StatusCode)
+	}
+	// 가로챈 연결은 서버 종료 시 기다리지 않으므로 기록될 때까지 기다림
+	deadline := time.Now().Add(5 * time.Second)
+	for _, counts := p.Counts(); counts[FaultReset] != 1; _, counts = p.Counts() {
+		if time.Now().After(deadline) {
+			t.Fatalf("counts = %v, want one reset", counts)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if inj := injections(t, srv, log); len(inj) != 1 || inj[0].Stage != "default" || strings.Join(inj[0].Faults, ",") != FaultReset {
+		t.Errorf("injection log = %+v, want one reset in the default stage", inj)
+	}
+}
+
+func TestProxyTruncate(t *testing.T) {
+	_, srv, log := startProxy(t, Config{Faults: Faults{TruncateRate: 1}})
+	resp, err := protect(t, srv.URL)
+	if err != nil {
+		t.Fatalf("protect: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err == nil {
+		t.Errorf("read %q without error, want a truncated body", body)
+	}
+	if int64(len(body)) >= resp.ContentLength {
+		t.Errorf("read %d bytes of %d, want fewer", len(body), resp.ContentLength)
+	}
+	if inj := injections(t, srv, log); len(inj) != 1 || inj[0].UpstreamStatus != 200 || strings.Join(inj[0].Faults, ",") != FaultTruncate {
+		t.Errorf("injection log = %+v, want one truncate with upstream status 200", inj)
+	}
+}
+
+func TestProxySlowRead(t *testing.T) {
+	_, srv, log := startProxy(t, Config{Faults: Faults{SlowReadRate: 1, SlowReadBps: 100}})
+	start := time.Now()
+	resp, err := protect(t, srv.URL)
+	if err != nil {
+		t.Fatalf("protect: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	elapsed := time.Since(start)
+	if err != nil || !json.Valid(body) {
+		t.Fatalf("body %q, err %v, want the complete response", body, err)
+	}
+	// 100바이트/초는 100ms마다 10바이트이므로 본문 길이에 비례해 느려짐
+	if min := time.Duration(len(body)/10-1) * 100 * time.Millisecond; elapsed < min {
+		t.Errorf("read %d bytes in %v, want at least %v", len(body), elapsed, min)
+	}
+	if inj := injections(t, srv, log); len(inj) != 1 || inj[0].SlowReadBps != 100 || strings.Join(inj[0].Faults, ",") != FaultSlowRead {
+		t.Errorf("injection log = %+v, want one slow read at 100 bps", inj)
+	}
+}
+
+func TestProxyLatency(t *testing.T) {
+	_, srv, log := startProxy(t, Config{Faults: Faults{Latency: Latency{Distribution: "fixed", BaseMs: 50}}})
+	start := time.Now()
+	resp, err := protect(t, srv.URL)
+	if err != nil {
+		t.Fatalf("protect: %v", err)
+	}
+	resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("response after %v, want at least 50ms", elapsed)
+	}
+	if inj := injections(t, srv, log); len(inj) != 1 || inj[0].DelayMs != 50 || inj[0].UpstreamStatus != 200 {
+		t.Errorf("injection log = %+v, want one 50ms delay", inj)
+	}
+}
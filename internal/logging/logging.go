@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Options는 로거 설정입니다
+type Options struct {
+	Level  string // debug, info, warn, error
+	Format string // text, json
+	File   string // 비어 있으면 표준 에러로 출력
+}
+
+// ParseLevel은 문자열을 slog.Level로 변환합니다
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid log level %q (expected debug, info, warn or error)", s)
+}
+
+// ValidateFormat은 로그 형식이 올바른지 확인합니다
+func ValidateFormat(s string) error {
+	switch strings.ToLower(s) {
+	case "text", "json", "":
+		return nil
+	}
+	return fmt.Errorf("invalid log format %q (expected text or json)", s)
+}
+
+// New는 설정에 따라 로거를 생성합니다
+// 반환된 io.Closer는 로그 파일을 닫으며, 표준 에러 출력이면 아무 일도 하지 않습니다
+func New(opts Options) (*slog.Logger, io.Closer, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ValidateFormat(opts.Format); err != nil {
+		return nil, nil, err
+	}
+
+	var w io.Writer = os.Stderr
+	var closer io.Closer = nopCloser{}
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		w, closer = f, f
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if strings.EqualFold(opts.Format, "json") {
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	} else {
+		handler = slog.NewTextHandler(w, handlerOpts)
+	}
+	return slog.New(handler), closer, nil
+}
+
+// Discard는 아무것도 출력하지 않는 로거를 반환합니다 (로거가 설정되지 않은 구성요소의 기본값)
+func Discard() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+}
+
+// loggerKey는 context에 로거를 저장하는 키입니다
+type loggerKey struct{}
+
+// NewContext는 로거를 담은 context를 반환합니다
+// 반복 번호처럼 호출 경로에 따라 달라지는 속성을 하위 구성요소 로그에 전달할 때 사용합니다
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext는 ctx에 담긴 로거를 반환합니다 (없으면 fallback)
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return fallback
+}
+
+// NewRequestID는 요청 추적용 무작위 ID(16자리 16진수)를 생성합니다
+func NewRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// nopCloser는 아무 일도 하지 않는 io.Closer입니다
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
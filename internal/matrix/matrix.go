@@ -0,0 +1,87 @@
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/sjrhee/crdp-cli-go/internal/config"
+)
+
+// Combination은 매트릭스에서 확장된 하나의 실행 조합을 나타냅니다
+type Combination struct {
+	Policy        string
+	Bulk          bool
+	BatchSize     int
+	Workers       int
+	TLS           bool
+	PayloadLength int
+}
+
+// Label은 조합을 사람이 읽을 수 있는 짧은 이름으로 반환합니다
+func (c Combination) Label() string {
+	mode := "single"
+	if c.Bulk {
+		mode = fmt.Sprintf("bulk%d", c.BatchSize)
+	}
+	scheme := "http"
+	if c.TLS {
+		scheme = "https"
+	}
+	label := fmt.Sprintf("%s/%s/w%d/%s", c.Policy, mode, c.Workers, scheme)
+	if c.PayloadLength > 0 {
+		label += fmt.Sprintf("/len%d", c.PayloadLength)
+	}
+	return label
+}
+
+// Expand는 설정의 매트릭스 스펙을 모든 조합의 목록으로 확장합니다
+// 비어 있는 축은 단일 실행 설정값 하나로 대체됩니다
+func Expand(cfg *config.Config) []Combination {
+	batchSizes := cfg.Matrix.BatchSizes
+	if len(batchSizes) == 0 {
+		if cfg.Batch.Enabled {
+			batchSizes = []int{cfg.Batch.Size}
+		} else {
+			batchSizes = []int{0}
+		}
+	}
+	workers := cfg.Matrix.Workers
+	if len(workers) == 0 {
+		workers = []int{cfg.Parallel.Workers}
+	}
+	policies := cfg.Matrix.Policies
+	if len(policies) == 0 {
+		policies = []string{cfg.Protection.Policy}
+	}
+	tlsModes := cfg.Matrix.TLS
+	if len(tlsModes) == 0 {
+		tlsModes = []bool{cfg.API.TLS}
+	}
+	lengths := cfg.Matrix.PayloadLengths
+	if len(lengths) == 0 {
+		lengths = []int{cfg.Execution.PayloadLength}
+	}
+
+	combos := make([]Combination, 0, len(policies)*len(tlsModes)*len(lengths)*len(batchSizes)*len(workers))
+	for _, policy := range policies {
+		for _, useTLS := range tlsModes {
+			for _, length := range lengths {
+				for _, size := range batchSizes {
+					for _, w := range workers {
+						if w < 1 {
+							w = 1
+						}
+						combos = append(combos, Combination{
+							Policy:        policy,
+							Bulk:          size > 0,
+							BatchSize:     size,
+							Workers:       w,
+							TLS:           useTLS,
+							PayloadLength: length,
+						})
+					}
+				}
+			}
+		}
+	}
+	return combos
+}
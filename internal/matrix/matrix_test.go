@@ -0,0 +1,85 @@
+package matrix
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sjrhee/crdp-cli-go/internal/config"
+)
+
+// baseConfig는 매트릭스 축이 비어 있는 단일 실행 설정을 반환합니다
+func baseConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Protection.Policy = "P03"
+	cfg.Parallel.Workers = 4
+	cfg.API.TLS = true
+	cfg.Execution.PayloadLength = 13
+	return cfg
+}
+
+func TestExpandDefaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(cfg *config.Config)
+		want  Combination
+	}{
+		{"single", func(cfg *config.Config) {}, Combination{Policy: "P03", Workers: 4, TLS: true, PayloadLength: 13}},
+		{"bulk", func(cfg *config.Config) {
+			cfg.Batch.Enabled = true
+			cfg.Batch.Size = 50
+		}, Combination{Policy: "P03", Bulk: true, BatchSize: 50, Workers: 4, TLS: true, PayloadLength: 13}},
+		{"workers below one", func(cfg *config.Config) { cfg.Parallel.Workers = 0 }, Combination{Policy: "P03", Workers: 1, TLS: true, PayloadLength: 13}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			tt.setup(cfg)
+			got := Expand(cfg)
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("Expand = %+v, want [%+v]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandAxes(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Matrix.Policies = []string{"P03", "P04"}
+	cfg.Matrix.BatchSizes = []int{0, 100}
+	cfg.Matrix.Workers = []int{1, 8}
+	cfg.Matrix.TLS = []bool{false}
+
+	got := Expand(cfg)
+	if len(got) != 8 {
+		t.Fatalf("Expand returned %d combinations, want 8", len(got))
+	}
+	// 정책, TLS, 길이, 배치 크기, 워커 순으로 중첩
+	want := []Combination{
+		{Policy: "P03", Workers: 1, PayloadLength: 13},
+		{Policy: "P03", Workers: 8, PayloadLength: 13},
+		{Policy: "P03", Bulk: true, BatchSize: 100, Workers: 1, PayloadLength: 13},
+		{Policy: "P03", Bulk: true, BatchSize: 100, Workers: 8, PayloadLength: 13},
+	}
+	if !reflect.DeepEqual(got[:4], want) {
+		t.Errorf("first combinations = %+v, want %+v", got[:4], want)
+	}
+	if got[4].Policy != "P04" {
+		t.Errorf("combination 5 policy = %q, want P04", got[4].Policy)
+	}
+}
+
+func TestCombinationLabel(t *testing.T) {
+	tests := []struct {
+		c    Combination
+		want string
+	}{
+		{Combination{Policy: "P03", Workers: 1}, "P03/single/w1/http"},
+		{Combination{Policy: "P03", Bulk: true, BatchSize: 50, Workers: 4, TLS: true}, "P03/bulk50/w4/https"},
+		{Combination{Policy: "P04", Workers: 2, PayloadLength: 32}, "P04/single/w2/http/len32"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Label(); got != tt.want {
+			t.Errorf("Label() = %q, want %q", got, tt.want)
+		}
+	}
+}
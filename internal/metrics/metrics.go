@@ -0,0 +1,296 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/runner"
+)
+
+// 히스토그램 버킷 경계
+var (
+	latencyBuckets   = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	batchSizeBuckets = []float64{1, 5, 10, 25, 50, 100, 200, 500, 1000}
+)
+
+// Registry는 실행 중 수집되는 Prometheus 메트릭 모음입니다
+// client.Observer를 구현하여 클라이언트 요청을, ObserveProgress로 runner 결과를 수집합니다
+type Registry struct {
+	mu sync.Mutex
+
+	requests    *counterVec   // 엔드포인트/상태 코드별 요청 수
+	retries     *counterVec   // 엔드포인트별 재시도 수
+	latency     *histogramVec // 엔드포인트별 요청 지연 시간
+	inFlight    float64       // 진행 중인 요청 수
+	iterations  *counterVec   // 결과별 반복(배치) 수
+	items       float64       // 처리한 데이터 개수
+	mismatches  float64       // 복원 결과가 원본과 다른 데이터 개수
+	batchSizes  *histogramVec // 반복(배치)당 데이터 개수
+	runsStarted time.Time
+	combination string // 현재 실행 중인 조합 이름
+}
+
+var _ client.Observer = (*Registry)(nil)
+
+// NewRegistry는 새로운 메트릭 Registry를 생성합니다
+func NewRegistry() *Registry {
+	return &Registry{
+		requests:    newCounterVec("crdp_requests_total", "CRDP HTTP requests by endpoint and status code.", "endpoint", "status"),
+		retries:     newCounterVec("crdp_request_retries_total", "CRDP HTTP request retries by endpoint.", "endpoint"),
+		latency:     newHistogramVec("crdp_request_duration_seconds", "CRDP HTTP request latency by endpoint.", latencyBuckets, "endpoint"),
+		iterations:  newCounterVec("crdp_iterations_total", "Protect/reveal iterations (or batches) by result.", "result"),
+		batchSizes:  newHistogramVec("crdp_batch_size", "Items per iteration (batch size in bulk mode).", batchSizeBuckets),
+		runsStarted: time.Now(),
+	}
+}
+
+// SetCombination은 현재 실행 중인 조합 이름을 설정합니다 (crdp_run_info 라벨)
+func (r *Registry) SetCombination(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.combination = name
+}
+
+// RequestStarted는 client.Observer 구현입니다
+func (r *Registry) RequestStarted(endpoint string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight++
+}
+
+// RequestFinished는 client.Observer 구현입니다
+func (r *Registry) RequestFinished(endpoint string, statusCode int, elapsed time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight--
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(statusCode)
+	}
+	r.requests.add(1, endpoint, status)
+	r.latency.observe(elapsed.Seconds(), endpoint)
+}
+
+// RequestRetried는 client.Observer 구현입니다
+func (r *Registry) RequestRetried(endpoint string, attempt int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries.add(1, endpoint)
+}
+
+// ObserveProgress는 runner 진행 정보를 메트릭에 반영합니다 (runner.Options.Progress에 연결)
+func (r *Registry) ObserveProgress(p runner.Progress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items += float64(len(p.Inputs))
+	r.batchSizes.observe(float64(len(p.Inputs)))
+
+	switch {
+	case p.Err != nil:
+		r.iterations.add(1, "error")
+	case !p.Result.Success:
+		r.iterations.add(1, "failed")
+	case p.Result.Match:
+		r.iterations.add(1, "match")
+	default:
+		r.iterations.add(1, "mismatch")
+	}
+
+	if p.Result != nil {
+		matched := p.Result.MatchedCount
+		if p.Result.Match && matched == 0 {
+			// 단일 모드는 MatchedCount를 채우지 않음
+			matched = len(p.Inputs)
+		}
+		r.mismatches += float64(len(p.Inputs) - matched)
+	}
+}
+
+// WriteTo는 Prometheus 텍스트 형식으로 메트릭을 기록합니다
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	r.requests.write(&b)
+	r.retries.write(&b)
+	r.latency.write(&b)
+	writeSingle(&b, "crdp_requests_in_flight", "CRDP HTTP requests currently in flight.", "gauge", r.inFlight)
+	r.iterations.write(&b)
+	writeSingle(&b, "crdp_items_total", "Data items processed.", "counter", r.items)
+	writeSingle(&b, "crdp_mismatches_total", "Data items whose revealed value did not match the original.", "counter", r.mismatches)
+	r.batchSizes.write(&b)
+	writeSingle(&b, "crdp_run_uptime_seconds", "Seconds since the metrics registry was created.", "gauge", time.Since(r.runsStarted).Seconds())
+	if r.combination != "" {
+		fmt.Fprintf(&b, "# HELP crdp_run_info Currently running combination.\n# TYPE crdp_run_info gauge\n")
+		fmt.Fprintf(&b, "crdp_run_info{combination=\"%s\"} 1\n", escapeLabel(r.combination))
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP는 /metrics 요청에 메트릭을 응답합니다
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+// Serve는 addr에서 /metrics HTTP 리스너를 백그라운드로 시작합니다
+func Serve(addr string, r *Registry) (*http.Server, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on metrics address %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go srv.Serve(ln)
+	return srv, nil
+}
+
+// counterVec은 라벨별 카운터입니다
+type counterVec struct {
+	name, help string
+	labels     []string
+	values     map[string]float64
+}
+
+func newCounterVec(name, help string, labels ...string) *counterVec {
+	return &counterVec{name: name, help: help, labels: labels, values: make(map[string]float64)}
+}
+
+func (c *counterVec) add(v float64, labelValues ...string) {
+	c.values[labelKey(c.labels, labelValues)] += v
+}
+
+func (c *counterVec) write(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(b, "%s%s %s\n", c.name, key, formatValue(c.values[key]))
+	}
+}
+
+// histogramVec은 라벨별 누적 히스토그램입니다
+type histogramVec struct {
+	name, help string
+	labels     []string
+	buckets    []float64
+	series     map[string]*histogram
+}
+
+type histogram struct {
+	counts []float64 // 버킷별 (비누적) 개수
+	sum    float64
+	count  float64
+}
+
+func newHistogramVec(name, help string, buckets []float64, labels ...string) *histogramVec {
+	return &histogramVec{name: name, help: help, labels: labels, buckets: buckets, series: make(map[string]*histogram)}
+}
+
+func (h *histogramVec) observe(v float64, labelValues ...string) {
+	key := labelKey(h.labels, labelValues)
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogram{counts: make([]float64, len(h.buckets))}
+		h.series[key] = s
+	}
+	for i, le := range h.buckets {
+		if v <= le {
+			s.counts[i]++
+			break
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *histogramVec) write(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	keys := make([]string, 0, len(h.series))
+	for k := range h.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := h.series[key]
+		cumulative := 0.0
+		for i, le := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(b, "%s_bucket%s %s\n", h.name, withLabel(key, "le", formatValue(le)), formatValue(cumulative))
+		}
+		fmt.Fprintf(b, "%s_bucket%s %s\n", h.name, withLabel(key, "le", "+Inf"), formatValue(s.count))
+		fmt.Fprintf(b, "%s_sum%s %s\n", h.name, key, formatValue(s.sum))
+		fmt.Fprintf(b, "%s_count%s %s\n", h.name, key, formatValue(s.count))
+	}
+}
+
+// writeSingle은 라벨 없는 단일 값 메트릭을 기록합니다
+func writeSingle(b *strings.Builder, name, help, kind string, v float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n%s %s\n", name, help, name, kind, name, formatValue(v))
+}
+
+// labelKey는 라벨 이름과 값으로 `{a="x",b="y"}` 형식의 문자열을 만듭니다
+func labelKey(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		v := ""
+		if i < len(values) {
+			v = values[i]
+		}
+		parts[i] = name + `="` + escapeLabel(v) + `"`
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// withLabel은 라벨 문자열에 라벨 하나를 추가합니다
+func withLabel(key, name, value string) string {
+	label := name + `="` + escapeLabel(value) + `"`
+	if key == "" {
+		return "{" + label + "}"
+	}
+	return key[:len(key)-1] + "," + label + "}"
+}
+
+// labelEscaper는 Prometheus 텍스트 형식 규칙대로 라벨 값의 \, ", 줄바꿈만 이스케이프합니다
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel은 라벨 값을 Prometheus 텍스트 형식으로 이스케이프합니다 (그 외 문자는 UTF-8 그대로)
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+// sortedKeys는 맵의 키를 정렬하여 반환합니다
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatValue는 Prometheus 텍스트 형식의 숫자 문자열을 반환합니다
+func formatValue(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEscapeLabel(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{`a\b`, `a\\b`},
+		{`say "hi"`, `say \"hi\"`},
+		{"line1\nline2", `line1\nline2`},
+		{"정책-P03", "정책-P03"},
+		{"tab\there", "tab\there"},
+	}
+	for _, tt := range tests {
+		if got := escapeLabel(tt.in); got != tt.want {
+			t.Errorf("escapeLabel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteToLabels(t *testing.T) {
+	r := NewRegistry()
+	r.SetCombination(`정책 "bulk"`)
+	r.RequestFinished("/v1/protect", 200, 10*time.Millisecond, nil)
+
+	var b strings.Builder
+	if _, err := r.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	out := b.String()
+	if !strings.Contains(out, `crdp_run_info{combination="정책 \"bulk\""} 1`) {
+		t.Errorf("crdp_run_info not escaped as Prometheus text:\n%s", out)
+	}
+	if strings.Contains(out, `\u`) {
+		t.Errorf("output contains Go-style unicode escapes:\n%s", out)
+	}
+}
@@ -0,0 +1,382 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/runner"
+)
+
+// FormatVersion은 결과 파일 형식의 버전입니다
+const FormatVersion = 1
+
+// Results는 결과 파일의 최상위 구조입니다
+type Results struct {
+	Version     int          `json:"version"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Host        string       `json:"host"`
+	Port        int          `json:"port"`
+	Config      string       `json:"config,omitempty"` // 실행 설정 (YAML, 비밀 값 마스킹)
+	Environment *Environment `json:"environment,omitempty"`
+	Rows        []Row        `json:"rows"`
+}
+
+// Environment는 결과를 만든 실행 환경 정보입니다
+type Environment struct {
+	Hostname  string `json:"hostname"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	NumCPU    int    `json:"num_cpu"`
+	GoVersion string `json:"go_version"`
+}
+
+// CurrentEnvironment는 현재 프로세스의 실행 환경 정보를 반환합니다
+func CurrentEnvironment() *Environment {
+	hostname, _ := os.Hostname()
+	return &Environment{
+		Hostname:  hostname,
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+		GoVersion: runtime.Version(),
+	}
+}
+
+// Row는 한 번의 실행(또는 매트릭스 조합) 결과를 나타냅니다
+// 지연 시간 값은 모두 밀리초 단위입니다
+type Row struct {
+	Name          string `json:"name"`
+	Policy        string `json:"policy"`
+	Bulk          bool   `json:"bulk"`
+	BatchSize     int    `json:"batch_size"`
+	Workers       int    `json:"workers"`
+	TLS           bool   `json:"tls"`
+	PayloadLength int    `json:"payload_length"`
+
+	Attempted  int     `json:"attempted"`
+	Successful int     `json:"successful"`
+	Matched    int     `json:"matched"`
+	Errors     int     `json:"errors"`
+	DurationS  float64 `json:"duration_s"`
+	Throughput float64 `json:"throughput"`
+	ErrorRate  float64 `json:"error_rate"`
+	MatchRate  float64 `json:"match_rate"`
+
+	Samples      int     `json:"samples"`
+	LatencyMean  float64 `json:"latency_mean_ms"`
+	LatencyStdev float64 `json:"latency_stddev_ms"`
+	LatencyMin   float64 `json:"latency_min_ms"`
+	LatencyMax   float64 `json:"latency_max_ms"`
+	LatencyP50   float64 `json:"latency_p50_ms"`
+	LatencyP90   float64 `json:"latency_p90_ms"`
+	LatencyP95   float64 `json:"latency_p95_ms"`
+	LatencyP99   float64 `json:"latency_p99_ms"`
+
+	// 연결 단계별 평균 소요 시간 (output.phase_timing 활성화 시)
+	ConnNew      int     `json:"conn_new,omitempty"`
+	ConnReused   int     `json:"conn_reused,omitempty"`
+	DNSMean      float64 `json:"dns_mean_ms,omitempty"`
+	ConnectMean  float64 `json:"connect_mean_ms,omitempty"`
+	TLSMean      float64 `json:"tls_mean_ms,omitempty"`
+	TTFBMean     float64 `json:"ttfb_mean_ms,omitempty"`
+	BodyReadMean float64 `json:"body_read_mean_ms,omitempty"`
+
+	// 상세 분포 (JSON 결과 파일과 HTML 리포트에만 기록)
+	Timeline       []TimePoint     `json:"timeline,omitempty"`
+	Endpoints      []EndpointStats `json:"endpoints,omitempty"`
+	ErrorsByStatus map[string]int  `json:"errors_by_status,omitempty"`
+	// 원인 분류별 실패 요청 수 (auth, forbidden, policy_not_found, invalid_data, rate_limited, server, timeout, transport 등)
+	ErrorsByCategory map[string]int `json:"errors_by_category,omitempty"`
+
+	// 토큰 검증 (token_checks 설정 시): 검사한 토큰 수, 실행 후 다시 protect한 표본 토큰 수와 검사 항목별 위반 수
+	TokensChecked   int            `json:"tokens_checked,omitempty"`
+	TokensRepeated  int            `json:"tokens_repeated,omitempty"`
+	TokenViolations map[string]int `json:"token_violations,omitempty"`
+
+	// 호스트별 집계 (api.hosts로 여러 호스트를 사용한 경우)
+	Hosts []HostStats `json:"hosts,omitempty"`
+
+	// 정책별 집계 (protection.policies로 여러 정책을 한 번에 실행한 경우, Name은 정책 이름)
+	Policies []Row `json:"policies,omitempty"`
+}
+
+// HostStats는 호스트별 요청 수, 실패 수, 제외 횟수와 지연 시간입니다
+type HostStats struct {
+	Host        string  `json:"host"`
+	Requests    int     `json:"requests"`
+	Errors      int     `json:"errors"`
+	Ejections   int     `json:"ejections"`
+	LatencyMean float64 `json:"latency_mean_ms"`
+	LatencyP99  float64 `json:"latency_p99_ms"`
+}
+
+// TimePoint는 실행 시작 후 1초 구간의 처리량과 지연 시간입니다
+type TimePoint struct {
+	Second      int     `json:"second"`
+	Items       int     `json:"items"`
+	Errors      int     `json:"errors"`
+	LatencyMean float64 `json:"latency_mean_ms"`
+	LatencyP99  float64 `json:"latency_p99_ms"`
+}
+
+// EndpointStats는 엔드포인트별 요청 지연 시간 통계와 히스토그램입니다
+type EndpointStats struct {
+	Endpoint    string   `json:"endpoint"`
+	Requests    int      `json:"requests"`
+	LatencyMean float64  `json:"latency_mean_ms"`
+	LatencyP50  float64  `json:"latency_p50_ms"`
+	LatencyP99  float64  `json:"latency_p99_ms"`
+	Histogram   []Bucket `json:"histogram"`
+}
+
+// Bucket은 지연 시간 히스토그램의 한 구간입니다 (LeMs가 0이면 마지막 +Inf 구간)
+type Bucket struct {
+	LeMs  float64 `json:"le_ms"`
+	Count int     `json:"count"`
+}
+
+// histogramBounds는 엔드포인트 히스토그램 구간 경계(밀리초)입니다
+var histogramBounds = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000}
+
+// FillSummary는 실행 집계 결과를 Row의 통계 필드에 채웁니다
+func (r *Row) FillSummary(s *runner.Summary) {
+	stats := s.LatencyStats()
+	r.Attempted = s.Attempted
+	r.Successful = s.Successful
+	r.Matched = s.Matched
+	r.Errors = s.Errors
+	r.DurationS = s.TotalTime.Seconds()
+	r.Throughput = s.Throughput()
+	r.ErrorRate = s.ErrorRate()
+	r.MatchRate = s.MatchRate()
+	r.Samples = stats.Count
+	r.LatencyMean = stats.Mean * 1000
+	r.LatencyStdev = stats.Stddev * 1000
+	r.LatencyMin = stats.Min * 1000
+	r.LatencyMax = stats.Max * 1000
+	r.LatencyP50 = stats.P50 * 1000
+	r.LatencyP90 = stats.P90 * 1000
+	r.LatencyP95 = stats.P95 * 1000
+	r.LatencyP99 = stats.P99 * 1000
+
+	phases := s.Phases.Stats()
+	r.ConnNew = s.Phases.NewConns
+	r.ConnReused = s.Phases.ReusedConns
+	r.DNSMean = phases.DNS.Mean * 1000
+	r.ConnectMean = phases.Connect.Mean * 1000
+	r.TLSMean = phases.TLS.Mean * 1000
+	r.TTFBMean = phases.TTFB.Mean * 1000
+	r.BodyReadMean = phases.BodyRead.Mean * 1000
+
+	r.Timeline = make([]TimePoint, 0, len(s.Timeline))
+	for i, b := range s.Timeline {
+		st := runner.ComputeLatencyStats(b.Latencies)
+		r.Timeline = append(r.Timeline, TimePoint{
+			Second: i, Items: b.Items, Errors: b.Errors,
+			LatencyMean: st.Mean * 1000, LatencyP99: st.P99 * 1000,
+		})
+	}
+
+	names := make([]string, 0, len(s.Endpoints))
+	for name := range s.Endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	r.Endpoints = make([]EndpointStats, 0, len(names))
+	for _, name := range names {
+		r.Endpoints = append(r.Endpoints, endpointStats(name, s.Endpoints[name]))
+	}
+
+	if len(s.StatusErrors) > 0 {
+		r.ErrorsByStatus = make(map[string]int, len(s.StatusErrors))
+		for k, v := range s.StatusErrors {
+			r.ErrorsByStatus[k] = v
+		}
+	}
+	if len(s.Categories) > 0 {
+		r.ErrorsByCategory = make(map[string]int, len(s.Categories))
+		for k, v := range s.Categories {
+			r.ErrorsByCategory[k] = v
+		}
+	}
+	r.TokensChecked = s.TokensChecked
+	r.TokensRepeated = s.RepeatChecked
+	if len(s.Violations) > 0 {
+		r.TokenViolations = make(map[string]int, len(s.Violations))
+		for k, v := range s.Violations {
+			r.TokenViolations[k] = v
+		}
+	}
+}
+
+// FillPolicies는 여러 정책 실행의 정책별 집계를 Row에 채웁니다
+// 정책별 행은 실행 설정(bulk, workers 등)을 이 행에서 복사하고 Policy에 정책 이름을 기록합니다
+func (r *Row) FillPolicies(s *runner.Summary) {
+	if len(s.Policies) == 0 {
+		return
+	}
+	names := make([]string, len(s.Policies))
+	r.Policies = make([]Row, 0, len(s.Policies))
+	for i, ps := range s.Policies {
+		names[i] = ps.Name
+		sub := Row{
+			Name:          ps.Name,
+			Policy:        ps.Name,
+			Bulk:          r.Bulk,
+			BatchSize:     r.BatchSize,
+			Workers:       r.Workers,
+			TLS:           r.TLS,
+			PayloadLength: r.PayloadLength,
+		}
+		sub.FillSummary(&ps.Summary)
+		r.Policies = append(r.Policies, sub)
+	}
+	r.Policy = strings.Join(names, "+")
+}
+
+// FillHosts는 Balancer의 호스트별 집계를 Row에 채웁니다
+func (r *Row) FillHosts(stats []client.HostStats) {
+	r.Hosts = make([]HostStats, 0, len(stats))
+	for _, h := range stats {
+		st := runner.ComputeLatencyStats(h.Latencies)
+		r.Hosts = append(r.Hosts, HostStats{
+			Host:        h.Addr,
+			Requests:    h.Requests,
+			Errors:      h.Errors,
+			Ejections:   h.Ejections,
+			LatencyMean: st.Mean * 1000,
+			LatencyP99:  st.P99 * 1000,
+		})
+	}
+}
+
+// endpointStats는 요청 소요 시간(초) 샘플로 통계와 히스토그램을 계산합니다
+func endpointStats(name string, samples []float64) EndpointStats {
+	st := runner.ComputeLatencyStats(samples)
+	buckets := make([]Bucket, len(histogramBounds)+1)
+	for i, le := range histogramBounds {
+		buckets[i].LeMs = le
+	}
+	for _, v := range samples {
+		ms := v * 1000
+		i := sort.SearchFloat64s(histogramBounds, ms)
+		buckets[i].Count++
+	}
+	return EndpointStats{
+		Endpoint:    name,
+		Requests:    st.Count,
+		LatencyMean: st.Mean * 1000,
+		LatencyP50:  st.P50 * 1000,
+		LatencyP99:  st.P99 * 1000,
+		Histogram:   buckets,
+	}
+}
+
+// csvHeader는 CSV 결과 파일의 컬럼 순서입니다
+var csvHeader = []string{
+	"name", "policy", "bulk", "batch_size", "workers", "tls", "payload_length",
+	"attempted", "successful", "matched", "errors", "duration_s", "throughput", "error_rate", "match_rate",
+	"samples", "latency_mean_ms", "latency_stddev_ms", "latency_min_ms", "latency_max_ms",
+	"latency_p50_ms", "latency_p90_ms", "latency_p95_ms", "latency_p99_ms",
+	"conn_new", "conn_reused", "dns_mean_ms", "connect_mean_ms", "tls_mean_ms", "ttfb_mean_ms", "body_read_mean_ms",
+}
+
+// WriteResults는 결과를 파일로 저장합니다
+// 확장자가 .csv이면 CSV, 그 외에는 JSON 형식으로 저장합니다
+func WriteResults(path string, results *Results) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create results file: %w", err)
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		err = writeCSV(f, results.Rows)
+	} else {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(results)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	return nil
+}
+
+// ReadResults는 JSON 결과 파일을 읽습니다
+func ReadResults(path string) (*Results, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results file: %w", err)
+	}
+
+	results := &Results{}
+	if err := json.Unmarshal(data, results); err != nil {
+		return nil, fmt.Errorf("failed to parse results file %s: %w", path, err)
+	}
+	return results, nil
+}
+
+// writeCSV는 Row 목록을 CSV로 기록합니다
+func writeCSV(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		record := []string{
+			r.Name, r.Policy, strconv.FormatBool(r.Bulk), strconv.Itoa(r.BatchSize), strconv.Itoa(r.Workers),
+			strconv.FormatBool(r.TLS), strconv.Itoa(r.PayloadLength),
+			strconv.Itoa(r.Attempted), strconv.Itoa(r.Successful), strconv.Itoa(r.Matched), strconv.Itoa(r.Errors),
+			formatFloat(r.DurationS), formatFloat(r.Throughput), formatFloat(r.ErrorRate), formatFloat(r.MatchRate),
+			strconv.Itoa(r.Samples), formatFloat(r.LatencyMean), formatFloat(r.LatencyStdev),
+			formatFloat(r.LatencyMin), formatFloat(r.LatencyMax),
+			formatFloat(r.LatencyP50), formatFloat(r.LatencyP90), formatFloat(r.LatencyP95), formatFloat(r.LatencyP99),
+			strconv.Itoa(r.ConnNew), strconv.Itoa(r.ConnReused), formatFloat(r.DNSMean), formatFloat(r.ConnectMean),
+			formatFloat(r.TLSMean), formatFloat(r.TTFBMean), formatFloat(r.BodyReadMean),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatFloat은 CSV용 실수 문자열을 반환합니다
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
+
+// PrintTable은 여러 실행 결과를 비교 표 형태로 출력합니다
+func PrintTable(w io.Writer, rows []Row) {
+	printTable(w, "combination", rows)
+}
+
+// PrintPolicyTable은 여러 정책 실행의 정책별 결과를 비교 표로 출력합니다
+func PrintPolicyTable(w io.Writer, rows []Row) {
+	printTable(w, "policy", rows)
+}
+
+// printTable은 첫 열 제목이 title인 비교 표를 출력합니다
+func printTable(w io.Writer, title string, rows []Row) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, title+"\titems\tok\tmatched\terr%\titems/s\tmean(ms)\tp50(ms)\tp95(ms)\tp99(ms)")
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.2f\t%.1f\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			r.Name, r.Attempted, r.Successful, r.Matched, r.ErrorRate*100, r.Throughput,
+			r.LatencyMean, r.LatencyP50, r.LatencyP95, r.LatencyP99)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testResults는 정책별 행과 토큰 검증 결과를 포함한 결과입니다
+func testResults() *Results {
+	return &Results{
+		Version:     FormatVersion,
+		GeneratedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Host:        "127.0.0.1",
+		Port:        32082,
+		Rows: []Row{{
+			Name: "P03/bulk50/w4/https", Policy: "P03", Bulk: true, BatchSize: 50, Workers: 4, TLS: true,
+			Attempted: 100, Successful: 99, Matched: 98, Errors: 1, DurationS: 1.5, Throughput: 66.6667,
+			ErrorRate: 0.01, MatchRate: 0.98, Samples: 2, LatencyMean: 12.34567, LatencyP99: 20,
+			ErrorsByStatus:  map[string]int{"503": 1},
+			TokensChecked:   99,
+			TokenViolations: map[string]int{"same_length": 2},
+			Policies:        []Row{{Name: "P03", Policy: "P03", Attempted: 100}},
+		}},
+	}
+}
+
+func TestWriteResultsJSONRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	want := testResults()
+	if err := WriteResults(path, want); err != nil {
+		t.Fatalf("WriteResults: %v", err)
+	}
+	got, err := ReadResults(path)
+	if err != nil {
+		t.Fatalf("ReadResults: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteResultsCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.CSV")
+	if err := WriteResults(path, testResults()); err != nil {
+		t.Fatalf("WriteResults: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("read CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("CSV has %d records, want header and 1 row", len(records))
+	}
+	if !reflect.DeepEqual(records[0], csvHeader) {
+		t.Errorf("header = %v, want %v", records[0], csvHeader)
+	}
+	row := make(map[string]string, len(csvHeader))
+	for i, name := range records[0] {
+		row[name] = records[1][i]
+	}
+	want := map[string]string{
+		"name": "P03/bulk50/w4/https", "bulk": "true", "batch_size": "50", "tls": "true",
+		"attempted": "100", "errors": "1", "throughput": "66.6667", "latency_mean_ms": "12.3457", "latency_p99_ms": "20.0000",
+	}
+	for k, v := range want {
+		if row[k] != v {
+			t.Errorf("column %s = %q, want %q", k, row[k], v)
+		}
+	}
+}
+
+func TestReadResultsErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ReadResults(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("ReadResults succeeded for a missing file")
+	}
+	bad := filepath.Join(dir, "bad.json")
+	os.WriteFile(bad, []byte("{not json"), 0o644)
+	if _, err := ReadResults(bad); err == nil {
+		t.Error("ReadResults succeeded for invalid JSON")
+	}
+}
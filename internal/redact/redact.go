@@ -0,0 +1,145 @@
+package redact
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Mode는 디버그 출력에서 민감한 값을 가리는 방식입니다
+type Mode string
+
+const (
+	None   Mode = "none"   // 원본 그대로 출력
+	Mask   Mode = "mask"   // 마지막 4자만 남기고 '*'로 가림 (8자 미만은 전체)
+	Hash   Mode = "hash"   // 프로세스마다 새로 만든 키의 HMAC-SHA256 앞 12자리 (한 실행 안에서만 같은 값끼리 비교 가능)
+	Length Mode = "length" // 길이만 출력
+)
+
+// sensitiveKeys는 값을 가려야 하는 JSON 키입니다 (배열/객체이면 하위 값 전체)
+var sensitiveKeys = map[string]bool{
+	"data":                 true,
+	"protected_data":       true,
+	"data_array":           true,
+	"protected_data_array": true,
+}
+
+// hashKey는 Hash 모드의 HMAC 키입니다
+// 프로세스마다 무작위로 만들므로 해시 값으로 원본을 대입 공격으로 찾을 수 없고, 다른 실행의 해시와는 비교할 수 없습니다
+var hashKey = newHashKey()
+
+// newHashKey는 32바이트 무작위 키를 생성합니다
+func newHashKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("redact: cannot generate hash key: %v", err))
+	}
+	return key
+}
+
+// ParseMode는 문자열을 Mode로 변환합니다 ("length-only"는 "length"와 같음)
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case None, Mask, Hash, Length:
+		return m, nil
+	case "length-only":
+		return Length, nil
+	}
+	return "", fmt.Errorf("invalid redaction mode %q (expected none, mask, hash or length)", s)
+}
+
+// Redactor는 설정된 Mode로 민감한 값을 가립니다
+type Redactor struct {
+	mode Mode
+}
+
+// New는 새로운 Redactor를 생성합니다
+func New(mode Mode) *Redactor {
+	return &Redactor{mode: mode}
+}
+
+// Mode는 Redactor의 Mode를 반환합니다
+func (r *Redactor) Mode() Mode {
+	return r.mode
+}
+
+// Value는 하나의 민감한 값을 가립니다
+func (r *Redactor) Value(s string) string {
+	switch r.mode {
+	case None:
+		return s
+	case Hash:
+		mac := hmac.New(sha256.New, hashKey)
+		mac.Write([]byte(s))
+		return "hmac:" + hex.EncodeToString(mac.Sum(nil))[:12]
+	case Length:
+		return fmt.Sprintf("<%d chars>", len(s))
+	}
+	if len(s) < 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
+
+// Authorization은 Authorization 헤더 값의 자격 증명 부분을 가립니다 (예: "Bearer ****abcd")
+func (r *Redactor) Authorization(value string) string {
+	if scheme, cred, ok := strings.Cut(value, " "); ok {
+		return scheme + " " + r.Value(cred)
+	}
+	return r.Value(value)
+}
+
+// JSON은 JSON 본문에서 민감한 키의 값을 가린 문자열을 반환합니다
+// JSON이 아니거나 None 모드이면 원본을 그대로 반환합니다
+func (r *Redactor) JSON(body []byte) string {
+	if r.mode == None {
+		return string(body)
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(r.walk(v, false)); err != nil {
+		return string(body)
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// Map은 응답 본문 맵에서 민감한 키의 값을 가린 복사본을 반환합니다
+func (r *Redactor) Map(m map[string]interface{}) map[string]interface{} {
+	if m == nil || r.mode == None {
+		return m
+	}
+	return r.walk(m, false).(map[string]interface{})
+}
+
+// walk는 값을 재귀적으로 복사하며 민감한 문자열을 가립니다
+func (r *Redactor) walk(v interface{}, sensitive bool) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(x))
+		for k, child := range x {
+			out[k] = r.walk(child, sensitive || sensitiveKeys[k])
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(x))
+		for i, child := range x {
+			out[i] = r.walk(child, sensitive)
+		}
+		return out
+	case string:
+		if sensitive {
+			return r.Value(x)
+		}
+	}
+	return v
+}
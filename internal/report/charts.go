@@ -0,0 +1,158 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// 차트 크기와 여백 (픽셀)
+const (
+	chartWidth   = 760
+	chartHeight  = 240
+	marginLeft   = 60
+	marginRight  = 16
+	marginTop    = 16
+	marginBottom = 36
+)
+
+// series는 선 그래프의 한 계열입니다
+type series struct {
+	Name   string
+	Color  string
+	Values []float64
+}
+
+// lineChart는 x축(초) 기준 선 그래프를 인라인 SVG로 그립니다
+func lineChart(xs []float64, list []series, yUnit string) template.HTML {
+	if len(xs) == 0 {
+		return template.HTML(`<p class="muted">no data</p>`)
+	}
+
+	xMax := xs[len(xs)-1]
+	if xMax <= 0 {
+		xMax = 1
+	}
+	yMax := 0.0
+	for _, s := range list {
+		for _, v := range s.Values {
+			yMax = math.Max(yMax, v)
+		}
+	}
+	yTicks := niceTicks(yMax)
+	yTop := yTicks[len(yTicks)-1]
+
+	plotW := float64(chartWidth - marginLeft - marginRight)
+	plotH := float64(chartHeight - marginTop - marginBottom)
+	px := func(x float64) float64 { return marginLeft + x/xMax*plotW }
+	py := func(y float64) float64 { return marginTop + plotH - y/yTop*plotH }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg viewBox="0 0 %d %d" class="chart" role="img">`, chartWidth, chartHeight)
+	writeYAxis(&b, yTicks, py, yUnit)
+
+	// x축 눈금 (최대 10개)
+	step := math.Max(1, math.Ceil(xMax/10))
+	for x := 0.0; x <= xMax; x += step {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" class="tick" text-anchor="middle">%gs</text>`, px(x), chartHeight-marginBottom+16, x)
+	}
+
+	for _, s := range list {
+		points := make([]string, 0, len(s.Values))
+		for i, v := range s.Values {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", px(xs[i]), py(v)))
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="1.6" points="%s"/>`, s.Color, strings.Join(points, " "))
+	}
+	writeLegend(&b, list)
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// barChart는 레이블별 막대 그래프(히스토그램)를 인라인 SVG로 그립니다
+func barChart(labels []string, counts []int, color string) template.HTML {
+	if len(counts) == 0 {
+		return template.HTML(`<p class="muted">no data</p>`)
+	}
+
+	yMax := 0.0
+	for _, c := range counts {
+		yMax = math.Max(yMax, float64(c))
+	}
+	yTicks := niceTicks(yMax)
+	yTop := yTicks[len(yTicks)-1]
+
+	plotW := float64(chartWidth - marginLeft - marginRight)
+	plotH := float64(chartHeight - marginTop - marginBottom)
+	py := func(y float64) float64 { return marginTop + plotH - y/yTop*plotH }
+	slot := plotW / float64(len(counts))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg viewBox="0 0 %d %d" class="chart" role="img">`, chartWidth, chartHeight)
+	writeYAxis(&b, yTicks, py, "")
+	for i, c := range counts {
+		x := marginLeft + float64(i)*slot
+		y := py(float64(c))
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %d</title></rect>`,
+			x+slot*0.1, y, slot*0.8, marginTop+plotH-y, color, template.HTMLEscapeString(labels[i]), c)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" class="tick" text-anchor="middle">%s</text>`,
+			x+slot/2, chartHeight-marginBottom+16, template.HTMLEscapeString(labels[i]))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// writeYAxis는 y축 눈금과 격자선을 그립니다
+func writeYAxis(b *strings.Builder, ticks []float64, py func(float64) float64, unit string) {
+	for _, t := range ticks {
+		y := py(t)
+		fmt.Fprintf(b, `<line x1="%d" x2="%d" y1="%.1f" y2="%.1f" class="grid"/>`, marginLeft, chartWidth-marginRight, y, y)
+		fmt.Fprintf(b, `<text x="%d" y="%.1f" class="tick" text-anchor="end">%s%s</text>`, marginLeft-6, y+4, formatTick(t), unit)
+	}
+}
+
+// writeLegend는 계열 범례를 그립니다
+func writeLegend(b *strings.Builder, list []series) {
+	x := marginLeft + 8
+	for _, s := range list {
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="10" height="10" fill="%s"/>`, x, marginTop, s.Color)
+		fmt.Fprintf(b, `<text x="%d" y="%d" class="legend">%s</text>`, x+14, marginTop+9, template.HTMLEscapeString(s.Name))
+		x += 24 + 7*len(s.Name)
+	}
+}
+
+// niceTicks는 0부터 max 이상까지의 보기 좋은 눈금 값(5개 내외)을 반환합니다
+func niceTicks(max float64) []float64 {
+	if max <= 0 {
+		return []float64{0, 1}
+	}
+	raw := max / 4
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := mag
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if m*mag >= raw {
+			step = m * mag
+			break
+		}
+	}
+	var ticks []float64
+	for v := 0.0; ; v += step {
+		ticks = append(ticks, v)
+		if v >= max {
+			break
+		}
+	}
+	return ticks
+}
+
+// formatTick은 눈금 값을 짧은 문자열로 반환합니다
+func formatTick(v float64) string {
+	switch {
+	case v >= 1e6:
+		return fmt.Sprintf("%gM", v/1e6)
+	case v >= 1e4:
+		return fmt.Sprintf("%gk", v/1e3)
+	}
+	return fmt.Sprintf("%g", math.Round(v*1000)/1000)
+}
@@ -0,0 +1,269 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/sjrhee/crdp-cli-go/internal/output"
+)
+
+// 차트 색상
+const (
+	colorPrimary   = "#2563eb"
+	colorSecondary = "#dc2626"
+	colorHistogram = "#0d9488"
+)
+
+// page는 HTML 템플릿에 전달하는 리포트 데이터입니다
+type page struct {
+	Results *output.Results
+	Rows    []rowView
+}
+
+// rowView는 한 실행 결과 행의 리포트 섹션입니다
+type rowView struct {
+	output.Row
+	Anchor          string
+	ThroughputChart template.HTML
+	LatencyChart    template.HTML
+	Endpoints       []endpointView
+	Errors          []errorView
+	Categories      []errorView // Status에 원인 분류 이름
+	Violations      []errorView // Status에 토큰 검증 항목 이름
+}
+
+// endpointView는 엔드포인트별 히스토그램입니다
+type endpointView struct {
+	output.EndpointStats
+	Chart template.HTML
+}
+
+// errorView는 상태 코드별 오류 수입니다
+type errorView struct {
+	Status string
+	Count  int
+}
+
+// Write는 결과 데이터를 외부 의존성 없는 단일 HTML 파일 리포트로 저장합니다
+func Write(path string, results *output.Results) error {
+	p := page{Results: results}
+	for i, row := range results.Rows {
+		p.Rows = append(p.Rows, newRowView(i, row))
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close()
+
+	if err := reportTemplate.Execute(f, p); err != nil {
+		return fmt.Errorf("failed to write report file: %w", err)
+	}
+	return nil
+}
+
+// newRowView는 결과 행으로 차트와 표 데이터를 만듭니다
+func newRowView(index int, row output.Row) rowView {
+	v := rowView{Row: row, Anchor: "row-" + strconv.Itoa(index)}
+
+	xs := make([]float64, len(row.Timeline))
+	items := make([]float64, len(row.Timeline))
+	errors := make([]float64, len(row.Timeline))
+	mean := make([]float64, len(row.Timeline))
+	p99 := make([]float64, len(row.Timeline))
+	for i, tp := range row.Timeline {
+		xs[i] = float64(tp.Second)
+		items[i] = float64(tp.Items)
+		errors[i] = float64(tp.Errors)
+		mean[i] = tp.LatencyMean
+		p99[i] = tp.LatencyP99
+	}
+	v.ThroughputChart = lineChart(xs, []series{
+		{Name: "items/s", Color: colorPrimary, Values: items},
+		{Name: "errors/s", Color: colorSecondary, Values: errors},
+	}, "")
+	v.LatencyChart = lineChart(xs, []series{
+		{Name: "mean", Color: colorPrimary, Values: mean},
+		{Name: "p99", Color: colorSecondary, Values: p99},
+	}, "ms")
+
+	for _, ep := range row.Endpoints {
+		labels := make([]string, len(ep.Histogram))
+		counts := make([]int, len(ep.Histogram))
+		for i, b := range ep.Histogram {
+			if b.LeMs > 0 {
+				labels[i] = "≤" + strconv.FormatFloat(b.LeMs, 'f', -1, 64)
+			} else {
+				labels[i] = "more"
+			}
+			counts[i] = b.Count
+		}
+		v.Endpoints = append(v.Endpoints, endpointView{EndpointStats: ep, Chart: barChart(labels, counts, colorHistogram)})
+	}
+
+	for status, count := range row.ErrorsByStatus {
+		v.Errors = append(v.Errors, errorView{Status: status, Count: count})
+	}
+	sort.Slice(v.Errors, func(i, j int) bool { return v.Errors[i].Status < v.Errors[j].Status })
+	for category, count := range row.ErrorsByCategory {
+		v.Categories = append(v.Categories, errorView{Status: category, Count: count})
+	}
+	sort.Slice(v.Categories, func(i, j int) bool { return v.Categories[i].Count > v.Categories[j].Count })
+	for check, count := range row.TokenViolations {
+		v.Violations = append(v.Violations, errorView{Status: check, Count: count})
+	}
+	sort.Slice(v.Violations, func(i, j int) bool { return v.Violations[i].Count > v.Violations[j].Count })
+	return v
+}
+
+// percent는 0~1 비율을 백분율 문자열로 반환합니다
+func percent(v float64) string {
+	return strconv.FormatFloat(v*100, 'f', 2, 64) + "%"
+}
+
+// reportTemplate은 리포트 HTML 템플릿입니다
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": percent,
+	"ms":      func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) },
+	"num":     func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>CRDP benchmark report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, "Noto Sans KR", sans-serif; margin: 0 auto; max-width: 1000px; padding: 24px; color: #111827; }
+h1 { margin-bottom: 4px; }
+h2 { border-bottom: 1px solid #e5e7eb; padding-bottom: 4px; margin-top: 40px; }
+table { border-collapse: collapse; margin: 8px 0 16px; font-size: 14px; }
+th, td { border: 1px solid #e5e7eb; padding: 4px 10px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+th { background: #f9fafb; }
+pre { background: #f9fafb; border: 1px solid #e5e7eb; padding: 12px; font-size: 13px; overflow-x: auto; }
+.muted { color: #6b7280; }
+.chart { width: 100%; height: auto; }
+.chart .grid { stroke: #e5e7eb; }
+.chart .tick { font-size: 11px; fill: #6b7280; }
+.chart .legend { font-size: 12px; fill: #374151; }
+.ok { color: #059669; }
+.bad { color: #dc2626; }
+</style>
+</head>
+<body>
+<h1>CRDP benchmark report</h1>
+<p class="muted">Generated {{.Results.GeneratedAt.Format "2006-01-02 15:04:05 MST"}} &middot; target {{.Results.Host}}:{{.Results.Port}}</p>
+
+<h2>Summary</h2>
+<table>
+<tr><th>run</th><th>items</th><th>ok</th><th>matched</th><th>error rate</th><th>items/s</th><th>mean (ms)</th><th>p50 (ms)</th><th>p95 (ms)</th><th>p99 (ms)</th></tr>
+{{- range .Rows}}
+<tr><td><a href="#{{.Anchor}}">{{.Name}}</a></td><td>{{.Attempted}}</td><td>{{.Successful}}</td><td>{{.Matched}}</td>
+<td class="{{if gt .ErrorRate 0.0}}bad{{else}}ok{{end}}">{{percent .ErrorRate}}</td><td>{{num .Throughput}}</td>
+<td>{{ms .LatencyMean}}</td><td>{{ms .LatencyP50}}</td><td>{{ms .LatencyP95}}</td><td>{{ms .LatencyP99}}</td></tr>
+{{- end}}
+</table>
+
+{{range .Rows}}
+<h2 id="{{.Anchor}}">{{.Name}}</h2>
+<p class="muted">policy {{.Policy}} &middot; {{if .Bulk}}bulk, batch size {{.BatchSize}}{{else}}single{{end}} &middot; workers {{.Workers}} &middot; tls {{.TLS}}{{if .PayloadLength}} &middot; payload length {{.PayloadLength}}{{end}} &middot; {{printf "%.2f" .DurationS}}s</p>
+
+<h3>Throughput over time</h3>
+{{.ThroughputChart}}
+
+<h3>Latency over time (per iteration)</h3>
+{{.LatencyChart}}
+
+{{range .Endpoints}}
+<h3>{{.Endpoint}} latency histogram (ms)</h3>
+<p class="muted">{{.Requests}} requests &middot; mean {{ms .LatencyMean}}ms &middot; p50 {{ms .LatencyP50}}ms &middot; p99 {{ms .LatencyP99}}ms</p>
+{{.Chart}}
+{{end}}
+
+<h3>Errors</h3>
+{{if .Errors}}
+<table>
+<tr><th>status</th><th>count</th></tr>
+{{- range .Errors}}
+<tr><td>{{.Status}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{if .Categories}}
+<table>
+<tr><th>category</th><th>count</th></tr>
+{{- range .Categories}}
+<tr><td>{{.Status}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{end}}
+{{else}}
+<p class="ok">No errors.</p>
+{{end}}
+{{if .TokensChecked}}
+<h3>Token validation</h3>
+{{if .Violations}}
+<table>
+<tr><th>check</th><th>violations</th></tr>
+{{- range .Violations}}
+<tr><td>{{.Status}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{else}}
+<p class="ok">{{.TokensChecked}} tokens checked, no violations.</p>
+{{end}}
+{{end}}
+
+{{if .Policies}}
+<h3>Policies</h3>
+<table>
+<tr><th>policy</th><th>items</th><th>ok</th><th>matched</th><th>error rate</th><th>items/s</th><th>mean (ms)</th><th>p99 (ms)</th></tr>
+{{- range .Policies}}
+<tr><td>{{.Name}}</td><td>{{.Attempted}}</td><td>{{.Successful}}</td><td>{{.Matched}}</td><td class="{{if .ErrorRate}}bad{{else}}ok{{end}}">{{percent .ErrorRate}}</td><td>{{num .Throughput}}</td><td>{{ms .LatencyMean}}</td><td>{{ms .LatencyP99}}</td></tr>
+{{- end}}
+</table>
+{{end}}
+
+{{if .Hosts}}
+<h3>Hosts</h3>
+<table>
+<tr><th>host</th><th>requests</th><th>errors</th><th>ejections</th><th>mean (ms)</th><th>p99 (ms)</th></tr>
+{{- range .Hosts}}
+<tr><td>{{.Host}}</td><td>{{.Requests}}</td><td class="{{if .Errors}}bad{{else}}ok{{end}}">{{.Errors}}</td><td>{{.Ejections}}</td><td>{{ms .LatencyMean}}</td><td>{{ms .LatencyP99}}</td></tr>
+{{- end}}
+</table>
+{{end}}
+
+{{if or .ConnNew .ConnReused}}
+<h3>Connection phases (mean)</h3>
+<table>
+<tr><th>phase</th><th>ms</th></tr>
+<tr><td>DNS</td><td>{{ms .DNSMean}}</td></tr>
+<tr><td>TCP connect</td><td>{{ms .ConnectMean}}</td></tr>
+<tr><td>TLS handshake</td><td>{{ms .TLSMean}}</td></tr>
+<tr><td>TTFB</td><td>{{ms .TTFBMean}}</td></tr>
+<tr><td>Body read</td><td>{{ms .BodyReadMean}}</td></tr>
+<tr><td>Connections (new / reused)</td><td>{{.ConnNew}} / {{.ConnReused}}</td></tr>
+</table>
+{{end}}
+{{end}}
+
+{{with .Results.Environment}}
+<h2>Environment</h2>
+<table>
+<tr><td>host</td><td>{{.Hostname}}</td></tr>
+<tr><td>os/arch</td><td>{{.OS}}/{{.Arch}}</td></tr>
+<tr><td>CPUs</td><td>{{.NumCPU}}</td></tr>
+<tr><td>Go</td><td>{{.GoVersion}}</td></tr>
+</table>
+{{end}}
+
+{{with .Results.Config}}
+<h2>Configuration</h2>
+<pre>{{.}}</pre>
+{{end}}
+</body>
+</html>
+`))
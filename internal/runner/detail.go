@@ -0,0 +1,102 @@
+package runner
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+)
+
+// TimeBucket은 실행 시작 후 1초 구간에 완료된 반복(배치)의 집계입니다
+type TimeBucket struct {
+	Items     int       // 완료된 데이터 개수
+	Errors    int       // 전송 오류이거나 2xx가 아닌 반복(배치) 수
+	Latencies []float64 // 반복/배치별 소요 시간 (초)
+}
+
+// newSummary는 실행 시작 시각을 기록한 빈 집계를 생성합니다
+func newSummary(jobs int) *Summary {
+	return &Summary{
+		Latencies:    make([]float64, 0, jobs),
+		Endpoints:    make(map[string][]float64),
+		StatusErrors: make(map[string]int),
+		Categories:   make(map[string]int),
+		start:        time.Now(),
+	}
+}
+
+// addDetail은 시간대별/엔드포인트별/상태 코드별 집계에 결과를 반영합니다
+func (s *Summary) addDetail(items int, bulk bool, result *IterationResult, err error) {
+	if s.StatusErrors == nil {
+		s.StatusErrors = make(map[string]int)
+	}
+	if s.Endpoints == nil {
+		s.Endpoints = make(map[string][]float64)
+	}
+	if s.Categories == nil {
+		s.Categories = make(map[string]int)
+	}
+
+	sec := 0
+	if !s.start.IsZero() {
+		sec = int(time.Since(s.start) / time.Second)
+	}
+	for len(s.Timeline) <= sec {
+		s.Timeline = append(s.Timeline, TimeBucket{})
+	}
+	bucket := &s.Timeline[sec]
+	bucket.Items += items
+
+	if err != nil {
+		bucket.Errors++
+		s.StatusErrors[ErrorKind(err)]++
+		s.Categories[string(client.Classify(err))]++
+		return
+	}
+	bucket.Latencies = append(bucket.Latencies, result.TimeS)
+	if !result.Success {
+		bucket.Errors++
+	}
+
+	protectEndpoint, revealEndpoint := "/v1/protect", "/v1/reveal"
+	if bulk {
+		protectEndpoint, revealEndpoint = "/v1/protectbulk", "/v1/revealbulk"
+	}
+	s.addResponse(protectEndpoint, result.ProtectResponse)
+	s.addResponse(revealEndpoint, result.RevealResponse)
+}
+
+// ErrorKind는 응답을 받지 못한 오류의 집계 키를 반환합니다
+// 서킷 브레이커가 거부한 요청은 "circuit_open", 보내기 전에 실패한 요청은 "request_setup",
+// 그 외 전송 오류는 "transport"입니다
+func ErrorKind(err error) string {
+	switch {
+	case errors.Is(err, client.ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, client.ErrRequestSetup):
+		return "request_setup"
+	}
+	return "transport"
+}
+
+// addThrottled는 한 응답이 속도/동시 요청 제한으로 대기한 시간을 집계합니다
+func (s *Summary) addThrottled(resp *client.APIResponse) {
+	if resp == nil || resp.Throttled <= 0 {
+		return
+	}
+	s.Throttled += resp.Throttled
+	s.ThrottledRequests++
+}
+
+// addResponse는 한 응답의 소요 시간과 오류 상태 코드/분류를 집계합니다
+func (s *Summary) addResponse(endpoint string, resp *client.APIResponse) {
+	if resp == nil {
+		return
+	}
+	s.Endpoints[endpoint] = append(s.Endpoints[endpoint], resp.Elapsed.Seconds())
+	if err := resp.Err(); err != nil {
+		s.StatusErrors[strconv.Itoa(resp.StatusCode)]++
+		s.Categories[string(client.Classify(err))]++
+	}
+}
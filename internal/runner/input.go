@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadItems는 입력 파일에서 protect/reveal 항목을 읽습니다
+// 형식은 확장자로 정합니다:
+//   - .csv: 첫 줄이 헤더인 CSV (data 열 필수, username/external_version 열 선택)
+//   - .jsonl, .ndjson: 줄마다 {"data": ..., "username": ..., "external_version": ...}
+//   - 그 외: 줄마다 데이터 하나 (빈 줄과 #으로 시작하는 줄은 건너뜀)
+func LoadItems(path string) ([]Item, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer f.Close()
+
+	var items []Item
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		items, err = readCSVItems(f)
+	case ".jsonl", ".ndjson":
+		items, err = readJSONItems(f)
+	default:
+		items, err = readTextItems(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%s: no input items", path)
+	}
+	return items, nil
+}
+
+// readCSVItems는 헤더가 있는 CSV에서 항목을 읽습니다
+func readCSVItems(r io.Reader) ([]Item, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	col := map[string]int{"data": -1, "username": -1, "external_version": -1}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := col[name]; ok {
+			col[name] = i
+		}
+	}
+	if col["data"] < 0 {
+		return nil, fmt.Errorf("CSV header must contain a data column")
+	}
+	field := func(record []string, name string) string {
+		if i := col[name]; i >= 0 && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var items []Item
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		item := Item{
+			Data:            field(record, "data"),
+			Username:        field(record, "username"),
+			ExternalVersion: field(record, "external_version"),
+		}
+		if item.Data == "" {
+			return nil, fmt.Errorf("line %d: data must not be empty", line)
+		}
+		items = append(items, item)
+	}
+}
+
+// readJSONItems는 JSON Lines에서 항목을 읽습니다
+func readJSONItems(r io.Reader) ([]Item, error) {
+	var items []Item
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var v struct {
+			Data            string `json:"data"`
+			Username        string `json:"username"`
+			ExternalVersion string `json:"external_version"`
+		}
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if v.Data == "" {
+			return nil, fmt.Errorf("line %d: data must not be empty", line)
+		}
+		items = append(items, Item{Data: v.Data, Username: v.Username, ExternalVersion: v.ExternalVersion})
+	}
+	return items, scanner.Err()
+}
+
+// readTextItems는 줄마다 데이터 하나인 텍스트 파일에서 항목을 읽습니다
+func readTextItems(r io.Reader) ([]Item, error) {
+	var items []Item
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		items = append(items, Item{Data: text})
+	}
+	return items, scanner.Err()
+}
@@ -0,0 +1,106 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeInput은 임시 디렉터리에 입력 파일을 만들고 경로를 반환합니다
+func writeInput(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []Item
+	}{
+		{
+			name:    "csv with per-item fields",
+			file:    "items.csv",
+			content: "Data, username ,external_version\n1111,alice,v1\n2222,,\n3333,bob\n",
+			want: []Item{
+				{Data: "1111", Username: "alice", ExternalVersion: "v1"},
+				{Data: "2222"},
+				{Data: "3333", Username: "bob"},
+			},
+		},
+		{
+			name:    "csv with data column only",
+			file:    "items.CSV",
+			content: "id,data\n1, 4444 \n",
+			want:    []Item{{Data: "4444"}},
+		},
+		{
+			name:    "jsonl with per-item fields and blank lines",
+			file:    "items.jsonl",
+			content: "{\"data\":\"1111\",\"username\":\"alice\",\"external_version\":\"v1\"}\n\n  \n{\"data\":\"2222\"}\n",
+			want: []Item{
+				{Data: "1111", Username: "alice", ExternalVersion: "v1"},
+				{Data: "2222"},
+			},
+		},
+		{
+			name:    "ndjson",
+			file:    "items.ndjson",
+			content: "{\"data\":\"5555\",\"username\":\"auditor\"}\n",
+			want:    []Item{{Data: "5555", Username: "auditor"}},
+		},
+		{
+			name:    "text with comments and blank lines",
+			file:    "items.txt",
+			content: "# header comment\n1111\n\n   \n  2222  \n  # indented comment\n3333",
+			want:    []Item{{Data: "1111"}, {Data: "2222"}, {Data: "3333"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadItems(writeInput(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("LoadItems: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadItems = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadItemsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"csv missing data column", "items.csv", "value,username\n1111,alice\n", "must contain a data column"},
+		{"csv empty header", "items.csv", "", "failed to read CSV header"},
+		{"csv empty data", "items.csv", "data,username\n1111,alice\n ,bob\n", "line 3: data must not be empty"},
+		{"csv header only", "items.csv", "data\n", "no input items"},
+		{"jsonl empty data", "items.jsonl", "{\"data\":\"1111\"}\n{\"username\":\"alice\"}\n", "line 2: data must not be empty"},
+		{"jsonl invalid json", "items.jsonl", "{\"data\":\"1111\"}\n\n{data}\n", "line 3:"},
+		{"text only comments", "items.txt", "# nothing\n\n", "no input items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadItems(writeInput(t, tt.file, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadItems err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadItems(filepath.Join(t.TempDir(), "missing.txt")); err == nil || !strings.Contains(err.Error(), "failed to open input file") {
+		t.Errorf("missing file err = %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package runner
+
+import "github.com/sjrhee/crdp-cli-go/internal/client"
+
+// PhaseSummary는 요청별 연결 단계 소요 시간(초 단위)의 집계입니다
+// DNS/Connect/TLS는 새로 연결한 요청에서만 수집합니다
+type PhaseSummary struct {
+	Requests    int // 단계 시간이 측정된 요청 수
+	NewConns    int // 새 연결을 사용한 요청 수
+	ReusedConns int // keep-alive 연결을 재사용한 요청 수
+
+	DNS      []float64
+	Connect  []float64
+	TLS      []float64
+	TTFB     []float64
+	BodyRead []float64
+}
+
+// PhaseStats는 연결 단계별 지연 시간 통계입니다
+type PhaseStats struct {
+	DNS      LatencyStats
+	Connect  LatencyStats
+	TLS      LatencyStats
+	TTFB     LatencyStats
+	BodyRead LatencyStats
+}
+
+// Stats는 연결 단계별 지연 시간 통계를 반환합니다
+func (p *PhaseSummary) Stats() PhaseStats {
+	return PhaseStats{
+		DNS:      ComputeLatencyStats(p.DNS),
+		Connect:  ComputeLatencyStats(p.Connect),
+		TLS:      ComputeLatencyStats(p.TLS),
+		TTFB:     ComputeLatencyStats(p.TTFB),
+		BodyRead: ComputeLatencyStats(p.BodyRead),
+	}
+}
+
+// add는 응답의 단계 시간을 집계에 반영합니다 (측정되지 않은 응답은 무시)
+func (p *PhaseSummary) add(resp *client.APIResponse) {
+	if resp == nil || resp.Timing == nil {
+		return
+	}
+	t := resp.Timing
+	p.Requests++
+	if t.Reused {
+		p.ReusedConns++
+	} else {
+		p.NewConns++
+		if t.DNS > 0 {
+			p.DNS = append(p.DNS, t.DNS.Seconds())
+		}
+		p.Connect = append(p.Connect, t.Connect.Seconds())
+		if t.TLS > 0 {
+			p.TLS = append(p.TLS, t.TLS.Seconds())
+		}
+	}
+	p.TTFB = append(p.TTFB, t.TTFB.Seconds())
+	p.BodyRead = append(p.BodyRead, t.BodyRead.Seconds())
+}
@@ -0,0 +1,93 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 여러 정책 실행 방식 (Options.PolicyMode)
+const (
+	PolicyModeMix     = "mix"     // 반복(배치)마다 가중치 비율로 정책을 섞어 선택
+	PolicyModeIterate = "iterate" // 정책마다 모든 항목을 차례로 실행
+)
+
+// PolicyWeight는 여러 정책 실행에 사용할 정책과 가중치입니다
+type PolicyWeight struct {
+	Name   string
+	Weight int // mix 모드에서 선택 비율 (1 이상)
+}
+
+// ParsePolicies는 "P03:3,P04"와 같은 정책 목록을 해석합니다 (가중치를 생략하면 1)
+func ParsePolicies(s string) ([]PolicyWeight, error) {
+	var policies []PolicyWeight
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, weight, hasWeight := strings.Cut(part, ":")
+		p := PolicyWeight{Name: strings.TrimSpace(name), Weight: 1}
+		if p.Name == "" {
+			return nil, fmt.Errorf("invalid policy %q (expected name or name:weight)", part)
+		}
+		if hasWeight {
+			w, err := strconv.Atoi(strings.TrimSpace(weight))
+			if err != nil || w < 1 {
+				return nil, fmt.Errorf("invalid weight in %q (expected a positive integer)", part)
+			}
+			p.Weight = w
+		}
+		policies = append(policies, p)
+	}
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("no policies in %q", s)
+	}
+	return policies, nil
+}
+
+// assignPolicies는 반복(배치) 목록에 정책을 지정합니다
+// iterate 모드는 정책마다 모든 반복을 복사하고, mix 모드는 가중치 비율대로 반복마다 정책을 고릅니다
+func assignPolicies(jobs [][]Item, policies []PolicyWeight, mode string) [][]Item {
+	if len(policies) == 0 {
+		return jobs
+	}
+	withPolicy := func(batch []Item, name string) []Item {
+		out := make([]Item, len(batch))
+		for i, item := range batch {
+			item.Policy = name
+			out[i] = item
+		}
+		return out
+	}
+
+	if mode == PolicyModeIterate {
+		out := make([][]Item, 0, len(jobs)*len(policies))
+		for _, p := range policies {
+			for _, batch := range jobs {
+				out = append(out, withPolicy(batch, p.Name))
+			}
+		}
+		return out
+	}
+
+	// smooth weighted round-robin: 가중치 비율을 지키면서 같은 정책이 몰리지 않게 섞음
+	out := make([][]Item, len(jobs))
+	current := make([]int, len(policies))
+	total := 0
+	for _, p := range policies {
+		total += p.Weight
+	}
+	for i, batch := range jobs {
+		best := 0
+		for k, p := range policies {
+			current[k] += p.Weight
+			if current[k] > current[best] {
+				best = k
+			}
+		}
+		current[best] -= total
+		out[i] = withPolicy(batch, policies[best].Name)
+	}
+	return out
+}
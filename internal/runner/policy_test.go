@@ -0,0 +1,133 @@
+package runner
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParsePolicies(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []PolicyWeight
+		wantErr bool
+	}{
+		{"P03", []PolicyWeight{{"P03", 1}}, false},
+		{"P03:3, P04 ,P05:1", []PolicyWeight{{"P03", 3}, {"P04", 1}, {"P05", 1}}, false},
+		{" P03 : 2 ,", []PolicyWeight{{"P03", 2}}, false},
+		{"", nil, true},
+		{" , ", nil, true},
+		{":3", nil, true},
+		{"P03:0", nil, true},
+		{"P03:-1", nil, true},
+		{"P03:x", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePolicies(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePolicies(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParsePolicies(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// testJobs는 항목 하나짜리 반복 n개를 만듭니다
+func testJobs(n int) [][]Item {
+	jobs := make([][]Item, n)
+	for i := range jobs {
+		jobs[i] = []Item{{Data: strconv.Itoa(i)}}
+	}
+	return jobs
+}
+
+// jobPolicies는 반복마다 지정된 정책 이름을 반환합니다
+func jobPolicies(jobs [][]Item) []string {
+	names := make([]string, len(jobs))
+	for i, batch := range jobs {
+		names[i] = batch[0].Policy
+	}
+	return names
+}
+
+func TestAssignPoliciesMix(t *testing.T) {
+	policies := []PolicyWeight{{"A", 3}, {"B", 1}, {"C", 1}}
+	tests := []struct {
+		jobs int
+		want map[string]int
+	}{
+		{5, map[string]int{"A": 3, "B": 1, "C": 1}},
+		{100, map[string]int{"A": 60, "B": 20, "C": 20}},
+		{3, map[string]int{"A": 2, "B": 1}},
+	}
+	for _, tt := range tests {
+		jobs := assignPolicies(testJobs(tt.jobs), policies, PolicyModeMix)
+		if len(jobs) != tt.jobs {
+			t.Fatalf("%d jobs: got %d jobs back", tt.jobs, len(jobs))
+		}
+		got := make(map[string]int)
+		for _, name := range jobPolicies(jobs) {
+			got[name]++
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%d jobs: policy counts = %v, want %v", tt.jobs, got, tt.want)
+		}
+	}
+
+	// 같은 정책이 몰리지 않게 섞임
+	if got, want := jobPolicies(assignPolicies(testJobs(5), policies, PolicyModeMix)), []string{"A", "B", "A", "C", "A"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("mix order = %v, want %v", got, want)
+	}
+}
+
+func TestAssignPoliciesIterate(t *testing.T) {
+	policies := []PolicyWeight{{"A", 3}, {"B", 1}}
+	jobs := assignPolicies(testJobs(3), policies, PolicyModeIterate)
+	if got, want := jobPolicies(jobs), []string{"A", "A", "A", "B", "B", "B"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("iterate policies = %v, want %v", got, want)
+	}
+	for i, batch := range jobs {
+		if want := strconv.Itoa(i % 3); batch[0].Data != want {
+			t.Errorf("job %d data = %q, want %q", i, batch[0].Data, want)
+		}
+	}
+}
+
+func TestAssignPoliciesKeepsInput(t *testing.T) {
+	in := [][]Item{{{Data: "1", Policy: "orig"}, {Data: "2", Policy: "orig"}}}
+	out := assignPolicies(in, []PolicyWeight{{"A", 1}}, PolicyModeMix)
+	if in[0][0].Policy != "orig" {
+		t.Errorf("input batch was modified: %+v", in[0])
+	}
+	for _, item := range out[0] {
+		if item.Policy != "A" {
+			t.Errorf("bulk item policy = %q, want every item in the batch to get %q", item.Policy, "A")
+		}
+	}
+	if got := assignPolicies(in, nil, PolicyModeMix); !reflect.DeepEqual(got, in) {
+		t.Errorf("without policies = %v, want the jobs unchanged", got)
+	}
+}
+
+func TestOptionsTotal(t *testing.T) {
+	policies := []PolicyWeight{{"A", 3}, {"B", 1}}
+	tests := []struct {
+		name string
+		opts Options
+		want int
+	}{
+		{"iterations", Options{Iterations: 10}, 10},
+		{"items", Options{Iterations: 10, Items: []Item{{Data: "1"}, {Data: "2"}}}, 2},
+		{"mix", Options{Iterations: 10, Policies: policies, PolicyMode: PolicyModeMix}, 10},
+		{"iterate", Options{Iterations: 10, Policies: policies, PolicyMode: PolicyModeIterate}, 20},
+		{"iterate items", Options{Items: []Item{{Data: "1"}}, Policies: policies, PolicyMode: PolicyModeIterate}, 2},
+		{"iterate without policies", Options{Iterations: 10, PolicyMode: PolicyModeIterate}, 10},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.Total(); got != tt.want {
+			t.Errorf("%s: Total = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
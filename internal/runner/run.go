@@ -0,0 +1,455 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/logging"
+	"github.com/sjrhee/crdp-cli-go/internal/tracing"
+)
+
+// Options는 전체 실행(Run) 설정을 나타냅니다
+type Options struct {
+	Iterations    int    // 처리할 데이터 개수
+	StartData     string // 시작 데이터 (숫자 문자열)
+	PayloadLength int    // 데이터 길이 (0이면 StartData 그대로 사용)
+	Bulk          bool   // bulk protect/reveal 사용 여부
+	BatchSize     int    // bulk 모드의 배치 크기
+	Workers       int    // 동시 실행 워커 수 (1 이하이면 순차 실행)
+
+	// Items가 있으면 StartData/Iterations/PayloadLength 대신 이 항목을 순서대로 사용합니다 (입력 파일)
+	// bulk 모드에서는 username이 바뀌는 곳에서 배치를 나눕니다
+	Items []Item
+
+	// Policies가 있으면 반복(배치)마다 요청 정책을 지정하고 정책별로 따로 집계합니다 (Summary.Policies)
+	// PolicyMode가 PolicyModeIterate이면 정책마다 모든 항목을 실행하고, 그 외에는 가중치 비율로 섞습니다
+	Policies   []PolicyWeight
+	PolicyMode string
+
+	// TokenValidator가 설정되면 성공한 반복의 토큰 형식을 검사하고 위반을 Summary.Violations에 집계합니다
+	// 결정성/무작위성 검사는 측정이 끝난 뒤 정책별 RepeatSampleSize개 표본만 다시 protect하여 집계합니다
+	TokenValidator *TokenValidator
+
+	// Tracer가 설정되면 반복마다 스팬을 만들고 protect/reveal 요청을 자식 스팬으로 기록합니다
+	Tracer *tracing.Tracer
+
+	// Logger는 실행 시작/종료와 반복 오류를 기록합니다 (nil이면 기록하지 않음)
+	// 반복 중 클라이언트 요청 로그에는 iteration 속성이 추가됩니다
+	Logger *slog.Logger
+
+	// Progress는 반복(또는 배치)이 끝날 때마다 호출됩니다.
+	// 워커 수와 관계없이 한 번에 하나씩 직렬로 호출됩니다.
+	Progress func(p Progress)
+}
+
+// Total은 실행이 처리할 전체 데이터 개수를 반환합니다 (iterate 모드는 정책마다 모든 항목을 실행)
+func (o Options) Total() int {
+	n := o.Iterations
+	if len(o.Items) > 0 {
+		n = len(o.Items)
+	}
+	if o.PolicyMode == PolicyModeIterate && len(o.Policies) > 0 {
+		n *= len(o.Policies)
+	}
+	return n
+}
+
+// Progress는 한 번의 반복(또는 배치) 진행 정보를 나타냅니다
+type Progress struct {
+	Index  int      // 1부터 시작하는 반복/배치 번호
+	Inputs []string // 이번 반복에 사용된 입력 데이터
+	Result *IterationResult
+	Err    error
+}
+
+// Summary는 전체 실행 결과의 집계를 나타냅니다
+type Summary struct {
+	Attempted  int           // 시도한 데이터 개수
+	Successful int           // protect/reveal 모두 2xx인 데이터 개수
+	Matched    int           // 복원 결과가 원본과 일치한 데이터 개수
+	Errors     int           // 전송 오류가 발생한 반복/배치 수
+	TotalTime  time.Duration // 전체 실행 시간
+	Latencies  []float64     // 반복/배치별 소요 시간 (초)
+	Phases     PhaseSummary  // 요청별 연결 단계 소요 시간 (client.SetPhaseTiming 활성화 시)
+
+	Throttled         time.Duration // 클라이언트 속도/동시 요청 제한으로 대기한 시간 합계
+	ThrottledRequests int           // 제한으로 대기한 요청 수
+
+	Timeline     []TimeBucket         // 실행 시작 후 1초 구간별 집계
+	Endpoints    map[string][]float64 // 엔드포인트별 요청 소요 시간 (초)
+	StatusErrors map[string]int       // 상태 코드별 오류 응답 수 (응답이 없으면 ErrorKind)
+	Categories   map[string]int       // 원인 분류별 실패 요청 수 (client.Classify: auth, policy_not_found, timeout 등)
+
+	TokensChecked int            // 토큰 검증을 실행한 토큰 수
+	Violations    map[string]int // 토큰 검증 항목별 위반 수 (same_length, prefix, deterministic 등)
+	RepeatChecked int            // 실행 후 다시 protect하여 결정성/무작위성을 비교한 표본 토큰 수
+
+	Policies []*PolicySummary // 정책별 집계 (Options.Policies 지정 시, 지정 순서)
+
+	start time.Time
+}
+
+// PolicySummary는 여러 정책 실행에서 한 정책의 집계입니다
+// iterate 모드의 TotalTime은 그 정책의 첫 반복 시작부터 마지막 반복 완료까지입니다
+type PolicySummary struct {
+	Name   string
+	Weight int
+	Summary
+}
+
+// Throughput은 초당 처리 데이터 개수를 반환합니다
+func (s *Summary) Throughput() float64 {
+	if s.TotalTime <= 0 {
+		return 0
+	}
+	return float64(s.Attempted) / s.TotalTime.Seconds()
+}
+
+// ErrorRate는 실패한 데이터의 비율(0~1)을 반환합니다
+func (s *Summary) ErrorRate() float64 {
+	if s.Attempted == 0 {
+		return 0
+	}
+	return float64(s.Attempted-s.Successful) / float64(s.Attempted)
+}
+
+// MatchRate는 원본과 일치한 데이터의 비율(0~1)을 반환합니다
+func (s *Summary) MatchRate() float64 {
+	if s.Attempted == 0 {
+		return 0
+	}
+	return float64(s.Matched) / float64(s.Attempted)
+}
+
+// LatencyStats는 수집된 지연 시간의 통계를 반환합니다
+func (s *Summary) LatencyStats() LatencyStats {
+	return ComputeLatencyStats(s.Latencies)
+}
+
+// add는 한 번의 반복(또는 배치) 결과를 집계에 반영합니다
+func (s *Summary) add(inputs []string, bulk bool, result *IterationResult, err error) {
+	s.Attempted += len(inputs)
+	s.addDetail(len(inputs), bulk, result, err)
+	if err != nil {
+		s.Errors++
+		return
+	}
+
+	s.Latencies = append(s.Latencies, result.TimeS)
+	s.Phases.add(result.ProtectResponse)
+	s.Phases.add(result.RevealResponse)
+	s.addThrottled(result.ProtectResponse)
+	s.addThrottled(result.RevealResponse)
+	s.TokensChecked += result.TokensChecked
+	if len(result.Violations) > 0 && s.Violations == nil {
+		s.Violations = make(map[string]int)
+	}
+	for _, name := range result.Violations {
+		s.Violations[name]++
+	}
+	if bulk {
+		if result.Success {
+			s.Successful += result.RestoredCount
+		}
+		s.Matched += result.MatchedCount
+		return
+	}
+
+	if result.Success {
+		s.Successful++
+	}
+	if result.Match {
+		s.Matched++
+	}
+}
+
+// addRepeats는 실행 후 반복 protect 검사 결과를 집계에 반영합니다
+func (s *Summary) addRepeats(check string, checked, violations int) {
+	s.RepeatChecked += checked
+	if violations > 0 {
+		if s.Violations == nil {
+			s.Violations = make(map[string]int)
+		}
+		s.Violations[check] += violations
+	}
+}
+
+// Run은 옵션에 따라 protect->reveal 반복을 실행하고 집계 결과를 반환합니다
+func Run(c *client.Client, opts Options) *Summary {
+	items := opts.Items
+	if len(items) == 0 {
+		for _, data := range GenerateDataSequence(FitLength(opts.StartData, opts.PayloadLength), opts.Iterations) {
+			items = append(items, Item{Data: data})
+		}
+	}
+
+	// 반복 단위(단일 모드: 1개, bulk 모드: 배치)로 분할
+	step := 1
+	if opts.Bulk && opts.BatchSize > 0 {
+		step = opts.BatchSize
+	}
+	var jobs [][]Item
+	for i := 0; i < len(items); {
+		end := i + 1
+		for end < len(items) && end-i < step && items[end].Username == items[i].Username && items[end].Policy == items[i].Policy {
+			end++
+		}
+		jobs = append(jobs, items[i:end])
+		i = end
+	}
+	jobs = assignPolicies(jobs, opts.Policies, opts.PolicyMode)
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	type job struct {
+		index  int
+		items  []Item
+		inputs []string
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = logging.Discard()
+	}
+	logger.Info("run started", "items", len(items), "iterations", len(jobs), "bulk", opts.Bulk,
+		"batch_size", opts.BatchSize, "workers", workers, "policy", c.Policy())
+	if len(opts.Policies) > 0 {
+		names := make([]string, len(opts.Policies))
+		for i, p := range opts.Policies {
+			names[i] = p.Name + ":" + strconv.Itoa(p.Weight)
+		}
+		logger.Info("multiple policies", "policies", strings.Join(names, ","), "mode", opts.PolicyMode)
+	}
+	if opts.TokenValidator != nil {
+		logger.Info("token validation enabled", "checks", opts.TokenValidator.String())
+	}
+
+	summary := newSummary(len(jobs))
+	byPolicy := make(map[string]*PolicySummary, len(opts.Policies))
+	for _, p := range opts.Policies {
+		ps := &PolicySummary{Name: p.Name, Weight: p.Weight, Summary: *newSummary(0)}
+		summary.Policies = append(summary.Policies, ps)
+		byPolicy[p.Name] = ps
+	}
+	jobCh := make(chan job)
+	var mu sync.Mutex
+	var samples []repeatSample // 실행 후 반복 protect 검사에 사용할 표본
+	sampled := make(map[string]int)
+	var wg sync.WaitGroup
+
+	start := summary.start
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobCh {
+				iterLogger := logger.With("iteration", j.index)
+				ctx := logging.NewContext(context.Background(), iterLogger)
+				ctx = client.WithMetadata(ctx, "iteration", strconv.Itoa(j.index))
+				ctx, span := opts.Tracer.Start(ctx, "crdp.iteration", tracing.KindInternal)
+				span.SetAttribute("crdp.iteration", j.index)
+				policy := j.items[0].Policy
+				if policy == "" {
+					policy = c.Policy()
+				}
+				span.SetAttribute("crdp.policy", policy)
+				span.SetAttribute("crdp.bulk", opts.Bulk)
+				span.SetAttribute("crdp.batch_size", len(j.inputs))
+
+				var result *IterationResult
+				var err error
+				if opts.Bulk {
+					result, err = RunBulkItemsContext(ctx, c, j.items)
+				} else {
+					result, err = RunItemContext(ctx, c, j.items[0])
+				}
+				if err == nil {
+					opts.TokenValidator.validate(j.items, opts.Bulk, result)
+				}
+				finishIterationSpan(span, result, err)
+				logIteration(iterLogger, opts.Bulk, result, err)
+
+				mu.Lock()
+				summary.add(j.inputs, opts.Bulk, result, err)
+				if err == nil {
+					samples = opts.TokenValidator.sample(samples, sampled, j.items, opts.Bulk, result)
+				}
+				if ps := byPolicy[j.items[0].Policy]; ps != nil {
+					ps.add(j.inputs, opts.Bulk, result, err)
+					ps.TotalTime = time.Since(ps.start)
+				}
+				if opts.Progress != nil {
+					opts.Progress(Progress{Index: j.index, Inputs: j.inputs, Result: result, Err: err})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	started := make(map[string]bool, len(byPolicy))
+	for i, batch := range jobs {
+		// iterate 모드에서 정책별 처리량이 맞도록 정책의 첫 반복을 보낼 때 시작 시각을 기록
+		if ps := byPolicy[batch[0].Policy]; ps != nil && !started[ps.Name] {
+			started[ps.Name] = true
+			mu.Lock()
+			ps.start = time.Now()
+			mu.Unlock()
+		}
+		inputs := make([]string, len(batch))
+		for k, item := range batch {
+			inputs[k] = item.Data
+		}
+		jobCh <- job{index: i + 1, items: batch, inputs: inputs}
+	}
+	close(jobCh)
+	wg.Wait()
+
+	summary.TotalTime = time.Since(start)
+
+	// 반복 protect 검사는 측정 구간이 끝난 뒤 표본에만 실행하여 지연 시간과 처리량에 포함하지 않음
+	if len(samples) > 0 {
+		ctx := logging.NewContext(context.Background(), logger)
+		checked, violations := opts.TokenValidator.checkRepeats(ctx, c, samples, logger)
+		for policy, n := range checked {
+			summary.addRepeats(opts.TokenValidator.Repeat, n, violations[policy])
+			if ps := byPolicy[policy]; ps != nil {
+				ps.addRepeats(opts.TokenValidator.Repeat, n, violations[policy])
+			}
+		}
+	}
+
+	logger.Info("run finished", "attempted", summary.Attempted, "successful", summary.Successful,
+		"matched", summary.Matched, "errors", summary.Errors, "duration_s", summary.TotalTime.Seconds())
+	return summary
+}
+
+// logIteration은 실패하거나 복원 결과가 일치하지 않은 반복을 기록합니다
+func logIteration(logger *slog.Logger, bulk bool, result *IterationResult, err error) {
+	switch {
+	case errors.Is(err, client.ErrCircuitOpen):
+		// 브레이커 상태 전환은 client가 기록하므로 거부된 반복은 debug로만 남김
+		logger.Debug("iteration rejected", "error", err)
+	case err != nil:
+		logger.Warn("iteration failed", "category", client.Classify(err), "error", err)
+	case !result.Success:
+		logger.Warn("iteration returned error status", "protect_status", result.ProtectResponse.StatusCode,
+			"reveal_status", result.RevealResponse.StatusCode, "category", failureCategory(result))
+	case !result.Match && bulk:
+		logger.Warn("revealed data mismatch", "restored", result.RestoredCount, "matched", result.MatchedCount)
+	case !result.Match:
+		logger.Warn("revealed data mismatch")
+	case len(result.Violations) > 0:
+		logger.Warn("token validation failed", "violations", strings.Join(result.Violations, ","))
+	default:
+		logger.Debug("iteration completed", "time_s", result.TimeS)
+	}
+}
+
+// failureCategory는 2xx가 아닌 첫 응답의 오류 분류를 반환합니다
+func failureCategory(result *IterationResult) client.ErrorCategory {
+	if err := result.ProtectResponse.Err(); err != nil {
+		return client.Classify(err)
+	}
+	return client.Classify(result.RevealResponse.Err())
+}
+
+// finishIterationSpan은 반복 결과를 스팬 상태와 속성에 기록하고 스팬을 종료합니다
+func finishIterationSpan(span *tracing.Span, result *IterationResult, err error) {
+	if span == nil {
+		return
+	}
+	switch {
+	case err != nil:
+		span.SetStatus(tracing.StatusError, err.Error())
+	case !result.Success:
+		span.SetStatus(tracing.StatusError, "non-2xx response")
+	case !result.Match:
+		span.SetStatus(tracing.StatusError, "revealed data mismatch")
+	default:
+		span.SetStatus(tracing.StatusOK, "")
+	}
+	if result != nil {
+		span.SetAttribute("crdp.match", result.Match)
+		span.SetAttribute("crdp.protect_status", result.ProtectResponse.StatusCode)
+		span.SetAttribute("crdp.reveal_status", result.RevealResponse.StatusCode)
+	}
+	span.Finish()
+}
+
+// maxInt64Digits는 int64로 안전하게 증가시킬 수 있는 최대 자릿수입니다
+const maxInt64Digits = 18
+
+// suffixModulus는 maxInt64Digits자리 뒷부분의 경우의 수(10^18)입니다
+const suffixModulus int64 = 1e18
+
+// GenerateDataSequence는 startData부터 1씩 증가하는 데이터 시퀀스를 생성합니다
+func GenerateDataSequence(startData string, count int) []string {
+	inputs := make([]string, 0, count)
+
+	// maxInt64Digits자를 넘는 긴 숫자 문자열은 앞부분을 고정하고 뒷부분만 증가
+	// 뒷부분이 10^maxInt64Digits에 이르면 0부터 다시 시작하여 길이(payload_length)를 유지
+	if len(startData) > maxInt64Digits && isDigits(startData) {
+		prefix := startData[:len(startData)-maxInt64Digits]
+		suffix, _ := strconv.ParseInt(startData[len(startData)-maxInt64Digits:], 10, 64)
+		for i := 0; i < count; i++ {
+			inputs = append(inputs, prefix+zeroPad((suffix+int64(i))%suffixModulus, maxInt64Digits))
+		}
+		return inputs
+	}
+
+	// Parse initial value as int64 for efficient incrementing
+	currentNum, err := strconv.ParseInt(startData, 10, 64)
+	if err != nil {
+
+		// If parse fails, just return the startData repeated
+		for i := 0; i < count; i++ {
+			inputs = append(inputs, startData)
+		}
+		return inputs
+	}
+
+	// Generate sequence by incrementing numeric value
+	for i := 0; i < count; i++ {
+		inputs = append(inputs, strconv.FormatInt(currentNum+int64(i), 10))
+	}
+	return inputs
+}
+
+// FitLength는 startData를 지정된 길이로 자르거나 '0'으로 채웁니다 (length가 0 이하이면 그대로 반환)
+func FitLength(startData string, length int) string {
+	if length <= 0 || len(startData) == length {
+		return startData
+	}
+	if len(startData) > length {
+		return startData[:length]
+	}
+	return startData + strings.Repeat("0", length-len(startData))
+}
+
+// isDigits는 문자열이 숫자로만 구성되어 있는지 확인합니다
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// zeroPad는 숫자를 지정된 자릿수로 왼쪽 0 채움합니다
+func zeroPad(n int64, width int) string {
+	s := strconv.FormatInt(n, 10)
+	if len(s) >= width {
+		return s
+	}
+	return strings.Repeat("0", width-len(s)) + s
+}
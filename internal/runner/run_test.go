@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateDataSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		start string
+		count int
+		want  []string
+	}{
+		{"int64", "1234567890123", 3, []string{"1234567890123", "1234567890124", "1234567890125"}},
+		{"leading zeros dropped", "007", 2, []string{"7", "8"}},
+		{"non-numeric repeated", "abc", 2, []string{"abc", "abc"}},
+		{"long prefix kept", "12345678901234567890", 2, []string{"12345678901234567890", "12345678901234567891"}},
+		{"long suffix carries into zeros", "99000000000000000099", 2, []string{"99000000000000000099", "99000000000000000100"}},
+		{"long suffix wraps", "12999999999999999999", 3, []string{"12999999999999999999", "12000000000000000000", "12000000000000000001"}},
+		{"zero count", "1", 0, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateDataSequence(tt.start, tt.count)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateDataSequence(%q, %d) = %v, want %v", tt.start, tt.count, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateDataSequenceKeepsPayloadLength(t *testing.T) {
+	start := FitLength(strings.Repeat("9", 40), 32)
+	for _, data := range GenerateDataSequence(start, 1000) {
+		if len(data) != 32 {
+			t.Fatalf("generated %q with length %d, want 32", data, len(data))
+		}
+	}
+}
+
+func TestFitLength(t *testing.T) {
+	tests := []struct {
+		start  string
+		length int
+		want   string
+	}{
+		{"12345", 0, "12345"},
+		{"12345", 3, "123"},
+		{"12345", 5, "12345"},
+		{"12345", 8, "12345000"},
+	}
+	for _, tt := range tests {
+		if got := FitLength(tt.start, tt.length); got != tt.want {
+			t.Errorf("FitLength(%q, %d) = %q, want %q", tt.start, tt.length, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package runner
 
 import (
+	"context"
 	"time"
 
 	"github.com/sjrhee/crdp-cli-go/internal/client"
@@ -16,29 +17,68 @@ type IterationResult struct {
 	TimeS           float64
 	Success         bool
 	Match           bool
-	RestoredCount   int // bulk용: 복원된 항목 수
-	MatchedCount    int // bulk용: 일치하는 항목 수
+	RestoredCount   int    // bulk용: 복원된 항목 수
+	MatchedCount    int    // bulk용: 일치하는 항목 수
+	ExternalVersion string // reveal에 보낸 external_version (단일 모드, 없으면 빈 문자열)
+
+	ProtectedTokens []string // bulk용: protect 응답의 토큰 (입력 순서)
+	TokensChecked   int      // 토큰 검증(Options.TokenValidator)을 실행한 토큰 수
+	Violations      []string // 위반한 토큰 검증 항목 이름 (토큰마다 중복 포함)
+}
+
+// Item은 한 번의 protect/reveal에 사용할 입력 데이터와 요청별 선택 필드입니다
+type Item struct {
+	Data string
+	// Policy는 protect/reveal 요청의 보호 정책입니다 (비어 있으면 클라이언트 정책)
+	Policy string
+	// Username은 protect/reveal 요청의 username입니다 (비어 있으면 클라이언트 기본값)
+	Username string
+	// ExternalVersion은 reveal에 보낼 external_version입니다
+	// 비어 있으면 protect 응답의 external_version, 그것도 없으면 클라이언트 기본값을 보냅니다
+	ExternalVersion string
 }
 
 // RunIteration은 한 번의 protect->reveal 반복을 실행합니다
 func RunIteration(c *client.Client, data string) (*IterationResult, error) {
+	return RunIterationContext(context.Background(), c, data)
+}
+
+// RunIterationContext는 ctx를 사용하여 한 번의 protect->reveal 반복을 실행합니다
+func RunIterationContext(ctx context.Context, c *client.Client, data string) (*IterationResult, error) {
+	return RunItemContext(ctx, c, Item{Data: data})
+}
+
+// RunItemContext는 항목의 username/external_version을 사용하여 한 번의 protect->reveal 반복을 실행합니다
+// protect 응답에 external_version이 있으면 reveal에 다시 전달합니다
+func RunItemContext(ctx context.Context, c *client.Client, item Item) (*IterationResult, error) {
 	start := time.Now()
+	data := item.Data
 
 	// Protect 요청
-	protectResp, err := c.Protect(data)
+	protectResp, err := c.ProtectWithOptions(ctx, data, client.RequestOptions{Policy: item.Policy, Username: item.Username})
 	if err != nil {
 		return nil, err
 	}
 
-	var protectedData string
+	var protectedData, version string
 	if protectResp.Body != nil {
 		if pd, ok := protectResp.Body["protected_data"].(string); ok {
 			protectedData = pd
 		}
+		if ev, ok := protectResp.Body["external_version"].(string); ok {
+			version = ev
+		}
+	}
+	if item.ExternalVersion != "" {
+		version = item.ExternalVersion
 	}
 
 	// Reveal 요청
-	revealResp, err := c.Reveal(protectedData)
+	revealResp, err := c.RevealWithOptions(ctx, protectedData, client.RequestOptions{
+		Policy:          item.Policy,
+		Username:        item.Username,
+		ExternalVersion: version,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +101,8 @@ func RunIteration(c *client.Client, data string) (*IterationResult, error) {
 		TimeS:           elapsed,
 		Success:         protectResp.StatusCode >= 200 && protectResp.StatusCode < 300 &&
 			revealResp.StatusCode >= 200 && revealResp.StatusCode < 300,
-		Match: restoredData == data,
+		Match:           restoredData == data,
+		ExternalVersion: version,
 	}
 
 	return result, nil
@@ -74,19 +115,48 @@ func IsSuccess(statusCode int) bool {
 
 // RunBulkIteration은 배치 단위로 bulk protect->reveal 반복을 실행합니다
 func RunBulkIteration(c *client.Client, batch []string) (*IterationResult, error) {
+	return RunBulkIterationContext(context.Background(), c, batch)
+}
+
+// RunBulkIterationContext는 ctx를 사용하여 배치 단위로 bulk protect->reveal 반복을 실행합니다
+func RunBulkIterationContext(ctx context.Context, c *client.Client, batch []string) (*IterationResult, error) {
+	items := make([]Item, len(batch))
+	for i, data := range batch {
+		items[i] = Item{Data: data}
+	}
+	return RunBulkItemsContext(ctx, c, items)
+}
+
+// RunBulkItemsContext는 항목의 username/external_version을 사용하여 bulk protect->reveal 반복을 실행합니다
+// bulk 요청에는 정책과 username을 하나만 보낼 수 있으므로 첫 항목의 값을 사용합니다
+func RunBulkItemsContext(ctx context.Context, c *client.Client, items []Item) (*IterationResult, error) {
 	start := time.Now()
+	batch := make([]string, len(items))
+	for i, item := range items {
+		batch[i] = item.Data
+	}
+	var opts client.RequestOptions
+	if len(items) > 0 {
+		opts.Policy = items[0].Policy
+		opts.Username = items[0].Username
+	}
 
 	// Bulk Protect 요청
-	protectResp, err := c.ProtectBulk(batch)
+	protectResp, err := c.ProtectBulkWithOptions(ctx, batch, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// protected_data_array 추출
-	protectedList := extractProtectedList(protectResp)
+	// protected_data_array 추출 (항목별 external_version 포함)
+	protectedList := extractProtectedItems(protectResp)
+	for i := range protectedList {
+		if i < len(items) && items[i].ExternalVersion != "" {
+			protectedList[i].ExternalVersion = items[i].ExternalVersion
+		}
+	}
 
 	// Bulk Reveal 요청
-	revealResp, err := c.RevealBulk(protectedList)
+	revealResp, err := c.RevealBulkWithOptions(ctx, protectedList, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -108,9 +178,15 @@ func RunBulkIteration(c *client.Client, batch []string) (*IterationResult, error
 		}
 	}
 
+	tokens := make([]string, len(protectedList))
+	for i, item := range protectedList {
+		tokens[i] = item.ProtectedData
+	}
+
 	result := &IterationResult{
 		ProtectResponse: protectResp,
 		RevealResponse:  revealResp,
+		ProtectedTokens: tokens,
 		TimeS:           elapsed,
 		Success:         protectResp.StatusCode >= 200 && protectResp.StatusCode < 300 &&
 			revealResp.StatusCode >= 200 && revealResp.StatusCode < 300,
@@ -122,26 +198,27 @@ func RunBulkIteration(c *client.Client, batch []string) (*IterationResult, error
 	return result, nil
 }
 
-// extractProtectedList는 protect 응답에서 protected_data_array를 추출합니다
-func extractProtectedList(resp *client.APIResponse) []string {
+// extractProtectedItems는 protect 응답에서 protected_data_array의 토큰과 external_version을 추출합니다
+func extractProtectedItems(resp *client.APIResponse) []client.ProtectedItem {
 	if resp == nil || resp.Body == nil {
-		return []string{}
+		return []client.ProtectedItem{}
 	}
 
 	// protected_data_array 형태 지원
 	if pdArray, ok := resp.Body["protected_data_array"].([]interface{}); ok {
-		result := make([]string, 0, len(pdArray))
+		result := make([]client.ProtectedItem, 0, len(pdArray))
 		for _, item := range pdArray {
 			if itemMap, ok := item.(map[string]interface{}); ok {
 				if pd, ok := itemMap["protected_data"].(string); ok {
-					result = append(result, pd)
+					ev, _ := itemMap["external_version"].(string)
+					result = append(result, client.ProtectedItem{ProtectedData: pd, ExternalVersion: ev})
 				}
 			}
 		}
 		return result
 	}
 
-	return []string{}
+	return []client.ProtectedItem{}
 }
 
 // extractRestoredList는 reveal 응답에서 data_array를 추출합니다
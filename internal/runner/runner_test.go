@@ -0,0 +1,96 @@
+package runner
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+// newTestClient는 가짜 CRDP 서버를 시작하고 연결한 클라이언트를 반환합니다
+func newTestClient(t *testing.T, cfg fakecrdp.Config) *client.Client {
+	t.Helper()
+	srv := fakecrdp.NewTestServer(cfg)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parse server URL: %v", err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatalf("parse server port: %v", err)
+	}
+	return client.NewClient(u.Hostname(), port, "P03", 5, false)
+}
+
+func TestRunSummary(t *testing.T) {
+	policies := []fakecrdp.Policy{
+		{Name: "plain"},
+		{Name: "masked", DefaultAccess: fakecrdp.AccessMasked},
+		{Name: "denied", DefaultAccess: fakecrdp.AccessDenied},
+	}
+	tests := []struct {
+		name       string
+		policy     string
+		errorRate  float64
+		bulk       bool
+		successful int
+		matched    int
+	}{
+		{"plain single", "plain", 0, false, 10, 10},
+		{"plain bulk", "plain", 0, true, 10, 10},
+		{"masked single", "masked", 0, false, 10, 0},
+		{"masked bulk", "masked", 0, true, 10, 0},
+		{"denied single", "denied", 0, false, 0, 0},
+		{"denied bulk", "denied", 0, true, 0, 0},
+		{"server errors", "plain", 1, false, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, fakecrdp.Config{Policies: policies, ErrorRate: tt.errorRate, Seed: 1})
+			items := make([]Item, 0, 10)
+			for _, data := range GenerateDataSequence("1234567890123", 10) {
+				items = append(items, Item{Data: data, Policy: tt.policy})
+			}
+
+			s := Run(c, Options{Items: items, Bulk: tt.bulk, BatchSize: 4, Workers: 2})
+			if s.Attempted != 10 || s.Successful != tt.successful || s.Matched != tt.matched {
+				t.Errorf("attempted/successful/matched = %d/%d/%d, want 10/%d/%d",
+					s.Attempted, s.Successful, s.Matched, tt.successful, tt.matched)
+			}
+			if s.Errors != 0 {
+				t.Errorf("errors = %d, want 0 (error responses are not transport errors)", s.Errors)
+			}
+			if want := float64(10-tt.successful) / 10; s.ErrorRate() != want {
+				t.Errorf("ErrorRate() = %v, want %v", s.ErrorRate(), want)
+			}
+			if want := float64(tt.matched) / 10; s.MatchRate() != want {
+				t.Errorf("MatchRate() = %v, want %v", s.MatchRate(), want)
+			}
+		})
+	}
+}
+
+func TestRunPolicySummary(t *testing.T) {
+	c := newTestClient(t, fakecrdp.Config{
+		Policies: []fakecrdp.Policy{{Name: "plain"}, {Name: "masked", DefaultAccess: fakecrdp.AccessMasked}},
+		Seed:     1,
+	})
+	s := Run(c, Options{
+		Iterations: 8,
+		StartData:  "1234567890123",
+		Policies:   []PolicyWeight{{Name: "plain", Weight: 1}, {Name: "masked", Weight: 1}},
+		PolicyMode: "mix",
+	})
+	if s.Attempted != 8 || s.Successful != 8 || s.Matched != 4 {
+		t.Fatalf("attempted/successful/matched = %d/%d/%d, want 8/8/4", s.Attempted, s.Successful, s.Matched)
+	}
+	want := map[string]int{"plain": 4, "masked": 0}
+	for _, ps := range s.Policies {
+		if ps.Attempted != 4 || ps.Matched != want[ps.Name] {
+			t.Errorf("policy %s: attempted/matched = %d/%d, want 4/%d", ps.Name, ps.Attempted, ps.Matched, want[ps.Name])
+		}
+	}
+}
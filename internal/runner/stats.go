@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"math"
+	"sort"
+)
+
+// LatencyStats는 지연 시간 샘플(초 단위)의 통계를 나타냅니다
+type LatencyStats struct {
+	Count  int
+	Mean   float64
+	Stddev float64
+	Min    float64
+	Max    float64
+	P50    float64
+	P90    float64
+	P95    float64
+	P99    float64
+}
+
+// ComputeLatencyStats는 지연 시간 샘플에서 평균, 표준편차, 백분위수를 계산합니다
+func ComputeLatencyStats(samples []float64) LatencyStats {
+	stats := LatencyStats{Count: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	// 원본 순서를 유지하기 위해 복사 후 정렬
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	stats.Mean = sum / float64(len(sorted))
+
+	// 표본 표준편차 (n-1)
+	if len(sorted) > 1 {
+		sq := 0.0
+		for _, v := range sorted {
+			d := v - stats.Mean
+			sq += d * d
+		}
+		stats.Stddev = math.Sqrt(sq / float64(len(sorted)-1))
+	}
+
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+	stats.P50 = percentile(sorted, 50)
+	stats.P90 = percentile(sorted, 90)
+	stats.P95 = percentile(sorted, 95)
+	stats.P99 = percentile(sorted, 99)
+	return stats
+}
+
+// percentile은 정렬된 샘플에서 nearest-rank 방식으로 백분위수를 구합니다
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,254 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+)
+
+// 토큰 검증 항목 이름 (token_checks 설정, Summary.Violations의 키)
+const (
+	CheckSameLength    = "same_length"   // 토큰 길이가 입력과 같음
+	CheckSameCharset   = "same_charset"  // 토큰 문자가 입력에 있는 문자 종류(숫자, 대문자, 소문자, 기타)에만 속함
+	CheckPrefix        = "prefix"        // 입력 앞 N자가 토큰에 그대로 남음 (prefix=N)
+	CheckSuffix        = "suffix"        // 입력 뒤 N자가 토큰에 그대로 남음 (suffix=N)
+	CheckNotInput      = "not_input"     // 토큰이 입력과 다름
+	CheckDeterministic = "deterministic" // 같은 값을 다시 protect하면 같은 토큰
+	CheckRandomized    = "randomized"    // 같은 값을 다시 protect하면 다른 토큰
+)
+
+// TokenValidator는 protect 응답 토큰의 형식 보존, 결정성, 입력과의 차이를 검사합니다
+type TokenValidator struct {
+	SameLength  bool
+	SameCharset bool
+	Prefix      int // 보존되어야 할 앞 글자 수 (0이면 검사 안 함)
+	Suffix      int // 보존되어야 할 뒤 글자 수 (0이면 검사 안 함)
+	NotInput    bool
+	// Repeat는 같은 값을 한 번 더 protect하여 비교하는 검사입니다 (CheckDeterministic, CheckRandomized 또는 빈 문자열)
+	Repeat string
+}
+
+// ParseTokenChecks는 "same_length", "prefix=6"과 같은 검증 항목 목록을 해석합니다
+// 한 항목에 쉼표로 여러 검사를 지정할 수 있으며, 목록이 비어 있으면 nil을 반환합니다
+func ParseTokenChecks(specs []string) (*TokenValidator, error) {
+	v := &TokenValidator{}
+	n := 0
+	for _, spec := range specs {
+		for _, s := range strings.Split(spec, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+			name, arg, hasArg := strings.Cut(s, "=")
+			name = strings.TrimSpace(name)
+			switch name {
+			case CheckPrefix, CheckSuffix:
+				count, err := strconv.Atoi(strings.TrimSpace(arg))
+				if !hasArg || err != nil || count < 1 {
+					return nil, fmt.Errorf("invalid token check %q (expected %s=N with N >= 1)", s, name)
+				}
+				if name == CheckPrefix {
+					v.Prefix = count
+				} else {
+					v.Suffix = count
+				}
+			case CheckSameLength, CheckSameCharset, CheckNotInput, CheckDeterministic, CheckRandomized:
+				if hasArg {
+					return nil, fmt.Errorf("invalid token check %q (%s takes no value)", s, name)
+				}
+				switch name {
+				case CheckSameLength:
+					v.SameLength = true
+				case CheckSameCharset:
+					v.SameCharset = true
+				case CheckNotInput:
+					v.NotInput = true
+				default:
+					if v.Repeat != "" && v.Repeat != name {
+						return nil, fmt.Errorf("token checks %s and %s cannot be combined", v.Repeat, name)
+					}
+					v.Repeat = name
+				}
+			default:
+				return nil, fmt.Errorf("unknown token check %q (expected same_length, same_charset, prefix=N, suffix=N, not_input, deterministic or randomized)", s)
+			}
+			n++
+		}
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// String은 활성화된 검사 목록을 쉼표로 구분하여 반환합니다
+func (v *TokenValidator) String() string {
+	var names []string
+	if v.SameLength {
+		names = append(names, CheckSameLength)
+	}
+	if v.SameCharset {
+		names = append(names, CheckSameCharset)
+	}
+	if v.Prefix > 0 {
+		names = append(names, fmt.Sprintf("%s=%d", CheckPrefix, v.Prefix))
+	}
+	if v.Suffix > 0 {
+		names = append(names, fmt.Sprintf("%s=%d", CheckSuffix, v.Suffix))
+	}
+	if v.NotInput {
+		names = append(names, CheckNotInput)
+	}
+	if v.Repeat != "" {
+		names = append(names, v.Repeat)
+	}
+	return strings.Join(names, ",")
+}
+
+// Check는 입력과 토큰 한 쌍의 형식 검사를 실행하고 위반한 검사 이름을 반환합니다
+func (v *TokenValidator) Check(data, token string) []string {
+	in, out := []rune(data), []rune(token)
+	var violations []string
+	if v.SameLength && len(in) != len(out) {
+		violations = append(violations, CheckSameLength)
+	}
+	if v.SameCharset && !sameCharset(in, out) {
+		violations = append(violations, CheckSameCharset)
+	}
+	if v.Prefix > 0 {
+		n := minInt(v.Prefix, len(in))
+		if len(out) < n || string(out[:n]) != string(in[:n]) {
+			violations = append(violations, CheckPrefix)
+		}
+	}
+	if v.Suffix > 0 {
+		n := minInt(v.Suffix, len(in))
+		if len(out) < n || string(out[len(out)-n:]) != string(in[len(in)-n:]) {
+			violations = append(violations, CheckSuffix)
+		}
+	}
+	if v.NotInput && token == data {
+		violations = append(violations, CheckNotInput)
+	}
+	return violations
+}
+
+// CheckRepeat는 같은 값을 두 번 protect한 토큰을 비교하고 위반이면 검사 이름을 반환합니다
+func (v *TokenValidator) CheckRepeat(first, second string) string {
+	switch {
+	case v.Repeat == CheckDeterministic && first != second:
+		return CheckDeterministic
+	case v.Repeat == CheckRandomized && first == second:
+		return CheckRandomized
+	}
+	return ""
+}
+
+// RepeatSampleSize는 반복 protect 검사(deterministic, randomized)에 정책별로 사용하는 최대 토큰 수입니다
+const RepeatSampleSize = 100
+
+// repeatSample은 실행이 끝난 뒤 다시 protect할 입력과 실행 중 받은 토큰입니다
+type repeatSample struct {
+	item  Item
+	token string
+}
+
+// validate는 성공한 반복의 토큰 형식을 검사하여 result.TokensChecked와 result.Violations를 채웁니다
+// 요청을 보내지 않으므로 반복 소요 시간에 영향을 주지 않습니다
+func (v *TokenValidator) validate(items []Item, bulk bool, result *IterationResult) {
+	if v == nil || result == nil || !result.Success {
+		return
+	}
+	tokens := resultTokens(bulk, result)
+	n := minInt(len(items), len(tokens))
+	for i := 0; i < n; i++ {
+		result.Violations = append(result.Violations, v.Check(items[i].Data, tokens[i])...)
+	}
+	result.TokensChecked = n
+}
+
+// sample은 반복 protect 검사가 켜져 있으면 성공한 반복의 토큰을 정책별 RepeatSampleSize개까지 samples에 추가합니다
+func (v *TokenValidator) sample(samples []repeatSample, perPolicy map[string]int, items []Item, bulk bool, result *IterationResult) []repeatSample {
+	if v == nil || v.Repeat == "" || result == nil || !result.Success {
+		return samples
+	}
+	tokens := resultTokens(bulk, result)
+	for i := 0; i < minInt(len(items), len(tokens)); i++ {
+		if perPolicy[items[i].Policy] >= RepeatSampleSize {
+			break
+		}
+		perPolicy[items[i].Policy]++
+		samples = append(samples, repeatSample{item: items[i], token: tokens[i]})
+	}
+	return samples
+}
+
+// checkRepeats는 측정 구간이 끝난 뒤 표본 값을 한 번씩 다시 protect하여 처음 받은 토큰과 비교하고,
+// 정책별로 비교한 토큰 수와 위반 수를 반환합니다 (다시 protect하지 못한 표본은 건너뜀)
+func (v *TokenValidator) checkRepeats(ctx context.Context, c *client.Client, samples []repeatSample, logger *slog.Logger) (checked, violations map[string]int) {
+	checked, violations = make(map[string]int), make(map[string]int)
+	for _, s := range samples {
+		resp, err := c.ProtectWithOptions(ctx, s.item.Data, client.RequestOptions{Policy: s.item.Policy, Username: s.item.Username})
+		if err == nil {
+			err = resp.Err()
+		}
+		if err != nil {
+			logger.Warn("token repeat check skipped", "check", v.Repeat, "error", err)
+			continue
+		}
+		token, _ := resp.Body["protected_data"].(string)
+		checked[s.item.Policy]++
+		if v.CheckRepeat(s.token, token) != "" {
+			violations[s.item.Policy]++
+		}
+	}
+	return checked, violations
+}
+
+// resultTokens는 반복 결과의 protect 토큰을 입력 순서대로 반환합니다
+func resultTokens(bulk bool, result *IterationResult) []string {
+	if bulk {
+		return result.ProtectedTokens
+	}
+	return []string{result.ProtectedToken}
+}
+
+// sameCharset은 토큰의 모든 문자가 입력에 있는 문자 종류에 속하는지 확인합니다
+func sameCharset(in, out []rune) bool {
+	classes := make(map[int]bool, 4)
+	for _, r := range in {
+		classes[charClass(r)] = true
+	}
+	for _, r := range out {
+		if !classes[charClass(r)] {
+			return false
+		}
+	}
+	return true
+}
+
+// charClass는 문자 종류(0: 숫자, 1: 대문자, 2: 소문자, 3: 기타)를 반환합니다
+func charClass(r rune) int {
+	switch {
+	case unicode.IsDigit(r):
+		return 0
+	case unicode.IsUpper(r):
+		return 1
+	case unicode.IsLower(r):
+		return 2
+	}
+	return 3
+}
+
+// minInt는 두 정수 중 작은 값을 반환합니다
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,163 @@
+package runner
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+func TestParseTokenChecks(t *testing.T) {
+	tests := []struct {
+		specs   []string
+		want    *TokenValidator
+		wantErr bool
+	}{
+		{nil, nil, false},
+		{[]string{" , "}, nil, false},
+		{[]string{"same_length,same_charset"}, &TokenValidator{SameLength: true, SameCharset: true}, false},
+		{[]string{"prefix=6", "suffix=4", "not_input"}, &TokenValidator{Prefix: 6, Suffix: 4, NotInput: true}, false},
+		{[]string{"deterministic", "deterministic"}, &TokenValidator{Repeat: CheckDeterministic}, false},
+		{[]string{"randomized"}, &TokenValidator{Repeat: CheckRandomized}, false},
+		{[]string{"deterministic,randomized"}, nil, true},
+		{[]string{"prefix"}, nil, true},
+		{[]string{"prefix=0"}, nil, true},
+		{[]string{"suffix=x"}, nil, true},
+		{[]string{"same_length=1"}, nil, true},
+		{[]string{"unknown"}, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTokenChecks(tt.specs)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTokenChecks(%q) error = %v, wantErr %v", tt.specs, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTokenChecks(%q) = %+v, want %+v", tt.specs, got, tt.want)
+		}
+	}
+}
+
+func TestTokenValidatorString(t *testing.T) {
+	v := &TokenValidator{SameLength: true, Prefix: 2, Suffix: 4, NotInput: true, Repeat: CheckDeterministic}
+	if got, want := v.String(), "same_length,prefix=2,suffix=4,not_input,deterministic"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	all := &TokenValidator{SameLength: true, SameCharset: true, Prefix: 2, Suffix: 4, NotInput: true}
+	tests := []struct {
+		data, token string
+		want        []string
+	}{
+		{"1234567890123", "1298301740123", nil},
+		{"1234567890123", "12983017401234", []string{CheckSameLength, CheckSuffix}},
+		{"1234567890123", "12a8301740123", []string{CheckSameCharset}},
+		{"1234567890123", "9934567890123", []string{CheckPrefix}},
+		{"1234567890123", "1234567890123", []string{CheckNotInput}},
+		{"AB-51234", "AB-71234", nil},
+		{"ab", "ab", []string{CheckNotInput}},
+	}
+	for _, tt := range tests {
+		if got := all.Check(tt.data, tt.token); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Check(%q, %q) = %v, want %v", tt.data, tt.token, got, tt.want)
+		}
+	}
+}
+
+func TestCheckRepeat(t *testing.T) {
+	tests := []struct {
+		repeat, first, second, want string
+	}{
+		{CheckDeterministic, "a", "a", ""},
+		{CheckDeterministic, "a", "b", CheckDeterministic},
+		{CheckRandomized, "a", "b", ""},
+		{CheckRandomized, "a", "a", CheckRandomized},
+		{"", "a", "b", ""},
+	}
+	for _, tt := range tests {
+		v := &TokenValidator{Repeat: tt.repeat}
+		if got := v.CheckRepeat(tt.first, tt.second); got != tt.want {
+			t.Errorf("%q.CheckRepeat(%q, %q) = %q, want %q", tt.repeat, tt.first, tt.second, got, tt.want)
+		}
+	}
+}
+
+func TestRunRepeatCheckAfterRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		randomized bool
+		repeat     string
+		violations int
+	}{
+		{"deterministic policy passes", false, CheckDeterministic, 0},
+		{"randomized policy fails deterministic", true, CheckDeterministic, 5},
+		{"randomized policy passes randomized", true, CheckRandomized, 0},
+		{"deterministic policy fails randomized", false, CheckRandomized, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := fakecrdp.New(fakecrdp.Config{Policies: []fakecrdp.Policy{{Name: "P03", Randomized: tt.randomized}}, Seed: 1})
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+			u, _ := url.Parse(srv.URL)
+			port, _ := strconv.Atoi(u.Port())
+			c := client.NewClient(u.Hostname(), port, "P03", 5, false)
+
+			protects := 0
+			s := Run(c, Options{
+				Iterations:     5,
+				StartData:      "1234567890123",
+				TokenValidator: &TokenValidator{SameLength: true, Repeat: tt.repeat},
+				Progress: func(Progress) {
+					protects = handler.Requests()["/v1/protect"]
+				},
+			})
+			if protects != 5 {
+				t.Errorf("protect requests during the run = %d, want 5 (repeat check must run after the measured loop)", protects)
+			}
+			if n := handler.Requests()["/v1/protect"]; n != 10 {
+				t.Errorf("protect requests in total = %d, want 10", n)
+			}
+			if s.TokensChecked != 5 || s.RepeatChecked != 5 {
+				t.Errorf("tokens checked/repeated = %d/%d, want 5/5", s.TokensChecked, s.RepeatChecked)
+			}
+			if got := s.Violations[tt.repeat]; got != tt.violations {
+				t.Errorf("%s violations = %d, want %d", tt.repeat, got, tt.violations)
+			}
+			if len(s.Latencies) != 5 {
+				t.Errorf("latencies = %d, want 5", len(s.Latencies))
+			}
+		})
+	}
+}
+
+func TestRepeatSampleSizePerPolicy(t *testing.T) {
+	v := &TokenValidator{Repeat: CheckDeterministic}
+	var samples []repeatSample
+	sampled := make(map[string]int)
+	for i := 0; i < RepeatSampleSize+10; i++ {
+		for _, policy := range []string{"A", "B"} {
+			items := []Item{{Data: strconv.Itoa(i), Policy: policy}}
+			result := &IterationResult{Success: true, ProtectedToken: "t" + strconv.Itoa(i)}
+			samples = v.sample(samples, sampled, items, false, result)
+		}
+	}
+	if sampled["A"] != RepeatSampleSize || sampled["B"] != RepeatSampleSize || len(samples) != 2*RepeatSampleSize {
+		t.Errorf("sampled A/B/total = %d/%d/%d, want %d each", sampled["A"], sampled["B"], len(samples), RepeatSampleSize)
+	}
+
+	failed := &IterationResult{Success: false, ProtectedToken: "t"}
+	if got := v.sample(nil, map[string]int{}, []Item{{Data: "1"}}, false, failed); got != nil {
+		t.Errorf("sample of a failed iteration = %v, want nil", got)
+	}
+	ok := &IterationResult{Success: true, ProtectedToken: "t"}
+	if got := (&TokenValidator{}).sample(nil, map[string]int{}, []Item{{Data: "1"}}, false, ok); got != nil {
+		t.Errorf("sample without a repeat check = %v, want nil", got)
+	}
+}
@@ -0,0 +1,93 @@
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+)
+
+// JUnit XML 요소 정의
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit은 테스트 스위트들을 JUnit XML 파일로 저장합니다
+func WriteJUnit(path string, suites []Suite) error {
+	doc := junitTestSuites{Name: "crdp-cli"}
+	total := 0.0
+	for _, s := range suites {
+		js := junitTestSuite{
+			Name:      s.Name,
+			Tests:     len(s.Cases),
+			Failures:  s.Failures(),
+			Errors:    s.Errors(),
+			Time:      formatSeconds(s.Time),
+			Timestamp: s.Timestamp.Format(time.RFC3339),
+		}
+		for _, c := range s.Cases {
+			jc := junitTestCase{Name: c.Name, Classname: c.Classname, Time: formatSeconds(c.Time)}
+			if f := c.Failure; f != nil {
+				problem := &junitProblem{Message: f.Message, Type: f.Type, Body: f.Detail}
+				if f.Error {
+					jc.Error = problem
+				} else {
+					jc.Failure = problem
+				}
+			}
+			js.Cases = append(js.Cases, jc)
+		}
+		doc.Tests += js.Tests
+		doc.Failures += js.Failures
+		doc.Errors += js.Errors
+		total += s.Time
+		doc.Suites = append(doc.Suites, js)
+	}
+	doc.Time = formatSeconds(total)
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	data = append(data, '\n')
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+// formatSeconds는 JUnit time 속성 형식(초)으로 변환합니다
+func formatSeconds(s float64) string {
+	return fmt.Sprintf("%.4f", s)
+}
@@ -0,0 +1,54 @@
+package testreport
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WriteTAP은 테스트 스위트들을 TAP version 13 파일로 저장합니다
+func WriteTAP(path string, suites []Suite) error {
+	total := 0
+	for _, s := range suites {
+		total += len(s.Cases)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "TAP version 13\n1..%d\n", total)
+
+	n := 0
+	for _, s := range suites {
+		for _, c := range s.Cases {
+			n++
+			if c.Failure == nil {
+				fmt.Fprintf(&buf, "ok %d - %s: %s\n", n, s.Name, c.Name)
+				continue
+			}
+
+			fmt.Fprintf(&buf, "not ok %d - %s: %s\n", n, s.Name, c.Name)
+			// YAML 진단 블록
+			fmt.Fprintf(&buf, "  ---\n  message: %q\n  severity: %s\n  type: %q\n", c.Failure.Message, severity(c.Failure), c.Failure.Type)
+			if c.Failure.Detail != "" {
+				buf.WriteString("  data: |\n")
+				for _, line := range strings.Split(c.Failure.Detail, "\n") {
+					fmt.Fprintf(&buf, "    %s\n", line)
+				}
+			}
+			buf.WriteString("  ...\n")
+		}
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write TAP report: %w", err)
+	}
+	return nil
+}
+
+// severity는 TAP 진단 블록의 severity 값을 반환합니다
+func severity(f *Failure) string {
+	if f.Error {
+		return "error"
+	}
+	return "fail"
+}
@@ -0,0 +1,229 @@
+package testreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/assertion"
+	"github.com/sjrhee/crdp-cli-go/internal/redact"
+	"github.com/sjrhee/crdp-cli-go/internal/runner"
+)
+
+// maxFailureDetails는 하나의 테스트 케이스에 첨부할 최대 실패 상세 수입니다
+const maxFailureDetails = 10
+
+// Suite는 한 번의 실행(또는 매트릭스 조합)에 해당하는 테스트 스위트입니다
+type Suite struct {
+	Name      string
+	Timestamp time.Time
+	Time      float64 // 초
+	Cases     []Case
+}
+
+// Case는 하나의 테스트 케이스(반복 그룹 또는 SLO 조건)입니다
+type Case struct {
+	Name      string
+	Classname string
+	Time      float64 // 초
+	Failure   *Failure
+}
+
+// Failure는 테스트 케이스의 실패 정보입니다
+type Failure struct {
+	Error   bool   // true이면 전송 오류, false이면 검증 실패
+	Message string // 한 줄 요약
+	Type    string // 실패 종류 (mismatch, status, transport, assertion)
+	Detail  string // 응답 본문 등 상세 내용
+}
+
+// Failures는 스위트의 실패(검증 실패) 수를 반환합니다
+func (s *Suite) Failures() int {
+	n := 0
+	for _, c := range s.Cases {
+		if c.Failure != nil && !c.Failure.Error {
+			n++
+		}
+	}
+	return n
+}
+
+// Errors는 스위트의 오류(전송 오류) 수를 반환합니다
+func (s *Suite) Errors() int {
+	n := 0
+	for _, c := range s.Cases {
+		if c.Failure != nil && c.Failure.Error {
+			n++
+		}
+	}
+	return n
+}
+
+// group은 하나의 테스트 케이스로 묶이는 반복들의 집계입니다
+type group struct {
+	first, last int // 반복/배치 번호 범위
+	items       int
+	time        float64
+	failures    int
+	errors      int
+	types       map[string]bool
+	details     []string
+}
+
+// Collector는 runner 진행 정보를 받아 반복 그룹별 테스트 케이스를 만듭니다
+type Collector struct {
+	bulk      bool
+	groupSize int
+	start     time.Time
+	groups    map[int]*group
+	redactor  *redact.Redactor
+}
+
+// NewCollector는 새로운 Collector를 생성합니다
+// 단일 모드에서는 groupSize개의 반복을, bulk 모드에서는 배치 하나를 한 케이스로 묶습니다
+func NewCollector(bulk bool, groupSize int) *Collector {
+	if bulk || groupSize < 1 {
+		groupSize = 1
+	}
+	return &Collector{
+		bulk:      bulk,
+		groupSize: groupSize,
+		start:     time.Now(),
+		groups:    make(map[int]*group),
+		redactor:  redact.New(redact.Mask),
+	}
+}
+
+// SetRedactor는 실패 상세의 입력 데이터와 응답 본문을 가리는 방식을 설정합니다 (기본값 redact.Mask)
+func (c *Collector) SetRedactor(r *redact.Redactor) {
+	c.redactor = r
+}
+
+// Record는 한 번의 반복(또는 배치) 결과를 그룹에 반영합니다
+// runner.Options.Progress에서 호출되며 직렬 호출을 전제로 합니다
+func (c *Collector) Record(p runner.Progress) {
+	key := (p.Index - 1) / c.groupSize
+	g, ok := c.groups[key]
+	if !ok {
+		g = &group{first: key*c.groupSize + 1, last: key*c.groupSize + 1, types: make(map[string]bool)}
+		c.groups[key] = g
+	}
+	if p.Index > g.last {
+		g.last = p.Index
+	}
+	g.items += len(p.Inputs)
+
+	if p.Err != nil {
+		g.errors++
+		g.types[runner.ErrorKind(p.Err)] = true
+		g.addDetail(fmt.Sprintf("#%03d %s: %v", p.Index, c.describeInputs(p.Inputs), p.Err))
+		return
+	}
+
+	r := p.Result
+	g.time += r.TimeS
+	statusOK := runner.IsSuccess(r.ProtectResponse.StatusCode) && runner.IsSuccess(r.RevealResponse.StatusCode)
+	if statusOK && r.Match {
+		return
+	}
+
+	g.failures++
+	kind := "mismatch"
+	if !statusOK {
+		kind = "status"
+	}
+	g.types[kind] = true
+	g.addDetail(fmt.Sprintf("#%03d %s: %s protect_status=%d reveal_status=%d\n  protect response: %s\n  reveal response: %s",
+		p.Index, c.describeInputs(p.Inputs), kind, r.ProtectResponse.StatusCode, r.RevealResponse.StatusCode,
+		bodyString(c.redactor.Map(r.ProtectResponse.Body)), bodyString(c.redactor.Map(r.RevealResponse.Body))))
+}
+
+// addDetail은 최대 개수 이내에서 실패 상세를 추가합니다
+func (g *group) addDetail(detail string) {
+	if len(g.details) < maxFailureDetails {
+		g.details = append(g.details, detail)
+	}
+}
+
+// Suite는 수집된 그룹과 SLO 조건 결과로 테스트 스위트를 만듭니다
+func (c *Collector) Suite(name string, assertions []assertion.Result) Suite {
+	suite := Suite{Name: name, Timestamp: c.start, Time: time.Since(c.start).Seconds()}
+
+	keys := make([]int, 0, len(c.groups))
+	for k := range c.groups {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	for _, k := range keys {
+		g := c.groups[k]
+		tc := Case{Name: c.caseName(g), Classname: name, Time: g.time}
+		if g.failures > 0 || g.errors > 0 {
+			tc.Failure = &Failure{
+				Error:   g.failures == 0,
+				Message: fmt.Sprintf("%d failed, %d errors", g.failures, g.errors),
+				Type:    joinTypes(g.types),
+				Detail:  strings.Join(g.details, "\n"),
+			}
+			if hidden := g.failures + g.errors - len(g.details); hidden > 0 {
+				tc.Failure.Detail += fmt.Sprintf("\n... and %d more", hidden)
+			}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	for _, r := range assertions {
+		tc := Case{Name: "assertion: " + r.Assertion.Expr, Classname: name + ".assertions"}
+		if !r.Passed {
+			tc.Failure = &Failure{
+				Message: fmt.Sprintf("assertion failed: %s (actual %s)", r.Assertion.Expr, r.ActualString()),
+				Type:    "assertion",
+			}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	return suite
+}
+
+// caseName은 그룹의 테스트 케이스 이름을 반환합니다
+func (c *Collector) caseName(g *group) string {
+	if c.bulk {
+		return fmt.Sprintf("batch #%03d (%d items)", g.first, g.items)
+	}
+	if g.first == g.last {
+		return fmt.Sprintf("iteration #%03d", g.first)
+	}
+	return fmt.Sprintf("iterations #%03d-#%03d", g.first, g.last)
+}
+
+// describeInputs는 실패 상세에 표시할 입력 데이터 설명을 반환합니다
+func (c *Collector) describeInputs(inputs []string) string {
+	if len(inputs) == 1 {
+		return "data=" + c.redactor.Value(inputs[0])
+	}
+	return fmt.Sprintf("batch of %d", len(inputs))
+}
+
+// bodyString은 응답 본문을 JSON 문자열로 반환합니다
+func bodyString(body map[string]interface{}) string {
+	if body == nil {
+		return "(empty)"
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Sprintf("%v", body)
+	}
+	return string(b)
+}
+
+// joinTypes는 실패 종류 집합을 정렬된 문자열로 반환합니다
+func joinTypes(types map[string]bool) string {
+	list := make([]string, 0, len(types))
+	for t := range types {
+		list = append(list, t)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
@@ -0,0 +1,188 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// OTLP/JSON 인코딩 구조 (ExportTraceServiceRequest)
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+// encodeOTLP는 스팬 목록을 OTLP/JSON 요청 본문으로 인코딩합니다
+func encodeOTLP(service string, spans []*Span) ([]byte, error) {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		attrs := make([]otlpKeyValue, 0, len(s.Attributes))
+		for _, a := range s.Attributes {
+			attrs = append(attrs, otlpKeyValue{Key: a.Key, Value: anyValue(a.Value)})
+		}
+		out = append(out, otlpSpan{
+			TraceID:           s.TraceID,
+			SpanID:            s.SpanID,
+			ParentSpanID:      s.ParentID,
+			Name:              s.Name,
+			Kind:              s.Kind,
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+			Attributes:        attrs,
+			Status:            otlpStatus{Code: s.StatusCode, Message: s.StatusMsg},
+		})
+	}
+
+	req := otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: []otlpKeyValue{{Key: "service.name", Value: anyValue(service)}}},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: service}, Spans: out}},
+	}}}
+	return json.Marshal(req)
+}
+
+// anyValue는 Go 값을 OTLP AnyValue로 변환합니다
+func anyValue(v interface{}) otlpAnyValue {
+	switch x := v.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &x}
+	case int:
+		s := strconv.Itoa(x)
+		return otlpAnyValue{IntValue: &s}
+	case int64:
+		s := strconv.FormatInt(x, 10)
+		return otlpAnyValue{IntValue: &s}
+	case float64:
+		return otlpAnyValue{DoubleValue: &x}
+	case bool:
+		return otlpAnyValue{BoolValue: &x}
+	}
+	s := fmt.Sprint(v)
+	return otlpAnyValue{StringValue: &s}
+}
+
+// FileExporter는 OTLP/JSON 요청을 한 줄씩 파일에 기록합니다
+// OpenTelemetry Collector file exporter/receiver와 같은 형식입니다
+type FileExporter struct {
+	service string
+	mu      sync.Mutex
+	f       *os.File
+}
+
+// NewFileExporter는 path 파일(덮어쓰기)에 기록하는 Exporter를 생성합니다
+func NewFileExporter(service, path string) (*FileExporter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace file: %w", err)
+	}
+	return &FileExporter{service: service, f: f}, nil
+}
+
+// Export는 Exporter 구현입니다
+func (e *FileExporter) Export(spans []*Span) error {
+	data, err := encodeOTLP(e.service, spans)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.f.Write(append(data, '\n'))
+	return err
+}
+
+// Close는 Exporter 구현입니다
+func (e *FileExporter) Close() error {
+	return e.f.Close()
+}
+
+// HTTPExporter는 OTLP/HTTP(JSON)로 collector에 스팬을 전송합니다
+type HTTPExporter struct {
+	service  string
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPExporter는 endpoint(예: http://localhost:4318/v1/traces)로 전송하는 Exporter를 생성합니다
+func NewHTTPExporter(service, endpoint string) *HTTPExporter {
+	return &HTTPExporter{
+		service:  service,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Export는 Exporter 구현입니다
+func (e *HTTPExporter) Export(spans []*Span) error {
+	data, err := encodeOTLP(e.service, spans)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close는 Exporter 구현입니다
+func (e *HTTPExporter) Close() error {
+	return nil
+}
@@ -0,0 +1,253 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 스팬 종류 (OTLP SpanKind)
+const (
+	KindInternal = 1
+	KindClient   = 3
+)
+
+// 스팬 상태 코드 (OTLP StatusCode)
+const (
+	StatusUnset = 0
+	StatusOK    = 1
+	StatusError = 2
+)
+
+// flushInterval은 버퍼에 쌓인 스팬을 내보내는 주기입니다
+const flushInterval = 2 * time.Second
+
+// maxBuffered는 주기와 관계없이 즉시 내보낼 버퍼 크기입니다
+const maxBuffered = 2048
+
+// Exporter는 종료된 스팬을 외부로 내보냅니다
+type Exporter interface {
+	Export(spans []*Span) error
+	Close() error
+}
+
+// Tracer는 스팬을 생성하고 종료된 스팬을 Exporter로 내보냅니다
+type Tracer struct {
+	service     string
+	exporter    Exporter
+	sampleRatio float64
+
+	mu     sync.Mutex
+	buffer []*Span
+	stop   chan struct{}
+	done   chan struct{}
+	errs   int
+}
+
+// NewTracer는 새로운 Tracer를 생성하고 주기적 내보내기를 시작합니다
+// sampleRatio는 루트 스팬의 샘플링 비율(0~1)입니다
+func NewTracer(service string, exporter Exporter, sampleRatio float64) *Tracer {
+	t := &Tracer{
+		service:     service,
+		exporter:    exporter,
+		sampleRatio: sampleRatio,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go t.loop()
+	return t
+}
+
+// loop는 주기적으로 버퍼를 내보냅니다
+func (t *Tracer) loop() {
+	defer close(t.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.flush()
+		case <-t.stop:
+			return
+		}
+	}
+}
+
+// flush는 버퍼에 쌓인 스팬을 내보냅니다
+func (t *Tracer) flush() {
+	t.mu.Lock()
+	spans := t.buffer
+	t.buffer = nil
+	t.mu.Unlock()
+
+	if len(spans) == 0 {
+		return
+	}
+	if err := t.exporter.Export(spans); err != nil {
+		t.mu.Lock()
+		t.errs++
+		t.mu.Unlock()
+	}
+}
+
+// Shutdown은 남은 스팬을 모두 내보내고 Exporter를 닫습니다
+// 내보내기에 실패한 적이 있으면 오류를 반환합니다
+func (t *Tracer) Shutdown() error {
+	if t == nil {
+		return nil
+	}
+	close(t.stop)
+	<-t.done
+	t.flush()
+
+	closeErr := t.exporter.Close()
+	t.mu.Lock()
+	errs := t.errs
+	t.mu.Unlock()
+	if errs > 0 {
+		return fmt.Errorf("failed to export spans %d time(s)", errs)
+	}
+	return closeErr
+}
+
+// Start는 ctx의 스팬을 부모로 하는(없으면 루트) 새 스팬을 시작합니다
+// nil Tracer에서는 아무 일도 하지 않고 nil 스팬을 반환합니다
+func (t *Tracer) Start(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	span := &Span{tracer: t, Name: name, Kind: kind, Start: time.Now()}
+	if parent := FromContext(ctx); parent != nil {
+		span.TraceID = parent.TraceID
+		span.ParentID = parent.SpanID
+		span.Sampled = parent.Sampled
+	} else {
+		span.TraceID = randomHex(16)
+		span.Sampled = sample(t.sampleRatio)
+	}
+	span.SpanID = randomHex(8)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// StartChild는 ctx에 스팬이 있을 때만 같은 Tracer로 자식 스팬을 시작합니다
+func StartChild(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	parent := FromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	return parent.tracer.Start(ctx, name, kind)
+}
+
+// spanKey는 context에 스팬을 저장하는 키입니다
+type spanKey struct{}
+
+// FromContext는 ctx에 저장된 현재 스팬을 반환합니다
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// Inject는 ctx의 현재 스팬을 W3C traceparent 헤더로 설정합니다
+func Inject(ctx context.Context, header http.Header) {
+	span := FromContext(ctx)
+	if span == nil {
+		return
+	}
+	flags := "00"
+	if span.Sampled {
+		flags = "01"
+	}
+	header.Set("traceparent", fmt.Sprintf("00-%s-%s-%s", span.TraceID, span.SpanID, flags))
+}
+
+// Attribute는 스팬 속성입니다 (값은 string, int, int64, float64, bool)
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span은 하나의 작업 구간입니다
+type Span struct {
+	tracer *Tracer
+
+	TraceID    string
+	SpanID     string
+	ParentID   string
+	Name       string
+	Kind       int
+	Start      time.Time
+	End        time.Time
+	Attributes []Attribute
+	StatusCode int
+	StatusMsg  string
+	Sampled    bool
+}
+
+// SetAttribute는 스팬 속성을 추가합니다
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.Attributes = append(s.Attributes, Attribute{Key: key, Value: value})
+}
+
+// SetStatus는 스팬 상태를 설정합니다
+func (s *Span) SetStatus(code int, msg string) {
+	if s == nil {
+		return
+	}
+	s.StatusCode = code
+	s.StatusMsg = msg
+}
+
+// Finish는 스팬을 종료하고 샘플링된 경우 내보내기 버퍼에 추가합니다
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.End = time.Now()
+	if !s.Sampled {
+		return
+	}
+
+	t := s.tracer
+	t.mu.Lock()
+	t.buffer = append(t.buffer, s)
+	full := len(t.buffer) >= maxBuffered
+	t.mu.Unlock()
+	if full {
+		t.flush()
+	}
+}
+
+// randomHex는 n바이트 난수를 16진수 문자열로 반환합니다
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// sample은 ratio 확률로 true를 반환합니다
+func sample(ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	const precision = 1 << 20
+	n, err := rand.Int(rand.Reader, big.NewInt(precision))
+	if err != nil {
+		return true
+	}
+	return float64(n.Int64()) < ratio*precision
+}
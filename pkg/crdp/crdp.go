@@ -0,0 +1,323 @@
+// Package crdp는 CipherTrust RESTful Data Protection(CRDP) 서버의
+// protect/reveal API를 Go 서비스에서 호출하기 위한 클라이언트입니다
+//
+// 기본 사용법:
+//
+//	c, err := crdp.New("https://crdp.example.com:32082",
+//		crdp.WithPolicy("P03"),
+//		crdp.WithJWT(crdp.StaticToken(os.Getenv("CRDP_JWT"))),
+//		crdp.WithRetry(2, 100*time.Millisecond),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	token, err := c.Protect(ctx, "1234567890123")
+//	if errors.Is(err, crdp.ErrUnauthorized) {
+//		// JWT 토큰 확인
+//	}
+//	plain, err := c.Reveal(ctx, token)
+//
+// 오류 응답은 *APIError, 전송 오류는 *TransportError로 반환되며
+// errors.Is로 ErrUnauthorized, ErrForbidden, ErrRateLimited 등과 비교할 수 있습니다
+package crdp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+	"github.com/sjrhee/crdp-cli-go/internal/crdpengine"
+)
+
+// DefaultPort는 주소에 포트가 없을 때 사용하는 CRDP 기본 포트입니다
+const DefaultPort = 32082
+
+// Client는 CRDP API 클라이언트입니다 (여러 고루틴에서 동시에 사용할 수 있음)
+type Client struct {
+	engine *client.Client
+	policy string
+	addr   string
+}
+
+// New는 addr의 CRDP 서버에 대한 클라이언트를 생성합니다
+// addr은 "host:port", "https://host:port" 또는 "http://host:port" 형식이며,
+// https://이면 TLS를 사용하고 포트가 없으면 DefaultPort를 사용합니다
+func New(addr string, opts ...Option) (*Client, error) {
+	s := settings{timeout: 10 * time.Second}
+	host, port, scheme, err := parseAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == "https" {
+		s.tls = true
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if s.policy == "" {
+		return nil, errors.New("crdp: protection policy is required (use WithPolicy)")
+	}
+	if s.timeout <= 0 {
+		return nil, errors.New("crdp: timeout must be positive")
+	}
+	if s.maxRetries < 0 {
+		return nil, errors.New("crdp: retries must not be negative")
+	}
+	es := crdpengine.Settings{
+		Host:               host,
+		Port:               port,
+		Policy:             s.policy,
+		TLS:                s.tls,
+		TLSConfig:          s.tlsConfig,
+		InsecureSkipVerify: s.insecure,
+		Timeout:            s.timeout,
+		MaxRetries:         s.maxRetries,
+		Backoff:            s.backoff,
+		Username:           s.username,
+		ExternalVersion:    s.version,
+		Logger:             s.logger,
+		Headers:            s.headers,
+	}
+	if s.tokens != nil {
+		es.TokenSource = s.tokens.Token
+	}
+	for _, mw := range s.middlewares {
+		es.Middlewares = append(es.Middlewares, client.Middleware(mw))
+	}
+	if s.hooks != nil {
+		es.Middlewares = append(es.Middlewares, hookMiddleware(*s.hooks))
+	}
+	engine, err := crdpengine.New(es)
+	if err != nil {
+		return nil, fmt.Errorf("crdp: %w", err)
+	}
+
+	scheme = "http"
+	if s.tls {
+		scheme = "https"
+	}
+	return &Client{
+		engine: engine,
+		policy: s.policy,
+		addr:   scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port)),
+	}, nil
+}
+
+// parseAddr는 주소를 host, port, scheme으로 나눕니다
+func parseAddr(addr string) (string, int, string, error) {
+	scheme := ""
+	if strings.Contains(addr, "://") {
+		u, err := url.Parse(addr)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("crdp: invalid address %q: %w", addr, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return "", 0, "", fmt.Errorf("crdp: invalid address %q: scheme must be http or https", addr)
+		}
+		scheme = u.Scheme
+		addr = u.Host
+	}
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		// 포트가 없는 주소
+		host, portStr = strings.Trim(addr, "[]"), strconv.Itoa(DefaultPort)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, "", fmt.Errorf("crdp: invalid port in address %q", addr)
+	}
+	if host == "" {
+		return "", 0, "", fmt.Errorf("crdp: missing host in address %q", addr)
+	}
+	return host, port, scheme, nil
+}
+
+// Addr은 클라이언트가 요청을 보내는 서버 주소를 반환합니다 (예: "https://host:32082")
+func (c *Client) Addr() string {
+	return c.addr
+}
+
+// Policy는 클라이언트의 보호 정책 이름을 반환합니다
+func (c *Client) Policy() string {
+	return c.policy
+}
+
+// ForPolicy는 연결, 인증, 재시도 등 설정을 공유하고 보호 정책만 다른 클라이언트를 반환합니다
+// 한 프로세스에서 여러 정책을 함께 사용할 때 정책마다 New를 호출하지 않아도 됩니다
+func (c *Client) ForPolicy(name string) *Client {
+	dup := *c
+	dup.policy = name
+	return &dup
+}
+
+// opts는 요청에 사용할 정책을 담은 요청 옵션입니다
+func (c *Client) opts() client.RequestOptions {
+	return client.RequestOptions{Policy: c.policy}
+}
+
+// Protected는 protect 결과 토큰과 그 토큰을 reveal할 때 다시 보낼 external_version입니다
+// 정책이 키 버전을 토큰 밖에 보관하지 않으면 ExternalVersion은 비어 있습니다
+type Protected struct {
+	Token           string
+	ExternalVersion string
+}
+
+// Protect는 data를 보호하고 토큰(protected_data)을 반환합니다
+// 외부 버전을 쓰는 정책이면 ProtectVersioned로 external_version도 함께 받아야 합니다
+func (c *Client) Protect(ctx context.Context, data string) (string, error) {
+	p, err := c.ProtectVersioned(ctx, data)
+	return p.Token, err
+}
+
+// ProtectVersioned는 data를 보호하고 토큰과 서버가 반환한 external_version을 함께 반환합니다
+func (c *Client) ProtectVersioned(ctx context.Context, data string) (Protected, error) {
+	body, err := c.call(func() (*client.APIResponse, error) {
+		return c.engine.ProtectWithOptions(ctx, data, c.opts())
+	})
+	if err != nil {
+		return Protected{}, err
+	}
+	token, ok := body["protected_data"].(string)
+	if !ok {
+		return Protected{}, invalidResponse("/v1/protect", "missing protected_data")
+	}
+	version, _ := body["external_version"].(string)
+	return Protected{Token: token, ExternalVersion: version}, nil
+}
+
+// Reveal은 토큰을 원본 데이터로 복원합니다
+// 정책에 따라 서버가 가린(masked) 값을 반환할 수 있습니다
+func (c *Client) Reveal(ctx context.Context, protectedData string) (string, error) {
+	return c.RevealVersioned(ctx, Protected{Token: protectedData})
+}
+
+// RevealVersioned는 p.ExternalVersion을 함께 보내 토큰을 복원합니다
+// ExternalVersion이 비어 있으면 WithExternalVersion으로 지정한 값을 사용합니다
+func (c *Client) RevealVersioned(ctx context.Context, p Protected) (string, error) {
+	opts := c.opts()
+	opts.ExternalVersion = p.ExternalVersion
+	body, err := c.call(func() (*client.APIResponse, error) {
+		return c.engine.RevealWithOptions(ctx, p.Token, opts)
+	})
+	if err != nil {
+		return "", err
+	}
+	data, ok := body["data"].(string)
+	if !ok {
+		return "", invalidResponse("/v1/reveal", "missing data")
+	}
+	return data, nil
+}
+
+// ProtectBulk는 여러 데이터를 한 번의 요청으로 보호하고 입력 순서대로 토큰을 반환합니다
+func (c *Client) ProtectBulk(ctx context.Context, data []string) ([]string, error) {
+	protected, err := c.ProtectBulkVersioned(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]string, len(protected))
+	for i, p := range protected {
+		tokens[i] = p.Token
+	}
+	return tokens, nil
+}
+
+// ProtectBulkVersioned는 여러 데이터를 한 번의 요청으로 보호하고
+// 입력 순서대로 토큰과 항목별 external_version을 반환합니다
+func (c *Client) ProtectBulkVersioned(ctx context.Context, data []string) ([]Protected, error) {
+	body, err := c.call(func() (*client.APIResponse, error) {
+		return c.engine.ProtectBulkWithOptions(ctx, data, c.opts())
+	})
+	if err != nil {
+		return nil, err
+	}
+	protected, ok := protectedItems(body)
+	if !ok || len(protected) != len(data) {
+		return nil, invalidResponse("/v1/protectbulk", fmt.Sprintf("expected %d protected_data items, got %d", len(data), len(protected)))
+	}
+	return protected, nil
+}
+
+// RevealBulk는 여러 토큰을 한 번의 요청으로 복원하고 입력 순서대로 데이터를 반환합니다
+func (c *Client) RevealBulk(ctx context.Context, protectedData []string) ([]string, error) {
+	protected := make([]Protected, len(protectedData))
+	for i, pd := range protectedData {
+		protected[i] = Protected{Token: pd}
+	}
+	return c.RevealBulkVersioned(ctx, protected)
+}
+
+// RevealBulkVersioned는 항목마다 external_version을 함께 보내 여러 토큰을 한 번의 요청으로 복원합니다
+// ExternalVersion이 빈 항목은 WithExternalVersion으로 지정한 값을 사용합니다
+func (c *Client) RevealBulkVersioned(ctx context.Context, protected []Protected) ([]string, error) {
+	items := make([]client.ProtectedItem, len(protected))
+	for i, p := range protected {
+		items[i] = client.ProtectedItem{ProtectedData: p.Token, ExternalVersion: p.ExternalVersion}
+	}
+	body, err := c.call(func() (*client.APIResponse, error) {
+		return c.engine.RevealBulkWithOptions(ctx, items, c.opts())
+	})
+	if err != nil {
+		return nil, err
+	}
+	values, ok := stringItems(body, "data_array", "data")
+	if !ok || len(values) != len(protected) {
+		return nil, invalidResponse("/v1/revealbulk", fmt.Sprintf("expected %d data items, got %d", len(protected), len(values)))
+	}
+	return values, nil
+}
+
+// call은 요청을 보내고 2xx가 아니면 *APIError로 바꿉니다
+func (c *Client) call(send func() (*client.APIResponse, error)) (map[string]interface{}, error) {
+	resp, err := send()
+	if err != nil {
+		// 전송 오류는 엔진이 *TransportError로 반환하고, 서킷 브레이커 거부는 ErrCircuitOpen 그대로 반환
+		return nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// stringItems는 body[arrayKey]의 각 객체에서 itemKey 문자열을 꺼냅니다
+func stringItems(body map[string]interface{}, arrayKey, itemKey string) ([]string, bool) {
+	items, ok := body[arrayKey].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		s, ok := m[itemKey].(string)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, s)
+	}
+	return out, true
+}
+
+// protectedItems는 protected_data_array의 각 객체에서 토큰과 external_version을 꺼냅니다
+func protectedItems(body map[string]interface{}) ([]Protected, bool) {
+	tokens, ok := stringItems(body, "protected_data_array", "protected_data")
+	if !ok {
+		return nil, false
+	}
+	items := body["protected_data_array"].([]interface{})
+	out := make([]Protected, len(tokens))
+	for i, token := range tokens {
+		version, _ := items[i].(map[string]interface{})["external_version"].(string)
+		out[i] = Protected{Token: token, ExternalVersion: version}
+	}
+	return out, true
+}
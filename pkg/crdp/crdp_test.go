@@ -0,0 +1,260 @@
+package crdp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+)
+
+func TestParseAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		host   string
+		port   int
+		scheme string
+	}{
+		{"crdp.example.com:9000", "crdp.example.com", 9000, ""},
+		{"crdp.example.com", "crdp.example.com", DefaultPort, ""},
+		{"https://crdp.example.com", "crdp.example.com", DefaultPort, "https"},
+		{"http://10.0.0.1:8080", "10.0.0.1", 8080, "http"},
+		{"[::1]:32083", "::1", 32083, ""},
+		{"[::1]", "::1", DefaultPort, ""},
+		{"::1", "::1", DefaultPort, ""},
+		{"https://[fe80::1]:9443", "fe80::1", 9443, "https"},
+		{"https://[fe80::1]", "fe80::1", DefaultPort, "https"},
+	}
+	for _, tt := range tests {
+		host, port, scheme, err := parseAddr(tt.addr)
+		if err != nil || host != tt.host || port != tt.port || scheme != tt.scheme {
+			t.Errorf("parseAddr(%q) = %q, %d, %q, %v, want %q, %d, %q", tt.addr, host, port, scheme, err, tt.host, tt.port, tt.scheme)
+		}
+	}
+
+	invalid := []struct {
+		addr    string
+		wantErr string
+	}{
+		{"ftp://crdp.example.com", "scheme must be http or https"},
+		{"crdp.example.com:0", "invalid port"},
+		{"crdp.example.com:70000", "invalid port"},
+		{"crdp.example.com:abc", "invalid port"},
+		{":32082", "missing host"},
+		{"https://:9000", "missing host"},
+	}
+	for _, tt := range invalid {
+		if _, _, _, err := parseAddr(tt.addr); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("parseAddr(%q) err = %v, want %q", tt.addr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestStringItems(t *testing.T) {
+	item := func(k, v string) map[string]interface{} { return map[string]interface{}{k: v} }
+	tests := []struct {
+		name string
+		body map[string]interface{}
+		want []string
+		ok   bool
+	}{
+		{"items", map[string]interface{}{"data_array": []interface{}{item("data", "a"), item("data", "b")}}, []string{"a", "b"}, true},
+		{"empty array", map[string]interface{}{"data_array": []interface{}{}}, []string{}, true},
+		{"missing array", map[string]interface{}{}, nil, false},
+		{"not an array", map[string]interface{}{"data_array": "a"}, nil, false},
+		{"item not an object", map[string]interface{}{"data_array": []interface{}{"a"}}, nil, false},
+		{"missing key", map[string]interface{}{"data_array": []interface{}{item("protected_data", "a")}}, nil, false},
+		{"not a string", map[string]interface{}{"data_array": []interface{}{map[string]interface{}{"data": 1.0}}}, nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := stringItems(tt.body, "data_array", "data")
+		if ok != tt.ok || (ok && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("%s: stringItems = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestHooks(t *testing.T) {
+	// 첫 요청은 503, 재시도는 본문을 늦게 보내는 200 응답
+	var mu sync.Mutex
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status":"Error","error":"busy"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"protected_data":`))
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`"5678010446818"}`))
+	}))
+	defer srv.Close()
+
+	var (
+		requests  []string
+		retries   []int
+		responses []ResponseInfo
+	)
+	c, err := New(srv.URL, WithPolicy("P03"), WithRetry(1, time.Millisecond), WithHooks(Hooks{
+		OnRequest:  func(endpoint string) { requests = append(requests, endpoint) },
+		OnRetry:    func(endpoint string, attempt int) { retries = append(retries, attempt) },
+		OnResponse: func(r ResponseInfo) { responses = append(responses, r) },
+	}))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := WithMetadata(context.Background(), "tenant", "team-a")
+	token, err := c.Protect(ctx, "1234567890123")
+	if err != nil || token != "5678010446818" {
+		t.Fatalf("Protect = %q, %v", token, err)
+	}
+
+	if !reflect.DeepEqual(requests, []string{"/v1/protect", "/v1/protect"}) {
+		t.Errorf("OnRequest endpoints = %v", requests)
+	}
+	if !reflect.DeepEqual(retries, []int{1}) {
+		t.Errorf("OnRetry attempts = %v, want [1]", retries)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("OnResponse called %d times, want 2", len(responses))
+	}
+	first, second := responses[0], responses[1]
+	if first.Attempt != 1 || first.StatusCode != 503 || second.Attempt != 2 || second.StatusCode != 200 {
+		t.Errorf("responses = %+v", responses)
+	}
+	if first.RequestID == "" || first.RequestID != second.RequestID {
+		t.Errorf("request IDs = %q, %q, want the same ID across retries", first.RequestID, second.RequestID)
+	}
+	if second.Metadata["tenant"] != "team-a" {
+		t.Errorf("Metadata = %v, want tenant=team-a", second.Metadata)
+	}
+	// OnResponse는 본문을 다 읽은 뒤 호출되므로 늦게 보낸 본문 시간이 포함됨
+	if second.Elapsed < 50*time.Millisecond {
+		t.Errorf("Elapsed = %v, want it to include the 50ms body read", second.Elapsed)
+	}
+}
+
+func TestHooksTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	addr := srv.URL
+	srv.Close()
+
+	var got []ResponseInfo
+	c, err := New(addr, WithPolicy("P03"), WithHooks(Hooks{OnResponse: func(r ResponseInfo) { got = append(got, r) }}))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := c.Protect(context.Background(), "1234567890123"); !errors.Is(err, ErrTransport) {
+		t.Fatalf("Protect err = %v, want ErrTransport", err)
+	}
+	if len(got) != 1 || got[0].Err == nil || got[0].StatusCode != 0 {
+		t.Errorf("OnResponse = %+v, want one transport error", got)
+	}
+}
+
+func TestForPolicy(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{Policies: []fakecrdp.Policy{{Name: "P03"}, {Name: "P05"}}})
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, err := New(srv.URL, WithPolicy("P03"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	p05 := c.ForPolicy("P05")
+	if c.Policy() != "P03" || p05.Policy() != "P05" || p05.Addr() != c.Addr() {
+		t.Errorf("policies = %q, %q, addrs = %q, %q", c.Policy(), p05.Policy(), c.Addr(), p05.Addr())
+	}
+
+	token, err := p05.Protect(ctx, "1234567890123")
+	if err != nil {
+		t.Fatalf("P05 Protect: %v", err)
+	}
+	if plain, err := p05.Reveal(ctx, token); err != nil || plain != "1234567890123" {
+		t.Errorf("P05 Reveal = %q, %v", plain, err)
+	}
+	// 정책마다 토큰 저장소가 다르므로 P05 토큰은 P03으로 복원할 수 없음
+	if _, err := c.Reveal(ctx, token); !errors.Is(err, ErrBadRequest) {
+		t.Errorf("P03 Reveal of a P05 token err = %v, want ErrBadRequest", err)
+	}
+	if _, err := c.ForPolicy("P99").Protect(ctx, "1234567890123"); !errors.Is(err, ErrBadRequest) {
+		t.Errorf("unknown policy err = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestExternalVersion(t *testing.T) {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{Policies: []fakecrdp.Policy{{Name: "P03", ExternalVersion: "v2"}}})
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, err := New(srv.URL, WithPolicy("P03"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	p, err := c.ProtectVersioned(ctx, "1234567890123")
+	if err != nil {
+		t.Fatalf("ProtectVersioned: %v", err)
+	}
+	if p.Token == "" || p.ExternalVersion != "v2" {
+		t.Fatalf("ProtectVersioned = %+v, want a token with version v2", p)
+	}
+	if plain, err := c.RevealVersioned(ctx, p); err != nil || plain != "1234567890123" {
+		t.Errorf("RevealVersioned = %q, %v", plain, err)
+	}
+	// 버전 없이 보내면 서버가 거부
+	if _, err := c.Reveal(ctx, p.Token); !errors.Is(err, ErrBadRequest) {
+		t.Errorf("Reveal without version err = %v, want ErrBadRequest", err)
+	}
+
+	data := []string{"1111111111111", "2222222222222"}
+	bulk, err := c.ProtectBulkVersioned(ctx, data)
+	if err != nil {
+		t.Fatalf("ProtectBulkVersioned: %v", err)
+	}
+	for i, p := range bulk {
+		if p.ExternalVersion != "v2" {
+			t.Errorf("item %d version = %q, want v2", i, p.ExternalVersion)
+		}
+	}
+	if values, err := c.RevealBulkVersioned(ctx, bulk); err != nil || !reflect.DeepEqual(values, data) {
+		t.Errorf("RevealBulkVersioned = %v, %v", values, err)
+	}
+
+	// 항목 버전이 비어 있으면 클라이언트 기본값(WithExternalVersion) 사용
+	withDefault, err := New(srv.URL, WithPolicy("P03"), WithExternalVersion("v2"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if values, err := withDefault.RevealBulk(ctx, []string{bulk[0].Token, bulk[1].Token}); err != nil || !reflect.DeepEqual(values, data) {
+		t.Errorf("RevealBulk with default version = %v, %v", values, err)
+	}
+}
+
+func TestProtectedItems(t *testing.T) {
+	body := map[string]interface{}{"protected_data_array": []interface{}{
+		map[string]interface{}{"protected_data": "a", "external_version": "v1"},
+		map[string]interface{}{"protected_data": "b"},
+	}}
+	got, ok := protectedItems(body)
+	want := []Protected{{Token: "a", ExternalVersion: "v1"}, {Token: "b"}}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("protectedItems = %+v, %v, want %+v", got, ok, want)
+	}
+	if _, ok := protectedItems(map[string]interface{}{}); ok {
+		t.Error("protectedItems without array should fail")
+	}
+}
@@ -0,0 +1,49 @@
+package crdp
+
+import (
+	"fmt"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+)
+
+// errors.Is로 비교할 수 있는 오류 종류입니다 (CLI와 같은 분류를 사용)
+var (
+	ErrBadRequest      = client.ErrBadRequest      // 400 (ErrPolicyNotFound, ErrInvalidData 포함)
+	ErrUnauthorized    = client.ErrUnauthorized    // 401
+	ErrForbidden       = client.ErrForbidden       // 403
+	ErrNotFound        = client.ErrNotFound        // 404
+	ErrPolicyNotFound  = client.ErrPolicyNotFound  // 보호 정책이 없다는 응답
+	ErrInvalidData     = client.ErrInvalidData     // 정책에 맞지 않는 데이터 형식이라는 응답
+	ErrRateLimited     = client.ErrRateLimited     // 429
+	ErrServer          = client.ErrServer          // 5xx
+	ErrTimeout         = client.ErrTimeout         // 응답 전 타임아웃 (TransportError)
+	ErrTransport       = client.ErrTransport       // 요청을 보냈지만 응답을 받지 못한 오류 (TransportError)
+	ErrRequestSetup    = client.ErrRequestSetup    // 요청을 보내기 전 실패, 예: TokenSource 오류 (SetupError)
+	ErrInvalidResponse = client.ErrInvalidResponse // 2xx이지만 기대한 필드가 없음
+	ErrCircuitOpen     = client.ErrCircuitOpen     // 서킷 브레이커가 열려 요청을 보내지 않음
+)
+
+// APIError는 서버가 2xx가 아닌 상태 코드로 응답한 오류입니다
+// Category는 상태 코드와 서버 메시지로 판단한 오류 분류입니다
+type APIError = client.APIError
+
+// TransportError는 응답을 받지 못한 오류입니다 (연결 실패, 타임아웃, TLS 오류 등)
+// Unwrap으로 원인 오류(context.DeadlineExceeded 등)와 비교할 수 있습니다
+type TransportError = client.TransportError
+
+// SetupError는 요청을 보내기 전에 실패한 오류입니다 (TokenSource 오류 등)
+// 서버와 통신하지 않았으므로 ErrTransport와 같지 않으며 재시도하지 않습니다
+type SetupError = client.SetupError
+
+// ErrorCategory는 실패 원인 분류입니다 (Classify의 반환값)
+type ErrorCategory = client.ErrorCategory
+
+// Classify는 Client 메서드가 반환한 오류를 분류합니다 (예: "auth", "policy_not_found", "timeout")
+func Classify(err error) ErrorCategory {
+	return client.Classify(err)
+}
+
+// invalidResponse는 ErrInvalidResponse를 감싼 오류를 반환합니다
+func invalidResponse(endpoint, detail string) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidResponse, endpoint, detail)
+}
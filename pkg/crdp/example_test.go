@@ -0,0 +1,141 @@
+package crdp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/fakecrdp"
+	"github.com/sjrhee/crdp-cli-go/pkg/crdp"
+)
+
+func ExampleNew() {
+	// 예제에서는 가짜 CRDP 서버를 사용합니다 (실제로는 "https://crdp.example.com:32082" 등)
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{})
+	defer srv.Close()
+
+	c, err := crdp.New(srv.URL,
+		crdp.WithPolicy("P03"),
+		crdp.WithRetry(2, 100*time.Millisecond),
+		crdp.WithTimeout(5*time.Second),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	ctx := context.Background()
+	token, err := c.Protect(ctx, "1234567890123")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	plain, err := c.Reveal(ctx, token)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(plain)
+	// Output: 1234567890123
+}
+
+func ExampleClient_ProtectBulk() {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{})
+	defer srv.Close()
+
+	c, err := crdp.New(srv.URL, crdp.WithPolicy("P03"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	ctx := context.Background()
+	tokens, err := c.ProtectBulk(ctx, []string{"1234567890123", "9876543210987"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	values, err := c.RevealBulk(ctx, tokens)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(len(tokens), strings.Join(values, ","))
+	// Output: 2 1234567890123,9876543210987
+}
+
+func ExampleClient_ForPolicy() {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{Policies: []fakecrdp.Policy{{Name: "P03"}, {Name: "P05"}}})
+	defer srv.Close()
+
+	c, err := crdp.New(srv.URL, crdp.WithPolicy("P03"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// 연결과 인증 설정을 공유하고 정책만 바꿈
+	p05 := c.ForPolicy("P05")
+	token, err := p05.Protect(context.Background(), "1234567890123")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	plain, _ := p05.Reveal(context.Background(), token)
+	fmt.Println(p05.Policy(), plain)
+	// Output: P05 1234567890123
+}
+
+func ExampleWithJWT() {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{RequireJWT: true})
+	defer srv.Close()
+
+	// 토큰 없이 보내면 ErrUnauthorized
+	c, _ := crdp.New(srv.URL, crdp.WithPolicy("P03"))
+	_, err := c.Protect(context.Background(), "1234567890123")
+	fmt.Println(errors.Is(err, crdp.ErrUnauthorized), crdp.Classify(err))
+
+	// TokenSourceFunc로 요청마다 토큰을 가져옴 (만료 전 갱신 등)
+	c, _ = crdp.New(srv.URL, crdp.WithPolicy("P03"),
+		crdp.WithJWT(crdp.TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return fakecrdp.SignToken("", "alice", time.Hour), nil
+		})))
+	_, err = c.Protect(context.Background(), "1234567890123")
+	fmt.Println(err)
+	// Output:
+	// true auth
+	// <nil>
+}
+
+func ExampleWithHooks() {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{})
+	defer srv.Close()
+
+	c, _ := crdp.New(srv.URL, crdp.WithPolicy("P03"), crdp.WithHooks(crdp.Hooks{
+		OnResponse: func(r crdp.ResponseInfo) {
+			fmt.Println(r.Endpoint, r.StatusCode, r.Metadata["tenant"])
+		},
+	}))
+	ctx := crdp.WithMetadata(context.Background(), "tenant", "team-a")
+	c.Protect(ctx, "1234567890123")
+	// Output: /v1/protect 200 team-a
+}
+
+func ExampleWithMiddleware() {
+	srv := fakecrdp.NewTestServer(fakecrdp.Config{})
+	defer srv.Close()
+
+	// 요청마다 정책과 데이터 개수를 헤더로 붙이는 미들웨어
+	tag := func(next http.RoundTripper) http.RoundTripper {
+		return crdp.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if info, ok := crdp.RequestInfoFrom(req.Context()); ok {
+				req.Header.Set("X-CRDP-Policy", info.Policy)
+				fmt.Println(info.Endpoint, info.Policy, info.Items)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+	c, _ := crdp.New(srv.URL, crdp.WithPolicy("P03"), crdp.WithMiddleware(tag))
+	c.ProtectBulk(context.Background(), []string{"1234567890123", "9876543210987"})
+	// Output: /v1/protectbulk P03 2
+}
@@ -0,0 +1,86 @@
+package crdp
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+)
+
+// Hooks는 요청 단위 이벤트를 받는 함수입니다 (nil인 함수는 호출하지 않음)
+// 여러 고루틴에서 동시에 호출될 수 있으며, 오래 걸리면 요청 처리도 늦어집니다
+type Hooks struct {
+	// OnRequest는 HTTP 요청 전송 직전에 호출됩니다 (재시도 포함)
+	OnRequest func(endpoint string)
+	// OnResponse는 응답 수신 또는 전송 오류 후 호출됩니다 (재시도 포함)
+	OnResponse func(ResponseInfo)
+	// OnRetry는 재시도 직전에 호출됩니다 (attempt는 1부터 시작)
+	OnRetry func(endpoint string, attempt int)
+}
+
+// ResponseInfo는 한 번의 HTTP 요청 결과입니다
+type ResponseInfo struct {
+	Endpoint   string
+	RequestID  string // X-Request-ID 헤더 값
+	Attempt    int    // 1부터 시작하는 시도 번호
+	StatusCode int    // 전송 오류이면 0
+	Elapsed    time.Duration
+	Err        error             // 전송 오류
+	Metadata   map[string]string // WithMetadata로 ctx에 담은 값
+}
+
+// hookMiddleware는 Hooks를 전송 엔진의 미들웨어로 연결합니다
+// OnResponse는 응답 본문을 다 읽고 닫을 때 호출되므로 Elapsed에 본문 읽기 시간이 포함됩니다
+func hookMiddleware(h Hooks) client.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return client.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := client.RequestInfoFrom(req.Context())
+			if info == nil {
+				info = &client.RequestInfo{Endpoint: req.URL.Path, Attempt: 1}
+			}
+			if info.Attempt > 1 && h.OnRetry != nil {
+				h.OnRetry(info.Endpoint, info.Attempt-1)
+			}
+			if h.OnRequest != nil {
+				h.OnRequest(info.Endpoint)
+			}
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if h.OnResponse == nil {
+				return resp, err
+			}
+			r := ResponseInfo{
+				Endpoint:  info.Endpoint,
+				RequestID: info.RequestID,
+				Attempt:   info.Attempt,
+				Metadata:  info.Metadata,
+			}
+			if err != nil {
+				r.Elapsed, r.Err = time.Since(start), err
+				h.OnResponse(r)
+				return resp, err
+			}
+			r.StatusCode = resp.StatusCode
+			resp.Body = &hookBody{ReadCloser: resp.Body, done: func() {
+				r.Elapsed = time.Since(start)
+				h.OnResponse(r)
+			}}
+			return resp, nil
+		})
+	}
+}
+
+// hookBody는 응답 본문을 닫을 때 한 번 done을 호출합니다
+type hookBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *hookBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
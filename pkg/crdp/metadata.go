@@ -0,0 +1,35 @@
+package crdp
+
+import (
+	"context"
+
+	"github.com/sjrhee/crdp-cli-go/internal/client"
+)
+
+// RequestInfo는 미들웨어에서 읽을 수 있는 요청 단위 정보입니다
+type RequestInfo struct {
+	Endpoint  string            // 예: /v1/protect
+	RequestID string            // X-Request-ID 헤더 값 (재시도 간 동일)
+	Attempt   int               // 1부터 시작하는 시도 번호
+	Policy    string            // 보호 정책 이름
+	Items     int               // 요청에 포함된 데이터 개수 (단일 요청은 1)
+	Metadata  map[string]string // WithMetadata로 ctx에 담은 값 (읽기 전용)
+}
+
+// RequestInfoFrom은 미들웨어에서 req.Context()로 현재 요청의 정보를 꺼냅니다
+func RequestInfoFrom(ctx context.Context) (RequestInfo, bool) {
+	info, ok := client.RequestInfoFrom(ctx)
+	if !ok {
+		return RequestInfo{}, false
+	}
+	return RequestInfo(*info), true
+}
+
+// WithMetadata는 ctx로 보내는 요청에 key=value 메타데이터를 붙입니다
+// 메타데이터는 서버로 전송되지 않으며 미들웨어(RequestInfo.Metadata)와 Hooks(ResponseInfo.Metadata)에서 읽을 수 있습니다
+//
+//	ctx = crdp.WithMetadata(ctx, "tenant", "team-a")
+//	token, err := c.Protect(ctx, value)
+func WithMetadata(ctx context.Context, key, value string) context.Context {
+	return client.WithMetadata(ctx, key, value)
+}
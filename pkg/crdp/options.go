@@ -0,0 +1,127 @@
+package crdp
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option은 New에 넘기는 클라이언트 설정입니다
+type Option func(*settings)
+
+// settings는 Option으로 모은 설정입니다
+type settings struct {
+	policy      string
+	tls         bool
+	tlsConfig   *tls.Config
+	insecure    bool
+	tokens      TokenSource
+	maxRetries  int
+	backoff     time.Duration
+	timeout     time.Duration
+	logger      *slog.Logger
+	hooks       *Hooks
+	username    string
+	version     string
+	headers     map[string]string
+	middlewares []Middleware
+}
+
+// WithPolicy는 protect/reveal에 사용할 보호 정책(protection_policy_name)을 지정합니다 (필수)
+func WithPolicy(name string) Option {
+	return func(s *settings) { s.policy = name }
+}
+
+// WithUsername은 protect/reveal 요청에 username을 보냅니다
+// 정책의 사용자별 접근 권한에 따라 reveal 결과가 평문, 마스킹 값 또는 403이 됩니다
+func WithUsername(name string) Option {
+	return func(s *settings) { s.username = name }
+}
+
+// WithExternalVersion은 토큰 밖에 키 버전을 보관하는 정책에서 reveal 요청에 보낼 external_version을 지정합니다
+func WithExternalVersion(version string) Option {
+	return func(s *settings) { s.version = version }
+}
+
+// WithTLS는 HTTPS를 사용하고 서버 인증서를 시스템 인증서 저장소로 검증합니다
+func WithTLS() Option {
+	return func(s *settings) { s.tls = true }
+}
+
+// WithTLSConfig는 HTTPS를 사용하고 주어진 TLS 설정(루트 인증서, 클라이언트 인증서 등)을 적용합니다
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(s *settings) {
+		s.tls = true
+		s.tlsConfig = cfg
+	}
+}
+
+// WithInsecureSkipVerify는 HTTPS를 사용하되 서버 인증서를 검증하지 않습니다
+// 자체 서명 인증서를 쓰는 테스트 환경에서만 사용하세요
+func WithInsecureSkipVerify() Option {
+	return func(s *settings) {
+		s.tls = true
+		s.insecure = true
+	}
+}
+
+// WithJWT는 요청마다 src에서 토큰을 가져와 Authorization: Bearer 헤더로 보냅니다
+func WithJWT(src TokenSource) Option {
+	return func(s *settings) { s.tokens = src }
+}
+
+// WithRetry는 전송 오류와 일시적 오류 응답(429, 502, 503, 504)을 최대 maxRetries번 재시도합니다
+// backoff는 첫 재시도 대기 시간이며 재시도마다 두 배로 늘어납니다 (기본: 재시도 없음)
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(s *settings) {
+		s.maxRetries = maxRetries
+		s.backoff = backoff
+	}
+}
+
+// WithTimeout은 요청별 타임아웃을 지정합니다 (기본 10초, 재시도는 각각 별도 타임아웃)
+func WithTimeout(d time.Duration) Option {
+	return func(s *settings) { s.timeout = d }
+}
+
+// WithLogger는 요청/재시도/오류 로그를 기록할 로거를 지정합니다 (기본: 기록하지 않음)
+func WithLogger(l *slog.Logger) Option {
+	return func(s *settings) { s.logger = l }
+}
+
+// WithHooks는 요청 전송, 응답 수신, 재시도 시 호출할 함수를 지정합니다
+func WithHooks(h Hooks) Option {
+	return func(s *settings) { s.hooks = &h }
+}
+
+// WithHeaders는 모든 요청에 고정 HTTP 헤더를 추가합니다 (API 게이트웨이 키, 테넌트 ID 등)
+// 여러 번 지정하면 합쳐지며, 같은 이름은 나중 값이 사용됩니다
+func WithHeaders(headers map[string]string) Option {
+	return func(s *settings) {
+		if s.headers == nil {
+			s.headers = make(map[string]string, len(headers))
+		}
+		for k, v := range headers {
+			s.headers[k] = v
+		}
+	}
+}
+
+// Middleware는 HTTP 요청 전송을 감싸는 함수입니다 (요청 서명, 헤더 추가, 계측 등)
+// 먼저 지정한 미들웨어가 바깥쪽에서 실행되며, RequestInfoFrom(req.Context())로 요청 정보를 읽을 수 있습니다
+// 요청을 바꿀 때는 req.Clone으로 복사한 뒤 바꿉니다
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc는 함수를 http.RoundTripper로 사용합니다 (미들웨어 작성용)
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip은 http.RoundTripper 구현입니다
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware는 요청 전송에 미들웨어를 추가합니다 (재시도마다 각각 실행됨)
+func WithMiddleware(mw ...Middleware) Option {
+	return func(s *settings) { s.middlewares = append(s.middlewares, mw...) }
+}
@@ -0,0 +1,24 @@
+package crdp
+
+import "context"
+
+// TokenSource는 요청마다 보낼 JWT 토큰을 제공합니다
+// 토큰을 갱신해야 하면 구현에서 캐시와 만료 처리를 합니다 (여러 고루틴에서 동시에 호출됨)
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc는 함수를 TokenSource로 사용합니다
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token은 TokenSource 구현입니다
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticToken은 항상 같은 토큰을 반환하는 TokenSource입니다
+func StaticToken(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		return token, nil
+	})
+}